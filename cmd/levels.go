@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dasvh/go-learn-vim/internal/levelcheck"
+)
+
+// levelsUsage explains the levels command
+const levelsUsage = `Usage: go-learn-vim levels validate <path>
+
+Checks the level file, the pack directory or the directory of packs at the path. Every target has to be
+reachable with the allowed motions within its par, problems are reported as file:line: message
+and make the command exit with status 1.`
+
+// runLevels runs the levels command with its arguments and returns the exit status,
+// 2 when the arguments are wrong
+func runLevels(args []string, stdout, stderr io.Writer) int {
+	if len(args) != 2 || args[0] != "validate" {
+		_, _ = fmt.Fprintln(stderr, levelsUsage)
+		return 2
+	}
+
+	report := levelcheck.Check(args[1])
+	for _, result := range report.Levels {
+		_, _ = fmt.Fprintf(stdout, "%s: level %d %q, minimum keystrokes %s, par %s\n",
+			result.File, result.Level.Number, result.Level.Description, joinInts(result.Minimum, keystrokesText), joinPars(result))
+	}
+	for _, problem := range report.Problems {
+		_, _ = fmt.Fprintln(stdout, problem)
+	}
+	_, _ = fmt.Fprintf(stdout, "%d levels checked, %d problems\n", len(report.Levels), len(report.Problems))
+	if report.Failed() {
+		return 1
+	}
+	return 0
+}
+
+// keystrokesText returns the keystrokes of a target, zero is shown as - as a target that cannot be reached
+// has no minimum and a target without a par has no par
+func keystrokesText(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return fmt.Sprint(n)
+}
+
+// joinPars returns the pars of the targets of the level, a target without a par has none
+func joinPars(result levelcheck.Result) string {
+	pars := make([]int, len(result.Level.Targets))
+	for i, target := range result.Level.Targets {
+		pars[i] = target.Par
+	}
+	return joinInts(pars, keystrokesText)
+}
+
+// joinInts returns the numbers formatted by format and separated by commas
+func joinInts(numbers []int, format func(int) string) string {
+	texts := make([]string, len(numbers))
+	for i, n := range numbers {
+		texts[i] = format(n)
+	}
+	return strings.Join(texts, ", ")
+}
@@ -5,21 +5,33 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/dasvh/go-learn-vim/internal/app"
 	"github.com/dasvh/go-learn-vim/internal/storage"
+	"github.com/dasvh/go-learn-vim/levels"
 	"os"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "levels" {
+		os.Exit(runLevels(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	repo, err := storage.NewJSONRepository("adventure.json")
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		exit(err)
 	}
 
-	program := tea.NewProgram(app.NewApp(repo))
+	// without a directory for the packs of the user only the embedded packs are loaded
+	packsDir, _ := storage.LevelPacksDir()
+
+	program := tea.NewProgram(app.NewApp(repo, levels.Packs, packsDir))
 
 	_, err = program.Run()
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		exit(err)
 	}
 }
+
+// exit prints the error and exits
+func exit(err error) {
+	_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
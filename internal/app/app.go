@@ -5,14 +5,21 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/dasvh/go-learn-vim/internal/app/controllers"
 	"github.com/dasvh/go-learn-vim/internal/app/screens/adventure"
+	"github.com/dasvh/go-learn-vim/internal/app/screens/editor"
 	"github.com/dasvh/go-learn-vim/internal/app/screens/info"
 	"github.com/dasvh/go-learn-vim/internal/app/screens/leaderboards"
 	"github.com/dasvh/go-learn-vim/internal/app/screens/menus"
 	"github.com/dasvh/go-learn-vim/internal/app/screens/selection"
 	"github.com/dasvh/go-learn-vim/internal/models"
 	"github.com/dasvh/go-learn-vim/internal/storage"
+	"io/fs"
+	"os"
+	"path/filepath"
 )
 
+// editorPack is the directory in the packs directory the level editor writes its levels to
+const editorPack = "custom"
+
 // App represents the main app structure which holds the screen controller
 // and the window size message
 type App struct {
@@ -23,11 +30,19 @@ type App struct {
 }
 
 // NewApp initializes a new App instance with a screen controller
-// and registers the respective screens
-func NewApp(repo storage.GameRepository) *App {
+// and registers the respective screens. The levels of the embedded packs and of the packs in packsDir are added
+// to the built-in ones, the level editor writes its levels to a pack in packsDir
+func NewApp(repo storage.GameRepository, embedded fs.FS, packsDir string) *App {
 	screen := controllers.NewScreen()
 	game := controllers.NewGame(repo)
 	level := controllers.NewLevel()
+	editorDir := ""
+	if packsDir != "" {
+		level.LoadPacks(embedded, os.DirFS(packsDir))
+		editorDir = filepath.Join(packsDir, editorPack)
+	} else {
+		level.LoadPacks(embedded)
+	}
 
 	app := &App{
 		sc: screen,
@@ -46,6 +61,7 @@ func NewApp(repo storage.GameRepository) *App {
 	screen.Register(models.LevelSelectionScreen, selection.NewLevelSelection(level))
 	screen.Register(models.ScoresScreen, leaderboards.NewScoresScreen(repo))
 	screen.Register(models.StatsScreen, leaderboards.NewStatsScreen(repo))
+	screen.Register(models.LevelEditorScreen, editor.NewLevelEditor(editorDir, level))
 
 	return app
 }
@@ -103,7 +119,8 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// pass the level from the level selection screen to the adventure mode screen to init the level
 	case models.SetLevelMsg:
 		if model, ok := a.sc.Screens()[models.AdventureModeScreen].(*adventure.Adventure); ok {
-			model.Update(msg)
+			_, cmd := model.Update(msg)
+			return a, cmd
 		}
 		return a, nil
 	// update the load button in the main menu screen
@@ -112,6 +129,12 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			model.UpdateLoadButton(msg.CanLoadGame)
 		}
 		return a, nil
+	// open the help screen with the sections of the cheatsheet asked for with :help
+	case models.OpenHelpMsg:
+		help := info.NewVimHelp(msg.Sections, msg.Back)
+		help.Update(a.size)
+		a.sc.Register(models.HelpScreen, help)
+		return a, a.sc.SwitchTo(models.HelpScreen)
 	// handle screen transitions with model registration
 	case models.ScreenTransitionMsg:
 		a.sc.Register(msg.Screen, msg.Model)
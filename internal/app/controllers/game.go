@@ -71,3 +71,27 @@ func (gc *Game) SaveGame(mode string, gameState models.GameState, saveID string)
 
 	return gc.repo.SaveGame(gameSave)
 }
+
+// BestRun returns the run of the best completed game of the level that fits, the best game
+// of the current player comes before the best game of all players. Games are ranked by their high score
+func (gc *Game) BestRun(level int, fits func(models.Run) bool) (models.Run, bool) {
+	scores, err := gc.repo.ComputeHighScores()
+	if err != nil {
+		return models.Run{}, false
+	}
+
+	var best models.Run
+	found := false
+	for _, score := range scores {
+		if score.Level != level || len(score.Run.Steps) == 0 || !fits(score.Run) {
+			continue
+		}
+		if gc.currentPlayer != nil && score.PlayerName == gc.currentPlayer.Name {
+			return score.Run, true
+		}
+		if !found {
+			best, found = score.Run, true
+		}
+	}
+	return best, found
+}
@@ -203,6 +203,49 @@ func Test_SaveGame(t *testing.T) {
 	}
 }
 
+func Test_BestRun(t *testing.T) {
+	run := func(x int) models.Run {
+		return models.Run{Width: 40, Height: 20, Steps: []models.RunStep{{Position: models.Position{X: x}}}}
+	}
+	repo := testutils.NewMockGameRepository()
+	repo.HighScoresData = []models.HighScore{
+		{PlayerName: "Bob", Level: 2, Score: 30000, SaveID: "other level", Run: run(3)},
+		{PlayerName: "Bob", Level: 1, Score: 20000, SaveID: "bob", Run: run(1)},
+		{PlayerName: "Carol", Level: 1, Score: 15000, SaveID: "carol"},
+		{PlayerName: "Alice", Level: 1, Score: 10000, SaveID: "alice", Run: run(2)},
+	}
+	fits := func(models.Run) bool { return true }
+
+	tests := []struct {
+		name   string
+		player *models.Player
+		fits   func(models.Run) bool
+		wantX  int
+		wantOK bool
+	}{
+		{name: "personal best", player: &models.Player{Name: "Alice"}, fits: fits, wantX: 2, wantOK: true},
+		{name: "global best", player: &models.Player{Name: "Carol"}, fits: fits, wantX: 1, wantOK: true},
+		{name: "without a player", fits: fits, wantX: 1, wantOK: true},
+		{name: "no run fits", player: &models.Player{Name: "Alice"}, fits: func(models.Run) bool { return false }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGame(repo)
+			if tt.player != nil {
+				game.SetPlayer(*tt.player)
+			}
+			got, ok := game.BestRun(1, tt.fits)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok %v, got %v", tt.wantOK, ok)
+			}
+			if ok && got.Steps[0].Position.X != tt.wantX {
+				t.Errorf("expected the run ending at x %d, got %v", tt.wantX, got.Steps[0].Position)
+			}
+		})
+	}
+}
+
 var testGameState = models.AdventureGameState{
 	WindowSize: tea.WindowSizeMsg{
 		Width:  140,
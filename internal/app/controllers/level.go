@@ -4,24 +4,75 @@ import (
 	"fmt"
 	"github.com/dasvh/go-learn-vim/internal/app/screens/adventure/level"
 	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/storage"
+	"io/fs"
 )
 
 // Level is a controller for level related actions
 type Level struct {
-	levels  map[int]models.Level
-	current models.Level
+	levels   map[int]models.Level
+	packs    []models.LevelPack
+	problems []error
+	current  models.Level
 }
 
-// NewLevel creates a new Level controller with levels
+// NewLevel creates a new Level controller with the levels built into the game
 func NewLevel() *Level {
-	return &Level{
-		levels: map[int]models.Level{
-			level.NewLevelZero().Number(): level.NewLevelZero(),
-			level.NewLevelOne().Number():  level.NewLevelOne(),
-		},
+	return &Level{levels: level.BuiltIn()}
+}
+
+// Define adds a level for every models.LevelDefinition, it returns an error
+// when a definition uses the number of another level
+func (lc *Level) Define(definitions ...models.LevelDefinition) error {
+	for _, def := range definitions {
+		if _, exists := lc.levels[def.Number]; exists {
+			return fmt.Errorf("level %d (%s) uses the number of another level", def.Number, def.Description)
+		}
+		lc.levels[def.Number] = level.NewDefinedLevel(def)
+	}
+	return nil
+}
+
+// LoadPacks adds the levels of the packs in every file system, in the order of the file systems and then
+// of the packs. A pack that fails to load or uses the number of another level is left out and kept as a problem
+func (lc *Level) LoadPacks(sources ...fs.FS) {
+	for _, fsys := range sources {
+		packs, errs := storage.LoadLevelPacks(fsys)
+		lc.problems = append(lc.problems, errs...)
+		for _, pack := range packs {
+			if err := lc.checkNumbers(pack); err != nil {
+				lc.problems = append(lc.problems, fmt.Errorf("pack %s: %w", pack.Name, err))
+				continue
+			}
+			// checked above, defining cannot fail
+			_ = lc.Define(pack.Levels...)
+			lc.packs = append(lc.packs, pack)
+		}
 	}
 }
 
+// checkNumbers returns an error when a level of the pack uses the number of another level
+func (lc *Level) checkNumbers(pack models.LevelPack) error {
+	numbers := make(map[int]bool, len(pack.Levels))
+	for _, def := range pack.Levels {
+		if _, exists := lc.levels[def.Number]; exists || numbers[def.Number] {
+			return fmt.Errorf("level %d (%s) uses the number of another level", def.Number, def.Description)
+		}
+		numbers[def.Number] = true
+	}
+	return nil
+}
+
+// GetPacks returns the packs the levels were loaded from, in order
+func (lc *Level) GetPacks() []models.LevelPack {
+	return lc.packs
+}
+
+// GetProblems returns the errors of the packs that could not be loaded
+func (lc *Level) GetProblems() []error {
+	return lc.problems
+}
+
 // GetLevels returns all levels
 func (lc *Level) GetLevels() map[int]models.Level {
 	return lc.levels
@@ -37,9 +88,10 @@ func (lc *Level) SetLevel(lvl models.Level) {
 	lc.current = lvl
 }
 
-// InitCurrentLevel initializes the current level with the given width and height
-func (lc *Level) InitCurrentLevel(width, height int) {
-	lc.current.Init(width, height)
+// InitCurrentLevel initializes the current level with the given width and height,
+// it returns an error when the level cannot be played with them
+func (lc *Level) InitCurrentLevel(width, height int) error {
+	return lc.current.Init(width, height)
 }
 
 // GetCurrentLevel returns the current level
@@ -52,6 +104,15 @@ func (lc *Level) GetLevelNumber() int {
 	return lc.current.Number()
 }
 
+// Par returns the fewest keystrokes the current level can be completed in and whether
+// it has a par, only a models.ParLevel has one
+func (lc *Level) Par() (int, bool) {
+	if level, ok := lc.current.(models.ParLevel); ok {
+		return level.Par()
+	}
+	return 0, false
+}
+
 // RestoreLevel restores the level state from a saved state
 func (lc *Level) RestoreLevel(state models.SavedLevel) error {
 	if state.Width <= 0 || state.Height <= 0 {
@@ -79,11 +140,15 @@ func (lc *Level) InitOrResizeLevel(width, height int) error {
 	}
 
 	if !lc.current.InProgress() {
-		lc.InitCurrentLevel(width, height)
-		return nil
+		return lc.InitCurrentLevel(width, height)
 	}
 
-	params := models.SavedLevel{
+	return lc.current.Restore(lc.SaveState(width, height))
+}
+
+// SaveState returns the models.SavedLevel of the current level with the given width and height
+func (lc *Level) SaveState(width, height int) models.SavedLevel {
+	state := models.SavedLevel{
 		Number:         lc.current.Number(),
 		Width:          width,
 		Height:         height,
@@ -93,5 +158,14 @@ func (lc *Level) InitOrResizeLevel(width, height int) error {
 		Completed:      lc.current.IsCompleted(),
 		InProgress:     lc.current.InProgress(),
 	}
-	return lc.current.Restore(params)
+	if marking, ok := lc.current.(models.MarkingLevel); ok {
+		state.Marks = marking.Marks()
+	}
+	if editing, ok := lc.current.(models.EditingLevel); ok {
+		state.Lines = editing.Text()
+	}
+	if history, ok := lc.current.(models.HistoryLevel); ok {
+		state.Changes, state.CurrentChange = history.Changes()
+	}
+	return state
 }
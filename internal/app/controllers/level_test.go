@@ -1,8 +1,13 @@
 package controllers
 
 import (
-	"github.com/dasvh/go-learn-vim/internal/models"
+	"fmt"
+	"maps"
+	"slices"
 	"testing"
+	"testing/fstest"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
 )
 
 func Test_NewLevel(t *testing.T) {
@@ -12,7 +17,7 @@ func Test_NewLevel(t *testing.T) {
 	}{
 		{
 			name:       "Initialize Level controller",
-			wantLevels: []int{0, 1},
+			wantLevels: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
 		},
 	}
 
@@ -32,44 +37,18 @@ func Test_NewLevel(t *testing.T) {
 }
 
 func Test_LevelGetters(t *testing.T) {
-	tests := []struct {
-		name         string
-		setup        func() *Level
-		wantLevels   int
-		wantLevelNum int
-	}{
-		{
-			name: "Get levels and current level for level 0",
-			setup: func() *Level {
-				lc := NewLevel()
-				lc.SetLevel(lc.GetLevels()[0])
-				return lc
-			},
-			wantLevels:   2,
-			wantLevelNum: 0,
-		},
-		{
-			name: "Get levels and current level for level 1",
-			setup: func() *Level {
-				lc := NewLevel()
-				lc.SetLevel(lc.GetLevels()[1])
-				return lc
-			},
-			wantLevels:   2,
-			wantLevelNum: 1,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			lc := tt.setup()
+	levels := NewLevel().GetLevels()
+	for _, number := range slices.Sorted(maps.Keys(levels)) {
+		t.Run(fmt.Sprintf("Get levels and current level for level %d", number), func(t *testing.T) {
+			lc := NewLevel()
+			lc.SetLevel(lc.GetLevels()[number])
 
-			if lc.GetLevelsCount() != tt.wantLevels {
-				t.Errorf("expected %d levels, got %d", tt.wantLevels, lc.GetLevelsCount())
+			if lc.GetLevelsCount() != len(levels) {
+				t.Errorf("expected %d levels, got %d", len(levels), lc.GetLevelsCount())
 			}
 
-			if lc.GetLevelNumber() != tt.wantLevelNum {
-				t.Errorf("expected current level number %d, got %d", tt.wantLevelNum, lc.GetLevelNumber())
+			if lc.GetLevelNumber() != number {
+				t.Errorf("expected current level number %d, got %d", number, lc.GetLevelNumber())
 			}
 		})
 	}
@@ -151,6 +130,17 @@ func Test_InitOrResizeLevel(t *testing.T) {
 			height:  20,
 			wantErr: false,
 		},
+		{
+			name: "Error on initializing a level that does not fit",
+			setup: func() *Level {
+				lc := NewLevel()
+				lc.SetLevel(lc.GetLevels()[1])
+				return lc
+			},
+			width:   6,
+			height:  4,
+			wantErr: true,
+		},
 		{
 			name: "Error on resizing with no current level",
 			setup: func() *Level {
@@ -173,3 +163,145 @@ func Test_InitOrResizeLevel(t *testing.T) {
 		})
 	}
 }
+
+func Test_SaveStateMarks(t *testing.T) {
+	lc := NewLevel()
+	lc.SetLevel(lc.GetLevels()[7])
+	lc.InitCurrentLevel(80, 20)
+
+	lc.GetCurrentLevel().PlayerMove(models.Motion{Kind: models.MotionDown, Count: 3, Keys: []string{"3", "j"}})
+	lc.GetCurrentLevel().PlayerMove(models.Motion{Kind: models.MotionSetMark, Char: 'a', Keys: []string{"m", "a"}})
+
+	state := lc.SaveState(80, 20)
+	want := models.Position{X: 0, Y: 3}
+	if state.Marks["a"] != want {
+		t.Fatalf("expected mark a at %v, got %v", want, state.Marks)
+	}
+
+	restored := NewLevel()
+	if err := restored.RestoreLevel(state); err != nil {
+		t.Fatalf("RestoreLevel() error = %v", err)
+	}
+	if got := restored.SaveState(80, 20).Marks["a"]; got != want {
+		t.Errorf("expected restored mark a at %v, got %v", want, got)
+	}
+}
+
+func Test_SaveStateLines(t *testing.T) {
+	lc := NewLevel()
+	lc.SetLevel(lc.GetLevels()[9])
+	lc.InitCurrentLevel(80, 20)
+
+	inserting := lc.GetCurrentLevel().(models.InsertingLevel)
+	inserting.StartInsert(models.Insert{Kind: models.InsertBefore, Keys: []string{"I"}})
+	inserting.TypeKey("A")
+	inserting.TypeKey(" ")
+	inserting.StopInsert()
+
+	state := lc.SaveState(80, 20)
+	if len(state.Lines) == 0 || state.Lines[0] != "A Insert mode types words, esc goes back to normal mode." {
+		t.Fatalf("expected the typed text to be saved, got %q", state.Lines)
+	}
+
+	restored := NewLevel()
+	if err := restored.RestoreLevel(state); err != nil {
+		t.Fatalf("RestoreLevel() error = %v", err)
+	}
+	if got := restored.SaveState(80, 20).Lines; !slices.Equal(got, state.Lines) {
+		t.Errorf("expected restored lines %q, got %q", state.Lines, got)
+	}
+}
+
+func Test_SaveStateChanges(t *testing.T) {
+	lc := NewLevel()
+	lc.SetLevel(lc.GetLevels()[11])
+	lc.InitCurrentLevel(80, 20)
+
+	history := lc.GetCurrentLevel().(models.HistoryLevel)
+	history.ApplyOperation(models.Operation{Operator: models.OperatorDelete, Linewise: true, Keys: []string{"d", "d"}})
+	history.ApplyOperation(models.Operation{Operator: models.OperatorDelete, Linewise: true, Keys: []string{"d", "d"}})
+	history.ApplyHistory(models.History{Kind: models.HistoryUndo, Keys: []string{"u"}})
+
+	state := lc.SaveState(80, 20)
+	if len(state.Changes) != 3 || state.CurrentChange != 1 {
+		t.Fatalf("expected 3 changes at change 1, got %d at change %d", len(state.Changes), state.CurrentChange)
+	}
+
+	// a restored level can still undo and redo
+	restored := NewLevel()
+	if err := restored.RestoreLevel(state); err != nil {
+		t.Fatalf("RestoreLevel() error = %v", err)
+	}
+	restoredHistory := restored.GetCurrentLevel().(models.HistoryLevel)
+	if result := restoredHistory.ApplyHistory(models.History{Kind: models.HistoryRedo, Keys: []string{"ctrl+r"}}); !result.ValidMove {
+		t.Fatalf("expected redo to be valid, got %q", result.InstructionMessage)
+	}
+	if got := restoredHistory.Text(); !slices.Equal(got, state.Changes[2].Lines) {
+		t.Errorf("expected the redone text %q, got %q", state.Changes[2].Lines, got)
+	}
+	restoredHistory.ApplyHistory(models.History{Kind: models.HistoryUndo, Count: 2, Keys: []string{"2", "u"}})
+	if got := restoredHistory.Text(); !slices.Equal(got, state.Changes[0].Lines) {
+		t.Errorf("expected the text the level started with %q, got %q", state.Changes[0].Lines, got)
+	}
+}
+
+func Test_LevelDefine(t *testing.T) {
+	def := models.LevelDefinition{
+		Number:      100,
+		Description: "Defined level",
+		Lesson:      "hjkl",
+		Text:        []string{"abc"},
+		Allowed:     models.BasicMotions,
+		Targets:     []models.TargetDefinition{{Position: models.Position{X: 2}}},
+	}
+
+	lc := NewLevel()
+	if err := lc.Define(def); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if lvl, ok := lc.GetLevels()[100]; !ok || lvl.Description() != def.Description {
+		t.Errorf("expected level 100 to be the defined level, got %v", lvl)
+	}
+
+	def.Number = 1
+	if err := lc.Define(def); err == nil {
+		t.Errorf("expected an error for a definition that uses the number of level 1")
+	}
+	if lc.GetLevels()[1].Description() == def.Description {
+		t.Errorf("expected level 1 to be kept")
+	}
+}
+
+func Test_LevelLoadPacks(t *testing.T) {
+	level := func(number int) string {
+		return fmt.Sprintf("number = %d\ndescription = \"Level %d\"\nlesson = \"l\"\nallowed = [\"l\"]\n"+
+			"text = \"ab\"\n\n[[targets]]\nposition = [1, 0]\n", number, number)
+	}
+	fsys := fstest.MapFS{
+		"first/pack.toml":  {Data: []byte(`name = "First"` + "\norder = 1")},
+		"first/a.toml":     {Data: []byte(level(100))},
+		"first/b.toml":     {Data: []byte(level(101))},
+		"clash/pack.toml":  {Data: []byte(`name = "Clash"` + "\norder = 2")},
+		"clash/a.toml":     {Data: []byte(level(102))},
+		"clash/b.toml":     {Data: []byte(level(3))},
+		"broken/pack.toml": {Data: []byte(`author = "nobody"`)},
+	}
+
+	lc := NewLevel()
+	lc.LoadPacks(fsys)
+
+	if len(lc.GetPacks()) != 1 || lc.GetPacks()[0].Name != "First" {
+		t.Errorf("expected only the pack First, got %+v", lc.GetPacks())
+	}
+	for _, number := range []int{100, 101} {
+		if _, exists := lc.GetLevels()[number]; !exists {
+			t.Errorf("expected level %d of the pack First to be added", number)
+		}
+	}
+	if _, exists := lc.GetLevels()[102]; exists {
+		t.Errorf("expected no level of the pack Clash to be added")
+	}
+	if len(lc.GetProblems()) != 2 {
+		t.Errorf("expected a problem for the packs Broken and Clash, got %v", lc.GetProblems())
+	}
+}
@@ -2,30 +2,52 @@ package adventure
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/dasvh/go-learn-vim/internal/app/controllers"
+	"github.com/dasvh/go-learn-vim/internal/components"
 	"github.com/dasvh/go-learn-vim/internal/models"
 	"github.com/dasvh/go-learn-vim/internal/views"
+	"github.com/google/uuid"
 )
 
 // TODO: there is a bug with the player position when the window is resized before starting a game
 // 		 need to send a Msg to AdventureModel before starting a game to init the size related state
 
-const gameMode = "Adventure"
+const (
+	gameMode     = "Adventure"
+	playtestMode = "Playtest"
+)
 
 // Adventure represents the adventure mode
 type Adventure struct {
-	controls   Controls
-	stats      *models.Stats
-	lc         *controllers.Level
-	gc         *controllers.Game
-	view       views.AdventureView
-	gridWidth  int
-	gridHeight int
-	saveID     string
+	controls     Controls
+	input        inputParser
+	command      components.CommandLine
+	search       action // search motion or operation on a search motion that waits for its pattern
+	macros       macroRecorder
+	replaying    bool // keys of a macro are being replayed, they are not registered again
+	aborted      bool // a replayed key failed, which stops the macro like in Vim
+	unsaved      bool // the level changed since it was last saved
+	stats        *models.Stats
+	par          int         // the par of the current level, zero when it has none
+	run          models.Run  // the moves of the current run, saved with the level
+	typed        []string    // the keys typed since the last move of the run
+	started      time.Time   // when the current run started
+	ghost        *models.Run // the best run of the level that is raced against, nil when there is none
+	ghostTicking bool        // a GhostTickMsg is on its way
+	lc           *controllers.Level
+	gc           *controllers.Game
+	view         views.AdventureView
+	gridWidth    int
+	gridHeight   int
+	saveID       string
+	playtest     bool  // the level is played from the level editor, it is never saved
+	failed       error // the current level could not be initialized, it cannot be played until it is
 }
 
 // NewAdventure creates a new Adventure instance
@@ -44,6 +66,21 @@ func NewAdventure(gc *controllers.Game, lc *controllers.Level) *Adventure {
 	}
 }
 
+// NewPlaytest creates an Adventure that plays the level from the level editor with the given size, the level is
+// never saved and leaving or completing it sends models.PlaytestDoneMsg to the editor instead of changing the screen
+func NewPlaytest(level models.Level, size tea.WindowSizeMsg) *Adventure {
+	lc := controllers.NewLevel()
+	lc.SetLevel(level)
+	a := NewAdventure(nil, lc)
+	a.playtest = true
+	a.view.SetMode(playtestMode)
+	a.view.SetPlayer("Editor")
+	a.view.Size = size
+	a.initializeLevel()
+	a.startRun()
+	return a
+}
+
 // scalePosition scales a models.Position based on the x and y scaling factors
 func scalePosition(pos models.Position, xScale, yScale float64) models.Position {
 	return models.Position{
@@ -55,39 +92,75 @@ func scalePosition(pos models.Position, xScale, yScale float64) models.Position
 // Reset the adventure mode by resetting the stats and exiting the current level
 func (a *Adventure) Reset() {
 	a.stats = models.NewStats()
+	a.par = 0
+	a.view.SetPar(0)
 	a.view.SetStats(0, 0)
 	a.saveID = ""
+	a.unsaved = false
+	a.macros = macroRecorder{}
+	a.view.SetRegisters(nil)
+	a.run = models.Run{}
+	a.typed = nil
+	a.ghost = nil
 	a.lc.ExitLevel()
 }
 
-// initializeLevel initializes the current level
+// initializeLevel initializes the current level, a level that records macros
+// shows the registers next to the map which leaves less room for the grid
 func (a *Adventure) initializeLevel() {
-	err := a.lc.InitOrResizeLevel(a.gridWidth, a.gridHeight)
-	if err != nil {
-		fmt.Println("Failed to initialize level:", err)
+	a.view.ShowRegisters(recordsMacros(a.lc.GetCurrentLevel()))
+	a.gridWidth, a.gridHeight = a.view.UpdateGridDimensions()
+	a.failed = a.lc.InitOrResizeLevel(a.gridWidth, a.gridHeight)
+	if a.failed != nil {
+		a.view.Info.SetText("%s", fmt.Sprintf("The level cannot be played: %v", a.failed))
 		return
 	}
+	a.input.Reset()
+	a.command = components.CommandLine{}
+	a.view.SetPending("")
+	a.view.SetCommandLine("")
+	a.syncMode()
 	a.view.Level.SetText("%s", fmt.Sprintf("Level: %d", a.lc.GetLevelNumber()))
 	a.view.Info.SetText("%s", a.lc.GetCurrentLevel().GetInstructions())
+	a.view.Help = a.levelHelp(a.lc.GetCurrentLevel())
+	a.updatePar()
 }
 
-// Save saves the models.AdventureGameState with models.SavedLevel and models.Stats
+// updatePar computes the par of the current level, which depends on the size of the grid,
+// and shows the keystrokes versus it
+func (a *Adventure) updatePar() {
+	a.par, _ = a.lc.Par()
+	a.view.SetPar(a.par)
+	a.view.SetStats(a.stats.TotalKeystrokes, a.stats.TimeElapsed)
+}
+
+// Save saves the models.AdventureGameState with models.SavedLevel and models.Stats, exits the level
 // and sends models.UpdateLoadButtonMsg to update the load button in the main menu
 func (a *Adventure) Save() tea.Cmd {
+	cmd := a.write()
+	a.Reset()
+	return cmd
+}
+
+// write saves the models.AdventureGameState without exiting the level, later saves of the level
+// replace it, and sends models.UpdateLoadButtonMsg to update the load button in the main menu
+func (a *Adventure) write() tea.Cmd {
+	if a.playtest {
+		a.unsaved = false
+		return nil
+	}
+	if a.saveID == "" {
+		a.saveID = uuid.NewString()
+	}
+	level := a.lc.SaveState(a.gridWidth, a.gridHeight)
+	level.Par = a.par
+	a.run.Width, a.run.Height = a.gridWidth, a.gridHeight
 	gameState := models.AdventureGameState{
 		WindowSize: a.view.Size,
-		Level: models.SavedLevel{
-			Number:         a.lc.GetLevelNumber(),
-			Width:          a.gridWidth,
-			Height:         a.gridHeight,
-			PlayerPosition: a.lc.GetCurrentLevel().GetCurrentPosition(),
-			Targets:        a.lc.GetCurrentLevel().GetTargets(),
-			CurrentTarget:  a.lc.GetCurrentLevel().GetCurrentTarget(),
-			Completed:      a.lc.GetCurrentLevel().IsCompleted(),
-			InProgress:     a.lc.GetCurrentLevel().InProgress(),
-		},
-		Stats:  *a.stats,
-		SaveID: a.saveID,
+		Level:      level,
+		Stats:      *a.stats,
+		Run:        a.run,
+		SaveID:     a.saveID,
 	}
 
 	err := a.gc.SaveGame(gameMode, gameState, a.saveID)
@@ -95,7 +168,7 @@ func (a *Adventure) Save() tea.Cmd {
 		fmt.Printf("Failed to save game state: %v\n", err)
 		return tea.Quit
 	}
-	a.Reset()
+	a.unsaved = false
 	return func() tea.Msg {
 		return models.UpdateLoadButtonMsg{CanLoadGame: true}
 	}
@@ -122,6 +195,9 @@ func Load(gc *controllers.Game, lc *controllers.Level, gameState models.GameStat
 		saveID:   ags.SaveID,
 	}
 	// update the grid dimensions
+	if lvl, exists := lc.GetLevels()[ags.Level.Number]; exists {
+		adventure.view.ShowRegisters(recordsMacros(lvl))
+	}
 	adventure.gridWidth, adventure.gridHeight = adventure.view.UpdateGridDimensions()
 
 	// calculate scaling factors
@@ -139,10 +215,20 @@ func Load(gc *controllers.Game, lc *controllers.Level, gameState models.GameStat
 		return nil, fmt.Errorf("failed to load level gameSave: %w", err)
 	}
 
+	// the run goes on from where it was saved, its time from the time played
+	adventure.run = ags.Run
+	if len(adventure.run.Steps) == 0 {
+		adventure.run.Start = adventure.lc.GetCurrentLevel().GetCurrentPosition()
+	}
+	adventure.started = time.Now().Add(-time.Duration(ags.Stats.TimeElapsed) * time.Second)
+	adventure.pickGhost()
+
 	adventure.view.SetMode(gameMode)
 	adventure.view.SetLevel(adventure.lc.GetLevelNumber())
-	adventure.view.SetStats(adventure.stats.TotalKeystrokes, adventure.stats.TimeElapsed)
+	adventure.updatePar()
 	adventure.view.SetInfo(adventure.lc.GetCurrentLevel().GetInstructions())
+	adventure.view.Help = adventure.levelHelp(adventure.lc.GetCurrentLevel())
+	adventure.syncMode()
 
 	return adventure, nil
 }
@@ -152,9 +238,9 @@ type TickMsg time.Time
 
 // Init initializes the app
 func (a *Adventure) Init() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+	return tea.Batch(tea.Tick(time.Second, func(t time.Time) tea.Msg {
 		return TickMsg(t)
-	})
+	}), a.ghostTick())
 }
 
 func (a *Adventure) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -164,72 +250,428 @@ func (a *Adventure) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 	case models.SetLevelMsg:
 		a.initializeLevel()
+		if a.failed != nil {
+			return a, nil
+		}
+		a.startRun()
+		return a, a.ghostTick()
 	case TickMsg:
 		a.stats.IncrementTime()
 		a.view.SetStats(a.stats.TotalKeystrokes, a.stats.TimeElapsed)
 		return a, tea.Tick(time.Second, func(t time.Time) tea.Msg {
 			return TickMsg(t)
 		})
+	case GhostTickMsg:
+		a.ghostTicking = false
+		return a, a.ghostTick()
 	case tea.WindowSizeMsg:
 		if msg.Width != a.view.Size.Width || msg.Height != a.view.Size.Height {
 			a.view.Size = msg
 			a.gridWidth, a.gridHeight = a.view.UpdateGridDimensions()
 			if a.lc.GetCurrentLevel() != nil {
+				failed := a.failed
 				a.initializeLevel()
+				if failed != nil && a.failed == nil {
+					// the level could not be played at the previous size, its run starts now
+					a.startRun()
+					return a, a.ghostTick()
+				}
 			}
 		}
 	case tea.KeyMsg:
-		keyString := msg.String()
-		isMotionKey := false
-		var delta models.Position
-		switch {
-		case key.Matches(msg, a.controls.MoveLeft):
-			isMotionKey = true
-			delta = models.Position{X: -1, Y: 0}
-		case key.Matches(msg, a.controls.MoveRight):
-			isMotionKey = true
-			delta = models.Position{X: 1, Y: 0}
-		case key.Matches(msg, a.controls.MoveUp):
-			isMotionKey = true
-			delta = models.Position{X: 0, Y: -1}
-		case key.Matches(msg, a.controls.MoveDown):
-			isMotionKey = true
-			delta = models.Position{X: 0, Y: 1}
-		case key.Matches(msg, a.controls.Escape):
-			saveCmd := a.Save()
-			return a, tea.Batch(saveCmd, models.ChangeScreen(models.LevelSelectionScreen))
-		case key.Matches(msg, a.controls.Quit):
-			saveCmd := a.Save()
-			return a, tea.Batch(saveCmd, tea.Quit)
+		if a.failed != nil {
+			return a.updateFailed(msg)
+		}
+		// like in Vim every typed key is recorded while recording a macro
+		a.macros.Record(msg)
+		a.typed = append(a.typed, msg.String())
+		return a.updateKey(msg)
+	}
+	return a, nil
+}
+
+// updateFailed handles a key message while the current level cannot be played,
+// the level can only be left since there is nothing to save
+func (a *Adventure) updateFailed(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, a.controls.Escape):
+		a.Reset()
+		return a, a.leave(models.LevelSelectionScreen, nil)
+	case key.Matches(msg, a.controls.Quit):
+		return a, tea.Quit
+	}
+	return a, nil
+}
+
+// updateKey handles a key message that was typed or is replayed by a macro
+func (a *Adventure) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.command.Active() {
+		return a.updateCommandLine(msg)
+	}
+	if inserting, ok := a.lc.GetCurrentLevel().(models.InsertingLevel); ok && inserting.Inserting() {
+		return a.updateInsert(msg, inserting)
+	}
+
+	switch {
+	case key.Matches(msg, a.controls.Escape) && a.input.Pending() != "":
+		// like in Vim escape cancels a count or a motion that is being typed
+		a.input.Reset()
+		a.view.SetPending("")
+		return a, nil
+	case a.input.AwaitingChar():
+		// the key is the character of a find motion, even when it is bound to another control
+	case key.Matches(msg, a.controls.Command) && a.input.Pending() == "":
+		if !a.replaying {
+			a.stats.RegisterCommandKey()
+		}
+		a.command.Open(exPrompt)
+		a.view.SetCommandLine(a.command.String())
+		return a, nil
+	case key.Matches(msg, a.controls.LevelHint(a.lc.GetCurrentLevel().AllowedMotions())) && a.input.Pending() == "":
+		return a.hint()
+	case key.Matches(msg, a.controls.Escape) && a.selecting() != nil:
+		// like in Vim escape leaves visual mode
+		return a.apply(a.selecting().StopVisual(), func() { a.stats.RegisterKey(msg.String(), true) })
+	case key.Matches(msg, a.controls.Record) && a.macroLevel() != nil:
+		// like in Vim q records a macro, the level is quit with ctrl+c instead
+	case a.replaying && (key.Matches(msg, a.controls.Escape) || key.Matches(msg, a.controls.Quit)):
+		// a macro never leaves the level
+		return a, nil
+	case key.Matches(msg, a.controls.Escape):
+		return a, a.leave(models.LevelSelectionScreen, a.Save())
+	case key.Matches(msg, a.controls.Quit):
+		saveCmd := a.Save()
+		return a, tea.Batch(saveCmd, tea.Quit)
+	}
+
+	act, isComplete := a.input.Feed(msg, a.controls)
+	a.view.SetPending(a.input.Pending())
+	if !isComplete {
+		return a, nil
+	}
+
+	// a search motion is completed by the pattern typed on the command line
+	if motion := act.searchMotion(); motion != nil {
+		a.search = act
+		a.command.Open(motion.Keys[len(motion.Keys)-1])
+		a.view.SetCommandLine(a.command.String())
+		return a, nil
+	}
+	return a.perform(act)
+}
+
+// updateCommandLine passes a key message to the command line, the keys typed
+// into it are registered apart from the motion keys. The line is either the pattern
+// of a search motion or an ex command
+func (a *Adventure) updateCommandLine(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !a.replaying {
+		a.stats.RegisterCommandKey()
+	}
+	state := a.command.Feed(msg)
+	a.view.SetCommandLine(a.command.String())
+
+	switch state {
+	case components.CommandSubmitted:
+		a.view.SetCommandLine("")
+		if a.command.Prompt() == exPrompt {
+			return a.execute(a.command.Value())
 		}
+		act := a.search
+		motion := act.searchMotion()
+		motion.Pattern = a.command.Value()
+		// like the keys of :{n} the pattern and the enter that runs the search are keys of the motion
+		keys := models.CommandLineKeys(motion.Pattern)
+		motion.Keys = slices.Concat(motion.Keys, keys)
+		if act.operation != nil {
+			act.operation.Keys = slices.Concat(act.operation.Keys, keys)
+		}
+		return a.perform(act)
+	case components.CommandCancelled:
+		a.view.SetCommandLine("")
+	}
+	return a, nil
+}
 
-		// update player action
-		result := a.lc.GetCurrentLevel().PlayerMove(delta)
+// updateInsert passes a key message to a level in insert mode, escape leaves insert mode
+// and every other key is typed into the text. The keys are registered apart from the motion keys
+func (a *Adventure) updateInsert(msg tea.KeyMsg, level models.InsertingLevel) (tea.Model, tea.Cmd) {
+	register := func() { a.stats.RegisterInsertKey() }
+	if key.Matches(msg, a.controls.Escape) || msg.Type == tea.KeyCtrlC {
+		// like in Vim ctrl+c leaves insert mode too, instead of quitting
+		return a.apply(level.StopInsert(), register)
+	}
+	return a.apply(level.TypeKey(msg.String()), register)
+}
 
-		// update app instructions
-		if result.InstructionMessage != "" {
-			a.view.SetInfo(result.InstructionMessage)
+// hint shows the first motion of the fewest keystrokes onto the current target in the info bar,
+// only a models.HintingLevel gives hints. Every hint shown is counted, a macro does not show them
+func (a *Adventure) hint() (tea.Model, tea.Cmd) {
+	if a.replaying {
+		return a, nil
+	}
+	level := a.lc.GetCurrentLevel()
+	hinting, ok := level.(models.HintingLevel)
+	if !ok {
+		a.view.SetInfo(fmt.Sprintf("There are no hints in this level. %s", level.GetInstructions()))
+		return a, nil
+	}
+	motion, ok := hinting.Hint()
+	if !ok {
+		a.view.SetInfo(fmt.Sprintf("There is no hint from here. %s", level.GetInstructions()))
+		return a, nil
+	}
+	a.stats.RegisterHint()
+	a.unsaved = true
+	a.view.SetInfo(fmt.Sprintf("Hint: try %s. %s", motion, level.GetInstructions()))
+	return a, nil
+}
+
+// perform applies a complete action to the current level, an operation can only be applied
+// to a level that is a models.EditingLevel, an insert to a level that is a models.InsertingLevel
+// a history command to a level that is a models.HistoryLevel and a macro command to a level that is a models.MacroLevel
+func (a *Adventure) perform(act action) (tea.Model, tea.Cmd) {
+	level := a.lc.GetCurrentLevel()
+	if act.macro != nil {
+		macro, ok := level.(models.MacroLevel)
+		if !ok {
+			a.view.SetInfo(fmt.Sprintf("%s is not used in this level. %s", act.macro, level.GetInstructions()))
+			return a, nil
+		}
+		return a.performMacro(*act.macro, macro)
+	}
+	if act.history != nil {
+		history, ok := level.(models.HistoryLevel)
+		if !ok {
+			a.view.SetInfo(fmt.Sprintf("%s is not used in this level. %s", act.history, level.GetInstructions()))
+			return a, nil
 		}
-		if result.Completed {
-			saveCmd := a.Save()
-			return a, tea.Batch(saveCmd, models.ChangeScreen(models.MainMenuScreen))
+		return a.apply(history.ApplyHistory(*act.history), func() { a.stats.RegisterHistory(*act.history) })
+	}
+	if act.visual != nil {
+		visual, ok := level.(models.VisualLevel)
+		if !ok {
+			a.view.SetInfo(fmt.Sprintf("%s is not used in this level. %s", act.visual, level.GetInstructions()))
+			return a, nil
+		}
+		return a.apply(visual.StartVisual(*act.visual), func() { a.stats.RegisterVisual(*act.visual) })
+	}
+	if act.insert != nil {
+		inserting, ok := level.(models.InsertingLevel)
+		if !ok {
+			a.view.SetInfo(fmt.Sprintf("%s is not used in this level. %s", act.insert, level.GetInstructions()))
+			return a, nil
 		}
+		return a.apply(inserting.StartInsert(*act.insert), func() { a.stats.RegisterInsert(*act.insert) })
+	}
+	if act.operation == nil {
+		return a.apply(level.PlayerMove(act.motion), func() { a.stats.RegisterMotion(act.motion) })
+	}
+
+	editing, ok := level.(models.EditingLevel)
+	if !ok {
+		a.view.SetInfo(fmt.Sprintf("%s is not used in this level. %s", act.operation.Operator, level.GetInstructions()))
+		return a, nil
+	}
+	return a.apply(editing.ApplyOperation(*act.operation), func() { a.stats.RegisterOperation(*act.operation) })
+}
 
-		// only register keystrokes if it's a motion key and the move is valid
-		if isMotionKey && result.ValidMove {
-			a.stats.RegisterKey(keyString, true)
+// performMacro starts or stops recording keys into a register, or replays the keys recorded in a register
+func (a *Adventure) performMacro(macro models.Macro, level models.MacroLevel) (tea.Model, tea.Cmd) {
+	if a.replaying {
+		// unlike in Vim a macro cannot record or replay another macro
+		a.aborted = true
+		return a, nil
+	}
+	register := func() { a.stats.RegisterMacro(macro) }
+	if macro.Kind == models.MacroRecord {
+		result := level.ApplyMacro(macro)
+		switch _, recording := a.macros.Recording(); {
+		case recording:
+			// q stops recording even when the level does not allow it
+			a.macros.Stop()
+			a.view.SetRegisters(a.macros.Registers())
+		case result.ValidMove:
+			a.macros.Start(macro.Register)
 		}
+		return a.apply(result, register)
+	}
 
-		a.view.SetStats(a.stats.TotalKeystrokes, a.stats.TimeElapsed)
+	keys, ok := a.macros.Keys(macro.Register)
+	if !ok {
+		a.view.SetInfo(fmt.Sprintf("There is no macro in register %c. %s", macro.Register, level.GetInstructions()))
 		return a, nil
 	}
+	result := level.ApplyMacro(macro)
+	if _, cmd := a.apply(result, register); !result.ValidMove || cmd != nil {
+		return a, cmd
+	}
+
+	// the keys are replayed count times, or until one of them fails
+	a.replaying = true
+	a.aborted = false
+	level.SetReplaying(true)
+	var cmd tea.Cmd
+replay:
+	for range max(macro.Count, 1) {
+		for _, msg := range keys {
+			if _, cmd = a.updateKey(msg); cmd != nil || a.aborted {
+				break replay
+			}
+		}
+	}
+	level.SetReplaying(false)
+	a.replaying = false
+	return a, cmd
+}
+
+// apply shows the result of a motion or an operation, register is called to count its keys
+func (a *Adventure) apply(result models.PlayerMovement, register func()) (tea.Model, tea.Cmd) {
+	// update app instructions
+	if result.InstructionMessage != "" {
+		a.view.SetInfo(result.InstructionMessage)
+	}
+	// the move is recorded before a completed level is saved with its run
+	if result.ValidMove || result.Completed {
+		a.recordStep(a.runPosition(result))
+	}
+	if result.Completed && a.playtest {
+		// unlike in a game the keys of the last motion are counted, the editor compares them with the par
+		if !a.replaying {
+			register()
+		}
+		done := models.PlaytestDoneMsg{Completed: true, Keystrokes: a.stats.TotalKeystrokes}
+		a.Reset()
+		return a, func() tea.Msg { return done }
+	}
+	if result.Completed {
+		saveCmd := a.Save()
+		return a, tea.Batch(saveCmd, models.ChangeScreen(models.MainMenuScreen))
+	}
+
+	// only register keystrokes if the move is valid, a motion with a count is registered as a whole,
+	// the keys a macro replays were registered when they were recorded
+	if result.ValidMove && !a.replaying {
+		register()
+	}
+	if result.ValidMove {
+		a.unsaved = true
+	}
+	if !result.ValidMove && a.replaying {
+		a.aborted = true
+	}
+	a.syncMode()
+
+	a.view.SetStats(a.stats.TotalKeystrokes, a.stats.TimeElapsed)
 	return a, nil
 }
 
+// leave changes to the screen once the level was exited, cmd is run along with it.
+// A playtest goes back to the level editor instead
+func (a *Adventure) leave(to models.Screen, cmd tea.Cmd) tea.Cmd {
+	if a.playtest {
+		return func() tea.Msg { return models.PlaytestDoneMsg{} }
+	}
+	return tea.Batch(cmd, models.ChangeScreen(to))
+}
+
+// screen returns the screen the level is played on
+func (a *Adventure) screen() models.Screen {
+	if a.playtest {
+		return models.LevelEditorScreen
+	}
+	return models.AdventureModeScreen
+}
+
+// syncMode shows the Vim mode the current level is in and tells the parser whether text is being selected
+func (a *Adventure) syncMode() {
+	level := a.lc.GetCurrentLevel()
+	var message string
+	if visual, ok := level.(models.VisualLevel); ok {
+		message = visual.VisualMode().Message()
+	}
+	if inserting, ok := level.(models.InsertingLevel); ok && inserting.Inserting() {
+		message = models.InsertModeMessage
+	}
+	register, recording := a.macros.Recording()
+	if recording {
+		// like in Vim the register being recorded into is shown after the mode
+		message = strings.TrimSpace(message + " " + models.RecordingMessage(register))
+	}
+	a.input.SetRecording(recording)
+	a.input.SetVisual(a.selecting() != nil)
+	a.view.SetModeMessage(message)
+}
+
+// selecting returns the current level when text is being selected in it, nil otherwise
+func (a *Adventure) selecting() models.VisualLevel {
+	if visual, ok := a.lc.GetCurrentLevel().(models.VisualLevel); ok && visual.VisualMode() != models.VisualNone {
+		return visual
+	}
+	return nil
+}
+
+// macroLevel returns the current level when macros are recorded in it, nil otherwise
+func (a *Adventure) macroLevel() models.MacroLevel {
+	if recordsMacros(a.lc.GetCurrentLevel()) {
+		return a.lc.GetCurrentLevel().(models.MacroLevel)
+	}
+	return nil
+}
+
+// recordsMacros reports whether keys can be recorded into registers in the level
+func recordsMacros(level models.Level) bool {
+	macro, ok := level.(models.MacroLevel)
+	return ok && slices.Contains(macro.AllowedMacros(), models.MacroRecord)
+}
+
+// levelHelp returns the controls of the motions, operators, inserts, visual modes, history and macro commands the level allows
+func (a *Adventure) levelHelp(level models.Level) []key.Binding {
+	var operators []models.Operator
+	if editing, ok := level.(models.EditingLevel); ok {
+		operators = editing.AllowedOperators()
+	}
+	var inserts []models.InsertKind
+	if inserting, ok := level.(models.InsertingLevel); ok {
+		inserts = inserting.AllowedInserts()
+	}
+	var visualModes []models.VisualMode
+	if visual, ok := level.(models.VisualLevel); ok {
+		visualModes = visual.AllowedVisualModes()
+	}
+	var history []models.HistoryKind
+	if undoing, ok := level.(models.HistoryLevel); ok {
+		history = undoing.AllowedHistory()
+	}
+	var macros []models.MacroKind
+	if macro, ok := level.(models.MacroLevel); ok {
+		macros = macro.AllowedMacros()
+	}
+	return a.controls.LevelHelp(level.AllowedMotions(), operators, inserts, visualModes, history, macros)
+}
+
 // View renders the entire app screen
 func (a *Adventure) View() string {
-	// render the game
-	game := a.lc.GetCurrentLevel().Render()
+	if a.failed != nil {
+		// there is no game to render, only the error in the info
+		a.view.GameMap.Field = nil
+		a.view.GameMap.Overlays = nil
+		return a.view.RenderScreen()
+	}
+	// render the game, levels larger than the grid only show the rows in their viewport
+	level := a.lc.GetCurrentLevel()
+	game := level.Render()
+	var overlays [][]models.Overlay
+	if highlighting, ok := level.(models.HighlightingLevel); ok {
+		overlays = highlighting.Overlays()
+	}
+	if cell, ok := a.ghostCell(); ok {
+		overlays = withGhost(overlays, game, cell)
+	}
+	if scrolling, ok := level.(models.ScrollingLevel); ok {
+		game = views.Frame(game, scrolling.Viewport())
+		overlays = views.Frame(overlays, scrolling.Viewport())
+	}
 	a.view.GameMap.Field = game
+	a.view.GameMap.Overlays = overlays
 	return a.view.RenderScreen()
 }
@@ -0,0 +1,180 @@
+package adventure
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dasvh/go-learn-vim/internal/app/controllers"
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/testutils"
+)
+
+// newLevelOne returns an Adventure that plays level one for the player on a small window
+func newLevelOne(repo *testutils.MockGameRepository) (*Adventure, *controllers.Level) {
+	gc := controllers.NewGame(repo)
+	gc.SetPlayer(models.Player{ID: "1", Name: "player"})
+	lc := controllers.NewLevel()
+	lc.SetLevel(lc.GetLevels()[1])
+
+	a := NewAdventure(gc, lc)
+	a.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	a.Update(models.SetLevelMsg{LevelNumber: 1})
+	return a, lc
+}
+
+// followHints asks for hints and types the motions they suggest until the level is completed
+func followHints(t *testing.T, a *Adventure, lc *controllers.Level) {
+	t.Helper()
+	hints := 0
+	for lc.GetCurrentLevel().InProgress() {
+		if hints > 200 {
+			t.Fatalf("expected the hints to complete the level, at %v", lc.GetCurrentLevel().GetCurrentPosition())
+		}
+		msg := tea.KeyMsg{Type: tea.KeyCtrlH}
+		if hints%2 == 1 {
+			// the level does not search backward, ? asks for a hint as well
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}}
+		}
+		a.Update(msg)
+		hints++
+		info := a.view.Info.Text
+		if !strings.HasPrefix(info, "Hint: try ") {
+			t.Fatalf("expected a hint, got %q", info)
+		}
+		if a.stats.Hints != hints {
+			t.Fatalf("expected %d hints to be registered, got %d", hints, a.stats.Hints)
+		}
+		keystrokes := a.stats.TotalKeystrokes
+		motion, _, _ := strings.Cut(strings.TrimPrefix(info, "Hint: try "), ". ")
+		for _, r := range motion {
+			a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		if strings.HasPrefix(motion, ":") {
+			a.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		}
+		if lc.GetCurrentLevel().InProgress() && a.stats.TotalKeystrokes == keystrokes && !strings.HasPrefix(motion, ":") {
+			t.Fatalf("expected the hinted %s to move the cursor", motion)
+		}
+	}
+}
+
+func Test_AdventureHint(t *testing.T) {
+	a, lc := newLevelOne(testutils.NewMockGameRepository())
+
+	// following the hints leads through both mazes of the level
+	followHints(t, a, lc)
+	if !lc.GetCurrentLevel().IsCompleted() {
+		t.Errorf("expected the level to be completed")
+	}
+}
+
+func Test_AdventureGhost(t *testing.T) {
+	repo := testutils.NewMockGameRepository()
+	a, lc := newLevelOne(repo)
+	if a.ghost != nil {
+		t.Fatalf("expected no ghost without a completed run")
+	}
+	start := lc.GetCurrentLevel().GetCurrentPosition()
+	followHints(t, a, lc)
+
+	if len(repo.GameSavesData) != 1 {
+		t.Fatalf("expected the completed run to be saved, got %d saves", len(repo.GameSavesData))
+	}
+	save := repo.GameSavesData[0]
+	run := save.GameState.(models.AdventureGameState).Run
+	if run.Start != start || len(run.Steps) == 0 {
+		t.Fatalf("expected a run from %v with steps, got %+v", start, run)
+	}
+	targets := lc.GetCurrentLevel().GetTargets()
+	if last := run.Steps[len(run.Steps)-1].Position; last != targets[len(targets)-1].Position {
+		t.Errorf("expected the run to end on the target %v, got %v", targets[len(targets)-1].Position, last)
+	}
+
+	// the completed run is raced against when the level is played again
+	repo.HighScoresData = []models.HighScore{{PlayerName: "player", Level: 1, Score: 20000, SaveID: save.ID, Run: run}}
+	a.Update(models.SetLevelMsg{LevelNumber: 1})
+	if a.ghost == nil {
+		t.Fatalf("expected the best run to be raced against")
+	}
+	cell, ok := a.ghostCell()
+	if !ok || cell != run.Start {
+		t.Fatalf("expected the ghost on %v, got %v (%v)", run.Start, cell, ok)
+	}
+	a.View()
+	if overlay := a.view.GameMap.Overlays[cell.Y][cell.X]; overlay != models.OverlayGhost {
+		t.Errorf("expected the ghost to be drawn on %v, got %v", cell, overlay)
+	}
+
+	// a grid of another size does not fit the run
+	a.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	if _, ok := a.ghostCell(); ok {
+		t.Errorf("expected no ghost on a grid the run does not fit")
+	}
+}
+
+func Test_AdventureGhostTick(t *testing.T) {
+	a, _ := newLevelOne(testutils.NewMockGameRepository())
+	step := func(elapsed time.Duration) models.RunStep {
+		return models.RunStep{Elapsed: elapsed, Position: models.Position{X: 1}}
+	}
+
+	tests := []struct {
+		name     string
+		ghost    *models.Run
+		ticking  bool
+		wantTick bool
+	}{
+		{name: "without a ghost", ghost: nil},
+		{name: "a ghost with moves left", ghost: &models.Run{Steps: []models.RunStep{step(time.Hour)}}, wantTick: true},
+		{name: "a ghost whose run has ended", ghost: &models.Run{Steps: []models.RunStep{step(0)}}},
+		{name: "a tick on its way", ghost: &models.Run{Steps: []models.RunStep{step(time.Hour)}}, ticking: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.started = time.Now()
+			a.ghost = tt.ghost
+			a.ghostTicking = tt.ticking
+			if got := a.ghostTick() != nil; got != tt.wantTick {
+				t.Errorf("expected a tick %v, got %v", tt.wantTick, got)
+			}
+		})
+	}
+
+	// a tick that arrives after the run of the ghost has ended issues no other tick
+	a.ghost = &models.Run{Steps: []models.RunStep{step(0)}}
+	a.ghostTicking = true
+	if _, cmd := a.Update(GhostTickMsg(time.Now())); cmd != nil || a.ghostTicking {
+		t.Errorf("expected the ticks to stop once the run of the ghost has ended")
+	}
+}
+
+func Test_AdventureLevelDoesNotFit(t *testing.T) {
+	lc := controllers.NewLevel()
+	lc.SetLevel(lc.GetLevels()[1])
+	a := NewAdventure(controllers.NewGame(testutils.NewMockGameRepository()), lc)
+	a.Update(tea.WindowSizeMsg{Width: 10, Height: 14})
+	a.Update(models.SetLevelMsg{LevelNumber: 1})
+
+	if a.failed == nil {
+		t.Fatal("expected level one not to fit the window")
+	}
+	if view := a.View(); !strings.Contains(view, "cannot be played") {
+		t.Errorf("expected the view to show why the level cannot be played, got %q", view)
+	}
+	a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	if a.stats.TotalKeystrokes != 0 {
+		t.Errorf("expected keys to be ignored while the level cannot be played, got %d keystrokes", a.stats.TotalKeystrokes)
+	}
+
+	// the level is played once the window is large enough
+	a.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	if a.failed != nil || !lc.GetCurrentLevel().InProgress() {
+		t.Fatalf("expected level one to be played after resizing, got %v", a.failed)
+	}
+	if a.run.Start != lc.GetCurrentLevel().GetCurrentPosition() {
+		t.Errorf("expected the run to start at %v, got %v", lc.GetCurrentLevel().GetCurrentPosition(), a.run.Start)
+	}
+}
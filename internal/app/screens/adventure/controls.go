@@ -1,22 +1,79 @@
 package adventure
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
 
 // Controls represents Controls for any level in the adventure mode
 type Controls struct {
-	MoveLeft    key.Binding
-	MoveRight   key.Binding
-	MoveUp      key.Binding
-	MoveDown    key.Binding
-	WordForward key.Binding
-	WordBack    key.Binding
-	EndOfWord   key.Binding
-	StartOfLine key.Binding
-	EndOfLine   key.Binding
-	FirstLine   key.Binding
-	LastLine    key.Binding
-	Escape      key.Binding
-	Quit        key.Binding
+	MoveLeft      key.Binding
+	MoveRight     key.Binding
+	MoveUp        key.Binding
+	MoveDown      key.Binding
+	WordForward   key.Binding
+	WordBack      key.Binding
+	EndOfWord     key.Binding
+	WORDForward   key.Binding
+	WORDBack      key.Binding
+	EndOfWORD     key.Binding
+	StartOfLine   key.Binding
+	FirstNonBlank key.Binding
+	EndOfLine     key.Binding
+	FirstLine     key.Binding
+	LastLine      key.Binding
+	FindForward   key.Binding
+	FindBackward  key.Binding
+	TillForward   key.Binding
+	TillBackward  key.Binding
+	RepeatFind    key.Binding
+	ReverseFind   key.Binding
+	ScreenTop     key.Binding
+	ScreenMiddle  key.Binding
+	ScreenBottom  key.Binding
+	HalfPageDown  key.Binding
+	HalfPageUp    key.Binding
+	PageDown      key.Binding
+	PageUp        key.Binding
+	ScrollCursor  key.Binding
+	Search        key.Binding
+	SearchBack    key.Binding
+	SearchNext    key.Binding
+	SearchPrev    key.Binding
+	SetMark       key.Binding
+	JumpMarkLine  key.Binding
+	JumpMark      key.Binding
+	JumpOlder     key.Binding
+	JumpNewer     key.Binding
+	SentenceNext  key.Binding
+	SentenceBack  key.Binding
+	ParagraphNext key.Binding
+	ParagraphBack key.Binding
+	MatchPair     key.Binding
+	Delete        key.Binding
+	Change        key.Binding
+	Yank          key.Binding
+	Insert        key.Binding
+	Append        key.Binding
+	InsertStart   key.Binding
+	AppendEnd     key.Binding
+	OpenBelow     key.Binding
+	OpenAbove     key.Binding
+	VisualChar    key.Binding
+	VisualLine    key.Binding
+	VisualBlock   key.Binding
+	Undo          key.Binding
+	Redo          key.Binding
+	Repeat        key.Binding
+	Record        key.Binding
+	Replay        key.Binding
+	Command       key.Binding
+	Hint          key.Binding
+	Escape        key.Binding
+	Quit          key.Binding
 }
 
 // NewBasicControls creates a new BasicControls instance with predefined key bindings
@@ -34,6 +91,182 @@ func NewBasicControls() Controls {
 		MoveDown: key.NewBinding(
 			key.WithKeys("j"),
 			key.WithHelp("j", "move down")),
+		WordForward: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "next word")),
+		WordBack: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "previous word")),
+		EndOfWord: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "end of word")),
+		WORDForward: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "next WORD")),
+		WORDBack: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "previous WORD")),
+		EndOfWORD: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "end of WORD")),
+		StartOfLine: key.NewBinding(
+			key.WithKeys("0"),
+			key.WithHelp("0", "start of line")),
+		FirstNonBlank: key.NewBinding(
+			key.WithKeys("^"),
+			key.WithHelp("^", "first non-blank")),
+		EndOfLine: key.NewBinding(
+			key.WithKeys("$"),
+			key.WithHelp("$", "end of line")),
+		FirstLine: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("gg", "first line")),
+		LastLine: key.NewBinding(
+			key.WithKeys("G"),
+			key.WithHelp("G", "last line")),
+		FindForward: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f{char}", "find char")),
+		FindBackward: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F{char}", "find char backward")),
+		TillForward: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t{char}", "till char")),
+		TillBackward: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T{char}", "till char backward")),
+		RepeatFind: key.NewBinding(
+			key.WithKeys(";"),
+			key.WithHelp(";", "repeat find")),
+		ReverseFind: key.NewBinding(
+			key.WithKeys(","),
+			key.WithHelp(",", "repeat find reversed")),
+		ScreenTop: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "top of window")),
+		ScreenMiddle: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "middle of window")),
+		ScreenBottom: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "bottom of window")),
+		HalfPageDown: key.NewBinding(
+			key.WithKeys("ctrl+d"),
+			key.WithHelp("ctrl+d", "half page down")),
+		HalfPageUp: key.NewBinding(
+			key.WithKeys("ctrl+u"),
+			key.WithHelp("ctrl+u", "half page up")),
+		PageDown: key.NewBinding(
+			key.WithKeys("ctrl+f"),
+			key.WithHelp("ctrl+f", "page down")),
+		PageUp: key.NewBinding(
+			key.WithKeys("ctrl+b"),
+			key.WithHelp("ctrl+b", "page up")),
+		ScrollCursor: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("zz/zt/zb", "scroll to cursor")),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search forward")),
+		SearchBack: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "search backward")),
+		SearchNext: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match")),
+		SearchPrev: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "previous match")),
+		SetMark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m{a-z}", "set mark")),
+		JumpMarkLine: key.NewBinding(
+			key.WithKeys("'"),
+			key.WithHelp("'{a-z}", "mark line")),
+		JumpMark: key.NewBinding(
+			key.WithKeys("`"),
+			key.WithHelp("`{a-z}", "mark position")),
+		JumpOlder: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "older jump")),
+		// the terminal sends ctrl+i as tab
+		JumpNewer: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("ctrl+i", "newer jump")),
+		SentenceNext: key.NewBinding(
+			key.WithKeys(")"),
+			key.WithHelp(")", "next sentence")),
+		SentenceBack: key.NewBinding(
+			key.WithKeys("("),
+			key.WithHelp("(", "previous sentence")),
+		ParagraphNext: key.NewBinding(
+			key.WithKeys("}"),
+			key.WithHelp("}", "next paragraph")),
+		ParagraphBack: key.NewBinding(
+			key.WithKeys("{"),
+			key.WithHelp("{", "previous paragraph")),
+		MatchPair: key.NewBinding(
+			key.WithKeys("%"),
+			key.WithHelp("%", "matching bracket")),
+		Delete: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d{motion}", "delete")),
+		Change: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c{motion}", "change")),
+		Yank: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y{motion}", "yank")),
+		Insert: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "insert")),
+		Append: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "append")),
+		InsertStart: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "insert at line start")),
+		AppendEnd: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "append at line end")),
+		OpenBelow: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open line below")),
+		OpenAbove: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "open line above")),
+		VisualChar: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "visual")),
+		VisualLine: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "visual line")),
+		VisualBlock: key.NewBinding(
+			key.WithKeys("ctrl+v"),
+			key.WithHelp("ctrl+v", "visual block")),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo")),
+		Redo: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "redo")),
+		Repeat: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", "repeat change")),
+		Record: key.NewBinding(
+			key.WithKeys("q"),
+			key.WithHelp("q{a-z}", "record macro")),
+		Replay: key.NewBinding(
+			key.WithKeys("@"),
+			key.WithHelp("@{a-z}", "replay macro")),
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "command line")),
+		// ctrl+h always asks for a hint, ? only does when it does not search backward
+		Hint: key.NewBinding(
+			key.WithKeys("?", "ctrl+h"),
+			key.WithHelp("?", "hint")),
 		Escape: key.NewBinding(
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "back to selection")),
@@ -54,3 +287,248 @@ func (c Controls) BasicHelp() []key.Binding {
 		c.Quit,
 	}
 }
+
+// LevelHelp returns a slice of key bindings for displaying the controls of a level
+// that allows the given motions, operators, inserts, visual modes, history and macro commands.
+// The command line is open in every level.
+// In a level that records macros q is not shown to quit, like in Vim it starts recording
+// and in a level that searches backward a hint is asked for with ctrl+h instead of ?
+func (c Controls) LevelHelp(allowed []models.MotionKind, operators []models.Operator, inserts []models.InsertKind, visualModes []models.VisualMode, history []models.HistoryKind, macros []models.MacroKind) []key.Binding {
+	var bindings []key.Binding
+	for _, mb := range c.motionBindings() {
+		if slices.Contains(allowed, mb.kind) {
+			bindings = append(bindings, mb.binding)
+		}
+	}
+	for _, ob := range c.operatorBindings() {
+		if slices.Contains(operators, ob.operator) {
+			bindings = append(bindings, ob.binding)
+		}
+	}
+	for _, ib := range c.insertBindings() {
+		if slices.Contains(inserts, ib.kind) {
+			bindings = append(bindings, ib.binding)
+		}
+	}
+	for _, vb := range c.visualBindings() {
+		if slices.Contains(visualModes, vb.mode) {
+			bindings = append(bindings, vb.binding)
+		}
+	}
+	for _, hb := range c.historyBindings() {
+		if slices.Contains(history, hb.kind) {
+			bindings = append(bindings, hb.binding)
+		}
+	}
+	quit := c.Quit
+	for _, mb := range c.macroBindings() {
+		if slices.Contains(macros, mb.kind) {
+			bindings = append(bindings, mb.binding)
+		}
+	}
+	if slices.Contains(macros, models.MacroRecord) {
+		quit = key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit"))
+	}
+	return append(bindings, c.Command, c.LevelHint(allowed), c.Escape, quit)
+}
+
+// LevelHint returns the key binding that asks for a hint in a level that allows the given motions,
+// like in Vim ? searches backward when the level allows it and only ctrl+h asks for a hint
+func (c Controls) LevelHint(allowed []models.MotionKind) key.Binding {
+	if slices.Contains(allowed, models.MotionSearchBackward) {
+		return key.NewBinding(key.WithKeys("ctrl+h"), key.WithHelp("ctrl+h", "hint"))
+	}
+	return c.Hint
+}
+
+// Motion returns the models.Motion for a key message and whether the key is bound to a motion
+func (c Controls) Motion(msg tea.KeyMsg) (models.Motion, bool) {
+	for _, mb := range c.motionBindings() {
+		if key.Matches(msg, mb.binding) {
+			return models.Motion{Kind: mb.kind, Keys: []string{msg.String()}}, true
+		}
+	}
+	return models.Motion{}, false
+}
+
+// Operator returns the models.Operator for a key message and whether the key is bound to an operator
+func (c Controls) Operator(msg tea.KeyMsg) (models.Operator, bool) {
+	for _, ob := range c.operatorBindings() {
+		if key.Matches(msg, ob.binding) {
+			return ob.operator, true
+		}
+	}
+	return 0, false
+}
+
+// InsertKind returns the models.InsertKind for a key message and whether the key starts insert mode
+func (c Controls) InsertKind(msg tea.KeyMsg) (models.InsertKind, bool) {
+	for _, ib := range c.insertBindings() {
+		if key.Matches(msg, ib.binding) {
+			return ib.kind, true
+		}
+	}
+	return 0, false
+}
+
+// VisualMode returns the models.VisualMode for a key message and whether the key starts visual mode
+func (c Controls) VisualMode(msg tea.KeyMsg) (models.VisualMode, bool) {
+	for _, vb := range c.visualBindings() {
+		if key.Matches(msg, vb.binding) {
+			return vb.mode, true
+		}
+	}
+	return models.VisualNone, false
+}
+
+// HistoryKind returns the models.HistoryKind for a key message and whether the key undoes, redoes or repeats a change
+func (c Controls) HistoryKind(msg tea.KeyMsg) (models.HistoryKind, bool) {
+	for _, hb := range c.historyBindings() {
+		if key.Matches(msg, hb.binding) {
+			return hb.kind, true
+		}
+	}
+	return 0, false
+}
+
+// MacroKind returns the models.MacroKind for a key message and whether the key records or replays a macro
+func (c Controls) MacroKind(msg tea.KeyMsg) (models.MacroKind, bool) {
+	for _, mb := range c.macroBindings() {
+		if key.Matches(msg, mb.binding) {
+			return mb.kind, true
+		}
+	}
+	return 0, false
+}
+
+// motionBinding pairs a models.MotionKind with the key binding that triggers it
+type motionBinding struct {
+	kind    models.MotionKind
+	binding key.Binding
+}
+
+// motionBindings returns the motion key bindings in the order they are displayed
+func (c Controls) motionBindings() []motionBinding {
+	return []motionBinding{
+		{models.MotionLeft, c.MoveLeft},
+		{models.MotionDown, c.MoveDown},
+		{models.MotionUp, c.MoveUp},
+		{models.MotionRight, c.MoveRight},
+		{models.MotionWordForward, c.WordForward},
+		{models.MotionWordBackward, c.WordBack},
+		{models.MotionWordEnd, c.EndOfWord},
+		{models.MotionWORDForward, c.WORDForward},
+		{models.MotionWORDBackward, c.WORDBack},
+		{models.MotionWORDEnd, c.EndOfWORD},
+		{models.MotionStartOfLine, c.StartOfLine},
+		{models.MotionFirstNonBlank, c.FirstNonBlank},
+		{models.MotionEndOfLine, c.EndOfLine},
+		{models.MotionFirstLine, c.FirstLine},
+		{models.MotionLastLine, c.LastLine},
+		{models.MotionFindForward, c.FindForward},
+		{models.MotionFindBackward, c.FindBackward},
+		{models.MotionTillForward, c.TillForward},
+		{models.MotionTillBackward, c.TillBackward},
+		{models.MotionRepeatFind, c.RepeatFind},
+		{models.MotionRepeatFindReverse, c.ReverseFind},
+		{models.MotionScreenTop, c.ScreenTop},
+		{models.MotionScreenMiddle, c.ScreenMiddle},
+		{models.MotionScreenBottom, c.ScreenBottom},
+		{models.MotionHalfPageDown, c.HalfPageDown},
+		{models.MotionHalfPageUp, c.HalfPageUp},
+		{models.MotionPageDown, c.PageDown},
+		{models.MotionPageUp, c.PageUp},
+		{models.MotionScrollCenter, c.ScrollCursor},
+		{models.MotionSearchForward, c.Search},
+		{models.MotionSearchBackward, c.SearchBack},
+		{models.MotionSearchNext, c.SearchNext},
+		{models.MotionSearchPrevious, c.SearchPrev},
+		{models.MotionSetMark, c.SetMark},
+		{models.MotionJumpMarkLine, c.JumpMarkLine},
+		{models.MotionJumpMark, c.JumpMark},
+		{models.MotionJumpOlder, c.JumpOlder},
+		{models.MotionJumpNewer, c.JumpNewer},
+		{models.MotionSentenceForward, c.SentenceNext},
+		{models.MotionSentenceBackward, c.SentenceBack},
+		{models.MotionParagraphForward, c.ParagraphNext},
+		{models.MotionParagraphBackward, c.ParagraphBack},
+		{models.MotionMatchPair, c.MatchPair},
+	}
+}
+
+// operatorBinding pairs a models.Operator with the key binding that triggers it
+type operatorBinding struct {
+	operator models.Operator
+	binding  key.Binding
+}
+
+// operatorBindings returns the operator key bindings in the order they are displayed
+func (c Controls) operatorBindings() []operatorBinding {
+	return []operatorBinding{
+		{models.OperatorDelete, c.Delete},
+		{models.OperatorChange, c.Change},
+		{models.OperatorYank, c.Yank},
+	}
+}
+
+// insertBinding pairs a models.InsertKind with the key binding that triggers it
+type insertBinding struct {
+	kind    models.InsertKind
+	binding key.Binding
+}
+
+// insertBindings returns the insert key bindings in the order they are displayed
+func (c Controls) insertBindings() []insertBinding {
+	return []insertBinding{
+		{models.InsertBefore, c.Insert},
+		{models.InsertAfter, c.Append},
+		{models.InsertLineStart, c.InsertStart},
+		{models.InsertLineEnd, c.AppendEnd},
+		{models.InsertOpenBelow, c.OpenBelow},
+		{models.InsertOpenAbove, c.OpenAbove},
+	}
+}
+
+// visualBinding pairs a models.VisualMode with the key binding that triggers it
+type visualBinding struct {
+	mode    models.VisualMode
+	binding key.Binding
+}
+
+// visualBindings returns the visual mode key bindings in the order they are displayed
+func (c Controls) visualBindings() []visualBinding {
+	return []visualBinding{
+		{models.VisualChar, c.VisualChar},
+		{models.VisualLine, c.VisualLine},
+		{models.VisualBlock, c.VisualBlock},
+	}
+}
+
+// historyBinding pairs a models.HistoryKind with the key binding that triggers it
+type historyBinding struct {
+	kind    models.HistoryKind
+	binding key.Binding
+}
+
+// historyBindings returns the history key bindings in the order they are displayed
+func (c Controls) historyBindings() []historyBinding {
+	return []historyBinding{
+		{models.HistoryUndo, c.Undo},
+		{models.HistoryRedo, c.Redo},
+		{models.HistoryRepeat, c.Repeat},
+	}
+}
+
+// macroBinding pairs a models.MacroKind with the key binding that triggers it
+type macroBinding struct {
+	kind    models.MacroKind
+	binding key.Binding
+}
+
+// macroBindings returns the macro key bindings in the order they are displayed
+func (c Controls) macroBindings() []macroBinding {
+	return []macroBinding{
+		{models.MacroRecord, c.Record},
+		{models.MacroReplay, c.Replay},
+	}
+}
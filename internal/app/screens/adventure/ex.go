@@ -0,0 +1,145 @@
+package adventure
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// exPrompt is the prompt of the command line when an ex command is typed
+const exPrompt = ":"
+
+// exLine represents a parsed ex command line like :q! or :help dw,
+// a line that is only a number is a jump to that line
+type exLine struct {
+	name string
+	bang bool
+	arg  string
+	line int
+}
+
+// parseExLine parses the text typed after the colon, like in Vim the name of a command
+// is the letters it starts with and a ! right after the name forces the command
+func parseExLine(text string) exLine {
+	text = strings.TrimSpace(text)
+	if line, err := strconv.Atoi(text); err == nil {
+		return exLine{line: max(line, 1)}
+	}
+
+	end := strings.IndexFunc(text, func(r rune) bool { return r < 'a' || r > 'z' })
+	if end < 0 {
+		end = len(text)
+	}
+	parsed := exLine{name: text[:end]}
+	rest := text[end:]
+	if strings.HasPrefix(rest, "!") {
+		parsed.bang = true
+		rest = rest[1:]
+	}
+	parsed.arg = strings.TrimSpace(rest)
+	return parsed
+}
+
+// exCommand represents an ex command that can be typed on the command line
+type exCommand struct {
+	name   string // full name of the command, like write
+	short  string // shortest abbreviation of the name, like w
+	leaves bool   // the command leaves the level, which a macro cannot do
+	run    func(a *Adventure, line exLine) (tea.Model, tea.Cmd)
+}
+
+// matches reports whether the name typed on the command line refers to the command
+func (ec exCommand) matches(name string) bool {
+	return strings.HasPrefix(name, ec.short) && strings.HasPrefix(ec.name, name)
+}
+
+// exCommands contains the ex commands of the adventure mode
+var exCommands = []exCommand{
+	{name: "write", short: "w", run: (*Adventure).exWrite},
+	{name: "quit", short: "q", leaves: true, run: (*Adventure).exQuit},
+	{name: "wq", short: "wq", leaves: true, run: (*Adventure).exWriteQuit},
+	{name: "xit", short: "x", leaves: true, run: (*Adventure).exWriteQuit},
+	{name: "help", short: "h", leaves: true, run: (*Adventure).exHelp},
+}
+
+// lookupExCommand returns the ex command the typed name refers to and whether there is one
+func lookupExCommand(name string) (exCommand, bool) {
+	for _, ec := range exCommands {
+		if ec.matches(name) {
+			return ec, true
+		}
+	}
+	return exCommand{}, false
+}
+
+// execute runs the ex command typed on the command line
+func (a *Adventure) execute(text string) (tea.Model, tea.Cmd) {
+	level := a.lc.GetCurrentLevel()
+	line := parseExLine(text)
+	if line.line > 0 {
+		// like in Vim :{n} jumps to line n the way {n}G does, it costs every key typed for it
+		motion := models.Motion{Kind: models.MotionLastLine, Count: line.line, Keys: models.ExJumpKeys(text)}
+		return a.apply(level.PlayerMove(motion), func() { a.stats.RegisterMotion(motion) })
+	}
+	if strings.TrimSpace(text) == "" {
+		return a, nil
+	}
+
+	ec, ok := lookupExCommand(line.name)
+	switch {
+	case !ok:
+		a.view.SetInfo(fmt.Sprintf("E492: Not an editor command: %s. %s", text, level.GetInstructions()))
+		return a, nil
+	case ec.leaves && a.replaying:
+		// a macro never leaves the level
+		a.aborted = true
+		return a, nil
+	}
+	return ec.run(a, line)
+}
+
+// exWrite saves the level without leaving it
+func (a *Adventure) exWrite(line exLine) (tea.Model, tea.Cmd) {
+	if line.arg != "" {
+		a.view.SetInfo(fmt.Sprintf("E488: Trailing characters: %s. %s", line.arg, a.lc.GetCurrentLevel().GetInstructions()))
+		return a, nil
+	}
+	if a.playtest {
+		a.view.SetInfo(fmt.Sprintf("A playtest is not saved, the level is written in the editor. %s", a.lc.GetCurrentLevel().GetInstructions()))
+		return a, nil
+	}
+	cmd := a.write()
+	a.view.SetInfo(fmt.Sprintf("Level %d written. %s", a.lc.GetLevelNumber(), a.lc.GetCurrentLevel().GetInstructions()))
+	return a, cmd
+}
+
+// exQuit leaves the level without saving it, unless it changed since it was last saved
+// and the command was not forced with a !
+func (a *Adventure) exQuit(line exLine) (tea.Model, tea.Cmd) {
+	if a.unsaved && !line.bang {
+		a.view.SetInfo(fmt.Sprintf("E37: No write since last change (add ! to override). %s", a.lc.GetCurrentLevel().GetInstructions()))
+		return a, nil
+	}
+	a.Reset()
+	return a, a.leave(models.LevelSelectionScreen, nil)
+}
+
+// exWriteQuit saves the level and leaves it
+func (a *Adventure) exWriteQuit(exLine) (tea.Model, tea.Cmd) {
+	return a, a.leave(models.LevelSelectionScreen, a.Save())
+}
+
+// exHelp opens the sections of the cheatsheet about a topic, or the whole cheatsheet without one
+func (a *Adventure) exHelp(line exLine) (tea.Model, tea.Cmd) {
+	sections := models.CheatsheetTopic(line.arg)
+	if len(sections) == 0 {
+		a.view.SetInfo(fmt.Sprintf("E149: Sorry, no help for %s. %s", line.arg, a.lc.GetCurrentLevel().GetInstructions()))
+		return a, nil
+	}
+	return a, func() tea.Msg {
+		return models.OpenHelpMsg{Sections: sections, Back: a.screen()}
+	}
+}
@@ -0,0 +1,156 @@
+package adventure
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dasvh/go-learn-vim/internal/app/controllers"
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/testutils"
+)
+
+func Test_ParseExLine(t *testing.T) {
+	tests := []struct {
+		text string
+		want exLine
+	}{
+		{text: "w", want: exLine{name: "w"}},
+		{text: "q!", want: exLine{name: "q", bang: true}},
+		{text: "wq", want: exLine{name: "wq"}},
+		{text: " 12 ", want: exLine{line: 12}},
+		{text: "0", want: exLine{line: 1}},
+		{text: "help dw", want: exLine{name: "help", arg: "dw"}},
+		{text: "h :wq", want: exLine{name: "h", arg: ":wq"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := parseExLine(tt.text); got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_LookupExCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantName string
+		wantOK   bool
+	}{
+		{name: "w", wantName: "write", wantOK: true},
+		{name: "wri", wantName: "write", wantOK: true},
+		{name: "wq", wantName: "wq", wantOK: true},
+		{name: "q", wantName: "quit", wantOK: true},
+		{name: "h", wantName: "help", wantOK: true},
+		{name: "writer", wantOK: false},
+		{name: "z", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ec, ok := lookupExCommand(tt.name)
+			if ok != tt.wantOK || ec.name != tt.wantName {
+				t.Errorf("expected %q, %v, got %q, %v", tt.wantName, tt.wantOK, ec.name, ok)
+			}
+		})
+	}
+}
+
+func Test_AdventureExCommands(t *testing.T) {
+	repo := testutils.NewMockGameRepository()
+	gc := controllers.NewGame(repo)
+	gc.SetPlayer(models.Player{ID: "1", Name: "player"})
+	lc := controllers.NewLevel()
+	lc.SetLevel(lc.GetLevels()[9])
+
+	a := NewAdventure(gc, lc)
+	a.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	a.Update(models.SetLevelMsg{LevelNumber: 9})
+
+	// typeLine feeds the keys of a command line to the adventure and returns the command of its last key
+	typeLine := func(line string) tea.Cmd {
+		var cmd tea.Cmd
+		for _, r := range line {
+			_, cmd = a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		_, cmd = a.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		return cmd
+	}
+
+	typeLine(":3")
+	if pos := lc.GetCurrentLevel().GetCurrentPosition(); pos.Y != 2 {
+		t.Fatalf("expected :3 to jump to the third line, at %v", pos)
+	}
+	if a.stats.CommandKeystrokes != 3 {
+		t.Errorf("expected the keys of :3 to be registered as command keys, got %d", a.stats.CommandKeystrokes)
+	}
+	// the jump costs the colon, the digit and enter
+	if a.stats.TotalKeystrokes != 3 || a.stats.KeyPresses[":3<CR>"] != 1 {
+		t.Errorf("expected the jump to cost 3 keystrokes, got %d (%v)", a.stats.TotalKeystrokes, a.stats.KeyPresses)
+	}
+
+	if cmd := typeLine(":q"); cmd != nil || !lc.GetCurrentLevel().InProgress() {
+		t.Fatalf("expected :q to stay in a level that changed")
+	}
+	if cmd := typeLine(":w"); cmd == nil || len(repo.GameSavesData) != 1 || !lc.GetCurrentLevel().InProgress() {
+		t.Fatalf("expected :w to save without leaving the level, got %d saves", len(repo.GameSavesData))
+	}
+	typeLine(":w")
+	if len(repo.GameSavesData) != 1 {
+		t.Errorf("expected :w to replace the earlier save, got %d saves", len(repo.GameSavesData))
+	}
+	if cmd := typeLine(":q"); cmd == nil || lc.GetCurrentLevel().InProgress() {
+		t.Errorf("expected :q to leave a level that was saved")
+	}
+}
+
+func Test_AdventureExQuitForced(t *testing.T) {
+	repo := testutils.NewMockGameRepository()
+	gc := controllers.NewGame(repo)
+	gc.SetPlayer(models.Player{ID: "1", Name: "player"})
+	lc := controllers.NewLevel()
+	lc.SetLevel(lc.GetLevels()[9])
+
+	a := NewAdventure(gc, lc)
+	a.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	a.Update(models.SetLevelMsg{LevelNumber: 9})
+	a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+
+	for _, r := range ":q!" {
+		a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if _, cmd := a.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || lc.GetCurrentLevel().InProgress() {
+		t.Fatalf("expected :q! to leave the level")
+	}
+	if len(repo.GameSavesData) != 0 {
+		t.Errorf("expected :q! not to save the level, got %d saves", len(repo.GameSavesData))
+	}
+}
+
+func Test_AdventureSearchKeystrokes(t *testing.T) {
+	gc := controllers.NewGame(testutils.NewMockGameRepository())
+	gc.SetPlayer(models.Player{ID: "1", Name: "player"})
+	lc := controllers.NewLevel()
+	lc.SetLevel(lc.GetLevels()[6])
+
+	a := NewAdventure(gc, lc)
+	a.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	a.Update(models.SetLevelMsg{LevelNumber: 6})
+
+	for _, r := range "/ERROR" {
+		a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	a.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if pos := lc.GetCurrentLevel().GetCurrentPosition(); pos != (models.Position{X: 9, Y: 9}) {
+		t.Fatalf("expected /ERROR to move onto the first error, at %v", pos)
+	}
+	if a.stats.CommandKeystrokes != 6 {
+		t.Errorf("expected the pattern and enter to be registered as command keys, got %d", a.stats.CommandKeystrokes)
+	}
+	// like :{n} the search costs the slash, every key of the pattern and enter
+	if a.stats.TotalKeystrokes != 7 || a.stats.KeyPresses["/ERROR<CR>"] != 1 {
+		t.Errorf("expected the search to cost 7 keystrokes, got %d (%v)", a.stats.TotalKeystrokes, a.stats.KeyPresses)
+	}
+}
@@ -0,0 +1,111 @@
+package adventure
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// ghostIdle is how long a ghost tick waits at most, a ghost that is picked while
+// a tick is on its way starts moving with that tick
+const ghostIdle = time.Second
+
+// GhostTickMsg represents a tick that moves the ghost of the run that is raced against
+type GhostTickMsg time.Time
+
+// startRun starts recording a run of the current level from the cursor
+// and picks the best run of the level to race against
+func (a *Adventure) startRun() {
+	a.started = time.Now()
+	a.typed = nil
+	a.run = models.Run{Start: a.lc.GetCurrentLevel().GetCurrentPosition()}
+	a.pickGhost()
+}
+
+// pickGhost picks the best run of the current level that fits the grid as the ghost,
+// the personal best of the player comes before the best run of all players. A playtest has no ghost
+func (a *Adventure) pickGhost() {
+	a.ghost = nil
+	if a.playtest || a.gc == nil {
+		return
+	}
+	if run, ok := a.gc.BestRun(a.lc.GetLevelNumber(), a.fits); ok {
+		a.ghost = &run
+	}
+}
+
+// fits reports whether a run can be raced against on the grid, the positions of a level that
+// scrolls are positions in its text while the positions of other levels depend on the size of the grid
+func (a *Adventure) fits(run models.Run) bool {
+	if _, ok := a.lc.GetCurrentLevel().(models.ScrollingLevel); ok {
+		return true
+	}
+	return run.Width == a.gridWidth && run.Height == a.gridHeight
+}
+
+// elapsed returns the time since the run started
+func (a *Adventure) elapsed() time.Duration {
+	return time.Since(a.started)
+}
+
+// recordStep records a move of the run that left the cursor at pos, with the keys typed since the move before it
+func (a *Adventure) recordStep(pos models.Position) {
+	a.run.Record(a.elapsed(), a.typed, pos)
+	a.typed = nil
+}
+
+// runPosition returns where a move left the cursor, a level can put the cursor back on
+// its start once it is completed so a completed run ends on the last target instead
+func (a *Adventure) runPosition(result models.PlayerMovement) models.Position {
+	level := a.lc.GetCurrentLevel()
+	if targets := level.GetTargets(); result.Completed && len(targets) > 0 {
+		return targets[len(targets)-1].Position
+	}
+	return level.GetCurrentPosition()
+}
+
+// ghostTick returns a command that sends GhostTickMsg when the ghost makes its next move,
+// there is no tick without a ghost, once its run has ended or while a tick is on its way
+func (a *Adventure) ghostTick() tea.Cmd {
+	if a.ghost == nil || a.ghostTicking {
+		return nil
+	}
+	elapsed := a.elapsed()
+	next, ok := a.ghost.Next(elapsed)
+	if !ok {
+		return nil
+	}
+	a.ghostTicking = true
+	return tea.Tick(min(next-elapsed, ghostIdle), func(t time.Time) tea.Msg {
+		return GhostTickMsg(t)
+	})
+}
+
+// ghostCell returns the cell of the grid the ghost is drawn in and whether it is drawn,
+// the ghost is where the cursor of its run was at the time since the current run started
+func (a *Adventure) ghostCell() (models.Position, bool) {
+	if a.ghost == nil || !a.fits(*a.ghost) {
+		return models.Position{}, false
+	}
+	pos := a.ghost.PositionAt(a.elapsed())
+	if grid, ok := a.lc.GetCurrentLevel().(models.GridLevel); ok {
+		return grid.Cell(pos)
+	}
+	return pos, true
+}
+
+// withGhost returns the overlays with the ghost laid over the cell of the field it is in,
+// the rows of the overlays are copied so the overlays of the level are left as they are
+func withGhost(overlays [][]models.Overlay, field [][]rune, cell models.Position) [][]models.Overlay {
+	if cell.Y < 0 || cell.Y >= len(field) || cell.X < 0 || cell.X >= len(field[cell.Y]) {
+		return overlays
+	}
+	ghosted := make([][]models.Overlay, max(len(field), len(overlays)))
+	copy(ghosted, overlays)
+	row := make([]models.Overlay, len(field[cell.Y]))
+	copy(row, ghosted[cell.Y])
+	row[cell.X] = models.OverlayGhost
+	ghosted[cell.Y] = row
+	return ghosted
+}
@@ -0,0 +1,315 @@
+package adventure
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// maxCount is the largest count that can be typed in front of a motion
+const maxCount = 9999
+
+// inputParser turns single key messages into models.Motion's, models.Operation's, models.Insert's,
+// models.Visual's, models.History's and models.Macro's, it keeps track of a typed count that multiplies the next motion
+// and of motions that take more than one key. In visual mode an operator acts on the selection right away
+type inputParser struct {
+	visual    bool
+	recording bool
+	count     int
+	keys      []string
+	pending   *models.MotionKind // motion that waits for another key, like the second g of gg or the z of zz
+	operation *models.Operation  // operator that waits for a motion or a text object, like the d of dw
+	object    string             // the i or a that starts a text object after an operator
+	macro     *models.MacroKind  // macro command that waits for the name of its register, like the a of qa
+}
+
+// action is a complete input of the parser, either a motion, an operation, an insert, a visual command,
+// a history command or a macro command
+type action struct {
+	motion    models.Motion
+	operation *models.Operation
+	insert    *models.Insert
+	visual    *models.Visual
+	history   *models.History
+	macro     *models.Macro
+}
+
+// searchMotion returns the search motion of the action that still needs its pattern, or nil
+func (act *action) searchMotion() *models.Motion {
+	motion := &act.motion
+	if act.operation != nil {
+		if act.operation.Linewise || act.operation.Object != models.TextObjectNone {
+			return nil
+		}
+		motion = &act.operation.Motion
+	}
+	if motion.Kind.NeedsPattern() {
+		return motion
+	}
+	return nil
+}
+
+// Feed passes a key message to the parser and returns the action once it is complete
+func (p *inputParser) Feed(msg tea.KeyMsg, controls Controls) (action, bool) {
+	k := msg.String()
+
+	if p.pending != nil {
+		motion, ok := p.complete(msg, controls)
+		if !ok {
+			p.Reset()
+			return action{}, false
+		}
+		return p.finish(motion), true
+	}
+	if p.object != "" {
+		return p.completeObject(k)
+	}
+	if p.macro != nil {
+		return p.completeMacro(msg)
+	}
+
+	// like in Vim a 0 is only part of the count when it follows another digit
+	if digit, err := strconv.Atoi(k); err == nil && len(k) == 1 && (digit > 0 || p.count > 0) {
+		p.count = min(p.count*10+digit, maxCount)
+		p.keys = append(p.keys, k)
+		return action{}, false
+	}
+
+	if operator, ok := controls.Operator(msg); ok && p.visual {
+		defer p.Reset()
+		return action{operation: &models.Operation{Operator: operator, Count: p.count, Visual: true, Keys: append(p.keys, k)}}, true
+	}
+	if operator, ok := controls.Operator(msg); ok {
+		return p.operator(operator, k)
+	}
+	if mode, ok := controls.VisualMode(msg); ok && p.operation == nil {
+		defer p.Reset()
+		return action{visual: &models.Visual{Mode: mode, Keys: append(p.keys, k)}}, true
+	}
+	if p.operation != nil && (k == "i" || k == "a") {
+		// the key starts a text object, like the i of diw
+		p.object = k
+		p.keys = append(p.keys, k)
+		return action{}, false
+	}
+	if kind, ok := controls.InsertKind(msg); ok && p.operation == nil && !p.visual {
+		// the count is typed along but does not repeat the inserted text
+		defer p.Reset()
+		return action{insert: &models.Insert{Kind: kind, Keys: append(p.keys, k)}}, true
+	}
+	if kind, ok := controls.HistoryKind(msg); ok && p.operation == nil && !p.visual {
+		defer p.Reset()
+		return action{history: &models.History{Kind: kind, Count: p.count, Keys: append(p.keys, k)}}, true
+	}
+	if kind, ok := controls.MacroKind(msg); ok && p.operation == nil && !p.visual {
+		return p.startMacro(kind, k)
+	}
+
+	motion, ok := controls.Motion(msg)
+	if !ok {
+		p.Reset()
+		return action{}, false
+	}
+
+	// the motion needs another key before it is complete
+	if motion.Kind == models.MotionFirstLine || motion.Kind == models.MotionScrollCenter || motion.Kind.NeedsChar() {
+		p.pending = &motion.Kind
+		p.keys = append(p.keys, k)
+		return action{}, false
+	}
+
+	motion.Count = p.count
+	motion.Keys = append(p.keys, motion.Keys...)
+	return p.finish(motion), true
+}
+
+// operator starts an operation, or completes it when the same operator is typed twice like dd
+func (p *inputParser) operator(operator models.Operator, k string) (action, bool) {
+	p.keys = append(p.keys, k)
+	switch {
+	case p.operation == nil:
+		p.operation = &models.Operation{Operator: operator, Count: p.count}
+		p.count = 0
+		return action{}, false
+	case p.operation.Operator == operator:
+		// a doubled operator acts on count lines
+		defer p.Reset()
+		operation := *p.operation
+		operation.Count = multiplyCounts(operation.Count, p.count)
+		operation.Linewise = true
+		operation.Keys = p.keys
+		return action{operation: &operation}, true
+	default:
+		// another operator does not form an operation
+		p.Reset()
+		return action{}, false
+	}
+}
+
+// completeObject completes the text object of the pending operation with the key
+func (p *inputParser) completeObject(k string) (action, bool) {
+	defer p.Reset()
+	object, ok := textObjects[p.object+k]
+	if !ok {
+		return action{}, false
+	}
+	operation := *p.operation
+	operation.Count = multiplyCounts(operation.Count, p.count)
+	operation.Object = object
+	operation.Keys = append(p.keys, k)
+	return action{operation: &operation}, true
+}
+
+// startMacro starts a macro command that waits for its register, like in Vim q stops recording right away
+func (p *inputParser) startMacro(kind models.MacroKind, k string) (action, bool) {
+	p.keys = append(p.keys, k)
+	if kind == models.MacroRecord && p.recording {
+		defer p.Reset()
+		return action{macro: &models.Macro{Kind: kind, Keys: p.keys}}, true
+	}
+	p.macro = &kind
+	return action{}, false
+}
+
+// completeMacro completes the pending macro command with the name of its register,
+// only @ replays a register named with @, which is the register that was replayed last
+func (p *inputParser) completeMacro(msg tea.KeyMsg) (action, bool) {
+	defer p.Reset()
+	if !isCharKey(msg) {
+		return action{}, false
+	}
+	register := msg.Runes[0]
+	if !isRegisterName(register) && (*p.macro != models.MacroReplay || register != models.LastMacroRegister) {
+		return action{}, false
+	}
+	return action{macro: &models.Macro{Kind: *p.macro, Register: register, Count: p.count, Keys: append(p.keys, msg.String())}}, true
+}
+
+// finish returns the action of a complete motion, a pending operator acts on the motion
+// and like in Vim the counts before and after the operator are multiplied
+func (p *inputParser) finish(motion models.Motion) action {
+	defer p.Reset()
+	if p.operation == nil {
+		return action{motion: motion}
+	}
+	operation := *p.operation
+	motion.Count = multiplyCounts(operation.Count, motion.Count)
+	operation.Count = motion.Count
+	operation.Motion = motion
+	operation.Keys = motion.Keys
+	return action{operation: &operation}
+}
+
+// complete completes the pending motion with the key message
+func (p *inputParser) complete(msg tea.KeyMsg, controls Controls) (models.Motion, bool) {
+	motion := models.Motion{Kind: *p.pending, Count: p.count, Keys: append(p.keys, msg.String())}
+
+	switch {
+	case motion.Kind.NeedsChar() && isCharKey(msg):
+		motion.Char = msg.Runes[0]
+		return motion, true
+	case motion.Kind == models.MotionFirstLine && key.Matches(msg, controls.FirstLine):
+		return motion, true
+	case motion.Kind == models.MotionScrollCenter:
+		// z is followed by the position of the cursor line in the window
+		kind, ok := scrollPositions[msg.String()]
+		motion.Kind = kind
+		return motion, ok
+	default:
+		// any other key does not form a motion
+		return models.Motion{}, false
+	}
+}
+
+// AwaitingChar reports whether the next key is the character of a find motion, the name of a mark
+// or the name of a register
+func (p *inputParser) AwaitingChar() bool {
+	return p.pending != nil && p.pending.NeedsChar() || p.macro != nil
+}
+
+// Pending returns the keys typed so far that do not form a complete motion yet
+func (p *inputParser) Pending() string {
+	return strings.Join(p.keys, "")
+}
+
+// SetVisual tells the parser whether text is being selected in visual mode
+func (p *inputParser) SetVisual(visual bool) {
+	p.visual = visual
+}
+
+// SetRecording tells the parser whether keys are being recorded into a register
+func (p *inputParser) SetRecording(recording bool) {
+	p.recording = recording
+}
+
+// Reset discards the keys typed so far
+func (p *inputParser) Reset() {
+	p.count = 0
+	p.keys = nil
+	p.pending = nil
+	p.operation = nil
+	p.object = ""
+	p.macro = nil
+}
+
+// scrollPositions maps the key typed after z to the scroll motion it completes
+var scrollPositions = map[string]models.MotionKind{
+	"z": models.MotionScrollCenter,
+	"t": models.MotionScrollTop,
+	"b": models.MotionScrollBottom,
+}
+
+// textObjects maps the keys typed after an operator to the text object they select
+var textObjects = map[string]models.TextObject{
+	"iw": models.TextObjectInnerWord,
+	"aw": models.TextObjectAWord,
+	"iW": models.TextObjectInnerWORD,
+	"aW": models.TextObjectAWORD,
+	`i"`: models.TextObjectInnerDoubleQuote,
+	`a"`: models.TextObjectADoubleQuote,
+	"i'": models.TextObjectInnerSingleQuote,
+	"a'": models.TextObjectASingleQuote,
+	"i`": models.TextObjectInnerBacktick,
+	"a`": models.TextObjectABacktick,
+	"i(": models.TextObjectInnerParen,
+	"i)": models.TextObjectInnerParen,
+	"ib": models.TextObjectInnerParen,
+	"a(": models.TextObjectAParen,
+	"a)": models.TextObjectAParen,
+	"ab": models.TextObjectAParen,
+	"i[": models.TextObjectInnerBracket,
+	"i]": models.TextObjectInnerBracket,
+	"a[": models.TextObjectABracket,
+	"a]": models.TextObjectABracket,
+	"i{": models.TextObjectInnerBrace,
+	"i}": models.TextObjectInnerBrace,
+	"iB": models.TextObjectInnerBrace,
+	"a{": models.TextObjectABrace,
+	"a}": models.TextObjectABrace,
+	"aB": models.TextObjectABrace,
+	"i<": models.TextObjectInnerAngle,
+	"i>": models.TextObjectInnerAngle,
+	"a<": models.TextObjectAAngle,
+	"a>": models.TextObjectAAngle,
+	"ip": models.TextObjectInnerParagraph,
+	"ap": models.TextObjectAParagraph,
+	"it": models.TextObjectInnerTag,
+	"at": models.TextObjectATag,
+}
+
+// multiplyCounts returns the count of an operation from the counts typed before and after
+// the operator, like 2d3w which deletes six words. A count of zero means no count was typed
+func multiplyCounts(before, after int) int {
+	if before == 0 && after == 0 {
+		return 0
+	}
+	return min(max(before, 1)*max(after, 1), maxCount)
+}
+
+// isCharKey reports whether the key message types a single character
+func isCharKey(msg tea.KeyMsg) bool {
+	return len(msg.Runes) == 1 && !msg.Alt && (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace)
+}
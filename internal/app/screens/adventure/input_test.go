@@ -0,0 +1,281 @@
+package adventure
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+func Test_InputParser(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      string
+		wantOK    bool
+		wantKind  models.MotionKind
+		wantCount int
+		wantChar  rune
+		wantKeys  []string
+	}{
+		{name: "single key motion", keys: "j", wantOK: true, wantKind: models.MotionDown, wantKeys: []string{"j"}},
+		{name: "0 without count is a motion", keys: "0", wantOK: true, wantKind: models.MotionStartOfLine, wantKeys: []string{"0"}},
+		{name: "gg", keys: "gg", wantOK: true, wantKind: models.MotionFirstLine, wantKeys: []string{"g", "g"}},
+		{name: "count G", keys: "10G", wantOK: true, wantKind: models.MotionLastLine, wantCount: 10, wantKeys: []string{"1", "0", "G"}},
+		{name: "count gg", keys: "3gg", wantOK: true, wantKind: models.MotionFirstLine, wantCount: 3, wantKeys: []string{"3", "g", "g"}},
+		{name: "find char", keys: "fa", wantOK: true, wantKind: models.MotionFindForward, wantChar: 'a', wantKeys: []string{"f", "a"}},
+		{name: "count till bound key", keys: "2tq", wantOK: true, wantKind: models.MotionTillForward, wantCount: 2, wantChar: 'q', wantKeys: []string{"2", "t", "q"}},
+		{name: "find digit", keys: "F0", wantOK: true, wantKind: models.MotionFindBackward, wantChar: '0', wantKeys: []string{"F", "0"}},
+		{name: "repeat find", keys: "3;", wantOK: true, wantKind: models.MotionRepeatFind, wantCount: 3, wantKeys: []string{"3", ";"}},
+		{name: "zt", keys: "zt", wantOK: true, wantKind: models.MotionScrollTop, wantKeys: []string{"z", "t"}},
+		{name: "count H", keys: "4H", wantOK: true, wantKind: models.MotionScreenTop, wantCount: 4, wantKeys: []string{"4", "H"}},
+		{name: "set mark", keys: "ma", wantOK: true, wantKind: models.MotionSetMark, wantChar: 'a', wantKeys: []string{"m", "a"}},
+		{name: "jump to mark", keys: "`a", wantOK: true, wantKind: models.MotionJumpMark, wantChar: 'a', wantKeys: []string{"`", "a"}},
+		{name: "jump to mark line", keys: "'q", wantOK: true, wantKind: models.MotionJumpMarkLine, wantChar: 'q', wantKeys: []string{"'", "q"}},
+		{name: "count )", keys: "2)", wantOK: true, wantKind: models.MotionSentenceForward, wantCount: 2, wantKeys: []string{"2", ")"}},
+		{name: "{", keys: "{", wantOK: true, wantKind: models.MotionParagraphBackward, wantKeys: []string{"{"}},
+		{name: "z followed by another key", keys: "zj", wantOK: false},
+		{name: "pending g", keys: "g", wantOK: false},
+		{name: "pending find", keys: "t", wantOK: false},
+		{name: "pending count", keys: "12", wantOK: false},
+		{name: "g followed by another key", keys: "gj", wantOK: false},
+		{name: "unbound key", keys: "5z", wantOK: false},
+	}
+
+	controls := NewBasicControls()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p inputParser
+			var act action
+			var ok bool
+			for _, r := range tt.keys {
+				act, ok = p.Feed(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}, controls)
+			}
+			motion := act.motion
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok to be %v, got %v", tt.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if motion.Kind != tt.wantKind {
+				t.Errorf("expected kind %d, got %d", tt.wantKind, motion.Kind)
+			}
+			if motion.Char != tt.wantChar {
+				t.Errorf("expected char %q, got %q", tt.wantChar, motion.Char)
+			}
+			if motion.Count != tt.wantCount {
+				t.Errorf("expected count %d, got %d", tt.wantCount, motion.Count)
+			}
+			if !slices.Equal(motion.Keys, tt.wantKeys) {
+				t.Errorf("expected keys %v, got %v", tt.wantKeys, motion.Keys)
+			}
+		})
+	}
+}
+
+func Test_InputParserOperators(t *testing.T) {
+	tests := []struct {
+		name         string
+		keys         string
+		wantOK       bool
+		wantOperator models.Operator
+		wantCount    int
+		wantKind     models.MotionKind
+		wantObject   models.TextObject
+		wantLinewise bool
+		wantKeys     string
+	}{
+		{name: "dw", keys: "dw", wantOK: true, wantOperator: models.OperatorDelete, wantKind: models.MotionWordForward, wantKeys: "dw"},
+		{name: "counts are multiplied", keys: "2d3w", wantOK: true, wantOperator: models.OperatorDelete, wantCount: 6, wantKind: models.MotionWordForward, wantKeys: "2d3w"},
+		{name: "count before the operator", keys: "3ce", wantOK: true, wantOperator: models.OperatorChange, wantCount: 3, wantKind: models.MotionWordEnd, wantKeys: "3ce"},
+		{name: "d0", keys: "d0", wantOK: true, wantOperator: models.OperatorDelete, wantKind: models.MotionStartOfLine, wantKeys: "d0"},
+		{name: "dgg", keys: "dgg", wantOK: true, wantOperator: models.OperatorDelete, wantKind: models.MotionFirstLine, wantKeys: "dgg"},
+		{name: "dt with a char", keys: "dt,", wantOK: true, wantOperator: models.OperatorDelete, wantKind: models.MotionTillForward, wantKeys: "dt,"},
+		{name: "dd", keys: "dd", wantOK: true, wantOperator: models.OperatorDelete, wantLinewise: true, wantKeys: "dd"},
+		{name: "count dd", keys: "3yy", wantOK: true, wantOperator: models.OperatorYank, wantCount: 3, wantLinewise: true, wantKeys: "3yy"},
+		{name: "diw", keys: "diw", wantOK: true, wantOperator: models.OperatorDelete, wantObject: models.TextObjectInnerWord, wantKeys: "diw"},
+		{name: "2yaW", keys: "2yaW", wantOK: true, wantOperator: models.OperatorYank, wantCount: 2, wantObject: models.TextObjectAWORD, wantKeys: "2yaW"},
+		{name: `ci"`, keys: `ci"`, wantOK: true, wantOperator: models.OperatorChange, wantObject: models.TextObjectInnerDoubleQuote, wantKeys: `ci"`},
+		{name: "ya(", keys: "ya(", wantOK: true, wantOperator: models.OperatorYank, wantObject: models.TextObjectAParen, wantKeys: "ya("},
+		{name: "dab", keys: "dab", wantOK: true, wantOperator: models.OperatorDelete, wantObject: models.TextObjectAParen, wantKeys: "dab"},
+		{name: "2dit", keys: "2dit", wantOK: true, wantOperator: models.OperatorDelete, wantCount: 2, wantObject: models.TextObjectInnerTag, wantKeys: "2dit"},
+		{name: "d}", keys: "d}", wantOK: true, wantOperator: models.OperatorDelete, wantKind: models.MotionParagraphForward, wantKeys: "d}"},
+		{name: "d%", keys: "d%", wantOK: true, wantOperator: models.OperatorDelete, wantKind: models.MotionMatchPair, wantKeys: "d%"},
+		{name: "yip", keys: "yip", wantOK: true, wantOperator: models.OperatorYank, wantObject: models.TextObjectInnerParagraph, wantKeys: "yip"},
+		{name: "pending operator", keys: "d", wantOK: false},
+		{name: "pending text object", keys: "ci", wantOK: false},
+		{name: "unknown text object", keys: "dix", wantOK: false},
+		{name: "mixed operators", keys: "dc", wantOK: false},
+	}
+
+	controls := NewBasicControls()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p inputParser
+			var act action
+			var ok bool
+			for _, r := range tt.keys {
+				act, ok = p.Feed(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}, controls)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok to be %v, got %v", tt.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+			operation := act.operation
+			if operation == nil {
+				t.Fatalf("expected an operation, got motion %v", act.motion)
+			}
+			if operation.Operator != tt.wantOperator {
+				t.Errorf("expected operator %s, got %s", tt.wantOperator, operation.Operator)
+			}
+			if operation.Count != tt.wantCount {
+				t.Errorf("expected count %d, got %d", tt.wantCount, operation.Count)
+			}
+			if operation.Object == models.TextObjectNone && !operation.Linewise && operation.Motion.Kind != tt.wantKind {
+				t.Errorf("expected motion kind %d, got %d", tt.wantKind, operation.Motion.Kind)
+			}
+			if operation.Object != tt.wantObject {
+				t.Errorf("expected text object %d, got %d", tt.wantObject, operation.Object)
+			}
+			if operation.Linewise != tt.wantLinewise {
+				t.Errorf("expected linewise to be %v", tt.wantLinewise)
+			}
+			if operation.String() != tt.wantKeys {
+				t.Errorf("expected keys %q, got %q", tt.wantKeys, operation.String())
+			}
+		})
+	}
+}
+
+func Test_InputParserInserts(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     string
+		wantOK   bool
+		wantKind models.InsertKind
+		wantKeys []string
+	}{
+		{name: "i", keys: "i", wantOK: true, wantKind: models.InsertBefore, wantKeys: []string{"i"}},
+		{name: "A", keys: "A", wantOK: true, wantKind: models.InsertLineEnd, wantKeys: []string{"A"}},
+		{name: "count o", keys: "2o", wantOK: true, wantKind: models.InsertOpenBelow, wantKeys: []string{"2", "o"}},
+		{name: "i after an operator starts a text object", keys: "di", wantOK: false},
+	}
+
+	controls := NewBasicControls()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p inputParser
+			var act action
+			var ok bool
+			for _, r := range tt.keys {
+				act, ok = p.Feed(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}, controls)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok to be %v, got %v", tt.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if act.insert == nil {
+				t.Fatalf("expected an insert, got %+v", act)
+			}
+			if act.insert.Kind != tt.wantKind {
+				t.Errorf("expected kind %d, got %d", tt.wantKind, act.insert.Kind)
+			}
+			if !slices.Equal(act.insert.Keys, tt.wantKeys) {
+				t.Errorf("expected keys %v, got %v", tt.wantKeys, act.insert.Keys)
+			}
+		})
+	}
+}
+
+func Test_InputParserVisual(t *testing.T) {
+	controls := NewBasicControls()
+
+	var p inputParser
+	act, ok := p.Feed(tea.KeyMsg{Type: tea.KeyCtrlV}, controls)
+	if !ok || act.visual == nil || act.visual.Mode != models.VisualBlock {
+		t.Fatalf("expected ctrl+v to start visual block mode, got %+v", act)
+	}
+
+	// in visual mode an operator acts on the selection without waiting for a motion
+	p.SetVisual(true)
+	act, ok = p.Feed(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}, controls)
+	if !ok || act.operation == nil || !act.operation.Visual || act.operation.Operator != models.OperatorYank {
+		t.Fatalf("expected y to yank the selection, got %+v", act)
+	}
+	if _, ok := p.Feed(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}}, controls); ok {
+		t.Errorf("expected i not to start insert mode in visual mode")
+	}
+	act, ok = p.Feed(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}}, controls)
+	if !ok || act.motion.Kind != models.MotionWordForward {
+		t.Errorf("expected w to move the cursor in visual mode, got %+v", act)
+	}
+}
+
+func Test_InputParserHistory(t *testing.T) {
+	controls := NewBasicControls()
+
+	var p inputParser
+	p.Feed(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}}, controls)
+	act, ok := p.Feed(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'.'}}, controls)
+	if !ok || act.history == nil || act.history.Kind != models.HistoryRepeat || act.history.Count != 3 {
+		t.Fatalf("expected 3. to repeat with a count of 3, got %+v", act)
+	}
+	act, ok = p.Feed(tea.KeyMsg{Type: tea.KeyCtrlR}, controls)
+	if !ok || act.history == nil || act.history.Kind != models.HistoryRedo {
+		t.Fatalf("expected ctrl+r to redo, got %+v", act)
+	}
+
+	// u is not a motion after an operator
+	p.Feed(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}}, controls)
+	if _, ok := p.Feed(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}}, controls); ok {
+		t.Errorf("expected du not to form an action")
+	}
+}
+
+func Test_InputParserMacro(t *testing.T) {
+	controls := NewBasicControls()
+	char := func(r rune) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+	}
+
+	var p inputParser
+	if _, ok := p.Feed(char('q'), controls); ok || !p.AwaitingChar() {
+		t.Fatalf("expected q to wait for a register")
+	}
+	act, ok := p.Feed(char('a'), controls)
+	if !ok || act.macro == nil || act.macro.Kind != models.MacroRecord || act.macro.Register != 'a' {
+		t.Fatalf("expected qa to start recording into a, got %+v", act)
+	}
+
+	// while recording q stops right away
+	p.SetRecording(true)
+	act, ok = p.Feed(char('q'), controls)
+	if !ok || act.macro == nil || act.macro.Kind != models.MacroRecord || act.macro.Register != 0 {
+		t.Fatalf("expected q to stop recording, got %+v", act)
+	}
+	p.SetRecording(false)
+
+	p.Feed(char('1'), controls)
+	p.Feed(char('9'), controls)
+	p.Feed(char('@'), controls)
+	act, ok = p.Feed(char('a'), controls)
+	if !ok || act.macro == nil || act.macro.Kind != models.MacroReplay || act.macro.Count != 19 || act.macro.String() != "19@a" {
+		t.Fatalf("expected 19@a to replay a 19 times, got %+v", act)
+	}
+	p.Feed(char('@'), controls)
+	act, ok = p.Feed(char('@'), controls)
+	if !ok || act.macro == nil || act.macro.Register != models.LastMacroRegister {
+		t.Fatalf("expected @@ to replay the last register, got %+v", act)
+	}
+
+	// only a to z name a register
+	p.Feed(char('q'), controls)
+	if _, ok := p.Feed(char('@'), controls); ok {
+		t.Errorf("expected q@ not to form an action")
+	}
+}
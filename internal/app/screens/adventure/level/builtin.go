@@ -0,0 +1,43 @@
+package level
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// builtIn are the constructors of the levels that are built into the game by the number of the level,
+// the numbers cannot be used by the levels of a pack
+var builtIn = map[int]func() models.Level{
+	levelNumberZero:     NewLevelZero,
+	levelNumberOne:      NewLevelOne,
+	levelNumberTwo:      NewLevelTwo,
+	levelNumberThree:    NewLevelThree,
+	levelNumberFour:     NewLevelFour,
+	levelNumberFive:     NewLevelFive,
+	levelNumberSix:      NewLevelSix,
+	levelNumberSeven:    NewLevelSeven,
+	levelNumberEight:    NewLevelEight,
+	levelNumberNine:     NewLevelNine,
+	levelNumberTen:      NewLevelTen,
+	levelNumberEleven:   NewLevelEleven,
+	levelNumberTwelve:   NewLevelTwelve,
+	levelNumberThirteen: NewLevelThirteen,
+	levelNumberFourteen: NewLevelFourteen,
+	levelNumberFifteen:  NewLevelFifteen,
+}
+
+// BuiltInNumbers returns the numbers of the levels that are built into the game in order
+func BuiltInNumbers() []int {
+	return slices.Sorted(maps.Keys(builtIn))
+}
+
+// BuiltIn returns a new instance of every level that is built into the game by its number
+func BuiltIn() map[int]models.Level {
+	levels := make(map[int]models.Level, len(builtIn))
+	for number, newLevel := range builtIn {
+		levels[number] = newLevel()
+	}
+	return levels
+}
@@ -0,0 +1,24 @@
+package level
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+)
+
+// snippets contains the Go code the code levels are played on, it is bundled with the binary.
+// The snippets are text files so they are not built with the module
+//
+//go:embed snippets/*.go.txt
+var snippets embed.FS
+
+// snippetLines returns the lines of the Go snippet with the given name, tabs are expanded to four spaces
+// since the text of a level has a cell for every character
+func snippetLines(name string) ([]string, error) {
+	data, err := snippets.ReadFile("snippets/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snippet: %w", err)
+	}
+	text := strings.ReplaceAll(strings.TrimRight(string(data), "\n"), "\t", "    ")
+	return strings.Split(text, "\n"), nil
+}
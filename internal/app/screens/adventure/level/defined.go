@@ -0,0 +1,29 @@
+package level
+
+import "github.com/dasvh/go-learn-vim/internal/models"
+
+// NewDefinedLevel returns a new instance of models.Level that plays the models.LevelDefinition,
+// like the levels with spots the targets have to be reached in order by moving onto them
+func NewDefinedLevel(def models.LevelDefinition) models.Level {
+	spots := make([]models.Position, len(def.Targets))
+	par := make([]int, len(def.Targets))
+	hints := make([]string, len(def.Targets))
+	for i, target := range def.Targets {
+		spots[i] = target.Position
+		par[i] = target.Par
+		hints[i] = target.Hint
+	}
+	return &TextLevel{
+		number:      def.Number,
+		description: def.Description,
+		lesson:      def.Lesson,
+		content:     def.Text,
+		wall:        def.Wall,
+		start:       def.Start,
+		allowed:     def.Allowed,
+		spots:       spots,
+		par:         par,
+		hints:       hints,
+		chars:       &models.DefaultCharacters,
+	}
+}
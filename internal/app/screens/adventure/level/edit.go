@@ -0,0 +1,152 @@
+package level
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// edit is a change the player has to make to the text, the operator has to act on the span
+// exactly, which is checked by comparing the text with the text the edit results in
+type edit struct {
+	operator models.Operator
+	span     vim.Span
+}
+
+// AllowedOperators returns the operators that can be used in the level
+func (tl *TextLevel) AllowedOperators() []models.Operator {
+	return tl.operators
+}
+
+// ApplyOperation handles a models.Operation, the operator acts on the text and the result
+// has to match the current edit of the level or the text is restored for another try.
+// In a level with a goal the text is kept and checked against the goal instead
+func (tl *TextLevel) ApplyOperation(operation models.Operation) models.PlayerMovement {
+	if !slices.Contains(tl.operators, operation.Operator) {
+		return tl.invalidMove(fmt.Sprintf("%s is not used in this level.", operation.Operator))
+	}
+	return tl.operate(operation, operation.Keystrokes())
+}
+
+// operate applies the models.Operation to the text, the keystrokes are added to the keystrokes spent
+// on the current target. Like in Vim every operation but a yank is remembered to be repeated with .
+func (tl *TextLevel) operate(operation models.Operation, keystrokes int) models.PlayerMovement {
+	span, message := tl.span(operation)
+	if message != "" {
+		return tl.invalidMove(message)
+	}
+	selecting := len(tl.visualModes) > 0 && tl.edits != nil
+	if selecting && !operation.Visual {
+		return tl.invalidMove("Select the text in visual mode before using an operator.")
+	}
+	// like in Vim an operator ends visual mode
+	tl.selection.clear()
+	if selecting && span != tl.edits[tl.currentTarget].span {
+		return tl.retry(fmt.Sprintf("The selection does not match the highlighted text, try again. %s", tl.GetInstructions()))
+	}
+
+	switch {
+	case operation.Operator == models.OperatorYank:
+		tl.history.begin(tl.history.lastChange, tl.player)
+	case operation.Visual:
+		// unlike in Vim a change made in visual mode cannot be repeated
+		tl.history.begin(nil, tl.player)
+	default:
+		tl.history.begin(&repeatable{operation: &operation}, tl.player)
+	}
+	tl.register, tl.player = vim.Operate(tl.buffer, tl.player, span, operation.Operator)
+	if operation.Operator == models.OperatorChange && len(tl.inserts) > 0 {
+		// like in Vim a change continues in insert mode where the text was
+		tl.inserting = true
+	} else {
+		tl.player = tl.buffer.Clamp(tl.player)
+		tl.commit()
+	}
+	tl.window.Follow(tl.buffer, tl.player.Y)
+	tl.spend(keystrokes)
+
+	switch par := tl.currentPar(); {
+	case tl.goal != nil:
+		return tl.checkGoal()
+	case !tl.editDone(operation.Operator):
+		return tl.retry(fmt.Sprintf("%s does not match the goal, try again. %s", operation, tl.GetInstructions()))
+	case par > 0 && tl.keys.spent > par:
+		return tl.retry(tl.overParMessage())
+	}
+	return tl.reach()
+}
+
+// span returns the vim.Span the models.Operation acts on, it returns a message when there is none
+func (tl *TextLevel) span(operation models.Operation) (vim.Span, string) {
+	switch {
+	case operation.Visual && !tl.selection.active():
+		return vim.Span{}, "There is no selection to act on."
+	case operation.Visual:
+		return tl.selection.span(tl.buffer, tl.player), ""
+	case operation.Linewise:
+		return vim.LineSpan(tl.buffer, tl.player, operation.Count), ""
+	case operation.Object != models.TextObjectNone:
+		if span, ok := vim.TextObjectSpan(tl.buffer, tl.player, operation.Object, operation.Count); ok {
+			return span, ""
+		}
+		return vim.Span{}, fmt.Sprintf("%s has no text to act on here.", operation)
+	}
+
+	motion := operation.Motion
+	if !slices.Contains(tl.allowed, motion.Kind) {
+		return vim.Span{}, fmt.Sprintf("%s is not used in this level.", operation)
+	}
+	motion, message := tl.prepare(motion)
+	if message != "" {
+		return vim.Span{}, message
+	}
+	// like in Vim a motion that cannot move still works with an operator, as in dgg on the first line,
+	// as long as it did not fail to find what it looks for
+	end, moved := tl.resolve(motion)
+	if message := tl.notMovedMessage(motion); !moved && message != "" {
+		return vim.Span{}, message
+	}
+	if span, ok := vim.MotionSpan(tl.buffer, tl.player, end, motion, operation.Operator); ok {
+		return span, ""
+	}
+	return vim.Span{}, fmt.Sprintf("%s has no text to act on here.", operation)
+}
+
+// replayEdits applies the edits before the current target to the text, and the current
+// one as well when the level is completed, to restore the text of a saved level
+func (tl *TextLevel) replayEdits(current int, completed bool) {
+	done := current
+	if completed {
+		done++
+	}
+	for _, e := range tl.edits[:min(done, len(tl.edits))] {
+		vim.Operate(tl.buffer, e.span.Start, e.span, e.operator)
+	}
+}
+
+// editDone reports whether the models.Operator that was just applied made the current edit,
+// which is when the text and the register are the same as the edit would leave them
+func (tl *TextLevel) editDone(operator models.Operator) bool {
+	e := tl.edits[tl.currentTarget]
+	goal := vim.NewBuffer(tl.attemptLines)
+	register, _ := vim.Operate(goal, e.span.Start, e.span, e.operator)
+	return operator == e.operator && tl.register == register && slices.Equal(tl.buffer.Lines(), goal.Lines())
+}
+
+// editGoal describes the current edit
+func (tl *TextLevel) editGoal() string {
+	e := tl.edits[tl.currentTarget]
+	switch {
+	case len(tl.visualModes) > 0:
+		return fmt.Sprintf("Select the highlighted text with %s and %s it", visualKey(e.span), strings.ToLower(e.operator.Verb()))
+	case !e.span.Linewise:
+		return fmt.Sprintf("%s %q on line %d", e.operator.Verb(), tl.buffer.Text(e.span), e.span.Start.Y+1)
+	case e.span.Start.Y == e.span.End.Y:
+		return fmt.Sprintf("%s line %d", e.operator.Verb(), e.span.Start.Y+1)
+	default:
+		return fmt.Sprintf("%s lines %d to %d", e.operator.Verb(), e.span.Start.Y+1, e.span.End.Y+1)
+	}
+}
@@ -0,0 +1,50 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+const levelNumberEight = 8
+
+// editText is the text of level eight, every line has something to delete, change or yank
+var editText = []string{
+	"Operators act on the words that a motion moves over.",
+	"Delete the really unwanted word on this line with dw.",
+	"A doubled operator works on whole lines, like dd.",
+	"This whole line is a mistake and should be removed.",
+	"Cut the end of this line from the comma on, like this part.",
+	"The c operator changes a word, cw on plum keeps the space after it.",
+	"Yank the word yellow with yiw, the line stays as it is.",
+	"Drop these words up to the colon: the rest stays.",
+	"",
+	"Counts work too, d2w takes these two words at once.",
+	"Use daw on the spare word to take its space along.",
+}
+
+// NewLevelEight returns a new instance of models.Level eight,
+// the spans of the edits are positions in the text as it is after the edits before them
+func NewLevelEight() models.Level {
+	return &TextLevel{
+		number:      levelNumberEight,
+		description: "Delete, change and yank text with operators",
+		lesson:      "d, c and y with a motion, dd, iw and aw",
+		content:     editText,
+		allowed:     slices.Concat(models.BasicMotions, models.WordMotions, models.LineMotions, models.FindMotions),
+		operators:   models.Operators,
+		edits: []edit{
+			{models.OperatorDelete, vim.Span{Start: models.Position{X: 11, Y: 1}, End: models.Position{X: 18, Y: 1}}},   // j2wdw
+			{models.OperatorDelete, vim.Span{Start: models.Position{Y: 3}, End: models.Position{Y: 3}, Linewise: true}}, // 2jdd
+			{models.OperatorDelete, vim.Span{Start: models.Position{X: 42, Y: 3}, End: models.Position{X: 59, Y: 3}}},   // f,d$
+			{models.OperatorChange, vim.Span{Start: models.Position{X: 37, Y: 4}, End: models.Position{X: 41, Y: 4}}},   // jbcw
+			{models.OperatorYank, vim.Span{Start: models.Position{X: 14, Y: 5}, End: models.Position{X: 20, Y: 5}}},     // j6byiw
+			{models.OperatorDelete, vim.Span{Start: models.Position{X: 0, Y: 6}, End: models.Position{X: 32, Y: 6}}},    // j0dt:
+			{models.OperatorDelete, vim.Span{Start: models.Position{X: 27, Y: 8}, End: models.Position{X: 37, Y: 8}}},   // 2j5Wd2w
+			{models.OperatorDelete, vim.Span{Start: models.Position{X: 15, Y: 9}, End: models.Position{X: 21, Y: 9}}},   // j3bdaw
+		},
+		par:   []int{5, 4, 4, 4, 6, 5, 7, 6},
+		chars: &models.DefaultCharacters,
+	}
+}
@@ -0,0 +1,45 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const levelNumberEleven = 11
+
+// repeatText is the text of level eleven, the same change has to be made in several places
+var repeatText = []string{
+	"A dot repeats the last change, u undoes it and ctrl+r redoes it.",
+	"The very old, very slow and very tired dog sleeps.",
+	"Every line below is missing a semicolon at its end:",
+	"let a = 1",
+	"let b = 2",
+	"let c = 3",
+}
+
+// repeatGoal is the text level eleven is completed with
+var repeatGoal = []string{
+	"A dot repeats the last change, u undoes it and ctrl+r redoes it.",
+	"The old, slow and tired dog sleeps.",
+	"Every line below is missing a semicolon at its end:",
+	"let a = 1;",
+	"let b = 2;",
+	"let c = 3;",
+}
+
+// NewLevelEleven returns a new instance of models.Level eleven
+func NewLevelEleven() models.Level {
+	return &TextLevel{
+		number:       levelNumberEleven,
+		description:  "Undo, redo and repeat changes",
+		lesson:       "., u and ctrl+r",
+		content:      repeatText,
+		allowed:      slices.Concat(models.BasicMotions, models.WordMotions, models.LineMotions, models.SearchMotions),
+		operators:    models.Operators,
+		inserts:      models.InsertKinds,
+		historyKinds: models.HistoryKinds,
+		goal:         repeatGoal,
+		chars:        &models.DefaultCharacters,
+	}
+}
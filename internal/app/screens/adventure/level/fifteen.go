@@ -0,0 +1,81 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const levelNumberFifteen = 15
+
+// pairSnippet is the Go snippet level fifteen is played on
+const pairSnippet = "brackets.go.txt"
+
+// pairSteps is the route through level fifteen, every step ends on a target
+var pairSteps = []routeStep{
+	{
+		[]models.Motion{
+			{Kind: models.MotionLastLine, Count: 8, Keys: []string{"8", "G"}},
+			{Kind: models.MotionMatchPair, Keys: []string{"%"}},
+		},
+		"% jumps from the first bracket under or after the cursor to the one matching it",
+	},
+	{
+		[]models.Motion{
+			{Kind: models.MotionEndOfLine, Keys: []string{"$"}},
+			{Kind: models.MotionMatchPair, Keys: []string{"%"}},
+		},
+		"Brackets in character literals like '{' are skipped",
+	},
+	{
+		[]models.Motion{
+			{Kind: models.MotionDown, Count: 3, Keys: []string{"3", "j"}},
+			{Kind: models.MotionEndOfLine, Keys: []string{"$"}},
+			{Kind: models.MotionMatchPair, Keys: []string{"%"}},
+		},
+		"From an opening brace % jumps over the whole body and the braces nested in it",
+	},
+	{
+		[]models.Motion{
+			{Kind: models.MotionUp, Count: 3, Keys: []string{"3", "k"}},
+			{Kind: models.MotionMatchPair, Keys: []string{"%"}},
+		},
+		"From a closing brace % jumps back to the brace it closes",
+	},
+	{
+		[]models.Motion{
+			{Kind: models.MotionDown, Count: 4, Keys: []string{"4", "j"}},
+			{Kind: models.MotionFindForward, Char: '[', Keys: []string{"f", "["}},
+			{Kind: models.MotionMatchPair, Keys: []string{"%"}},
+		},
+		"The ( and ) nested inside are skipped on the way to the ]",
+	},
+	{
+		[]models.Motion{
+			{Kind: models.MotionLastLine, Count: 29, Keys: []string{"2", "9", "G"}},
+			{Kind: models.MotionStartOfLine, Keys: []string{"0"}},
+			{Kind: models.MotionMatchPair, Keys: []string{"%"}},
+		},
+		`The ) of the smiley in ":)" is skipped since it is quoted, the one in the comment above has no match at all`,
+	},
+}
+
+// NewLevelFifteen returns a new instance of models.Level fifteen, the text is the Go snippet bundled with the binary
+// and the targets are where the steps of the route end. When the snippet cannot be loaded the level cannot be played
+func NewLevelFifteen() models.Level {
+	level := &TextLevel{
+		number:      levelNumberFifteen,
+		description: "Jump between matching brackets in Go code",
+		lesson:      "%",
+		allowed:     slices.Concat(models.BasicMotions, models.WordMotions, models.LineMotions, models.FindMotions, []models.MotionKind{models.MotionMatchPair}),
+		chars:       &models.DefaultCharacters,
+	}
+	content, err := snippetLines(pairSnippet)
+	if err != nil {
+		level.loadErr = err
+		return level
+	}
+	level.content = content
+	level.spots, level.par, level.hints = resolveRoute(content, pairSteps)
+	return level
+}
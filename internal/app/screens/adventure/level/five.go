@@ -0,0 +1,100 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const levelNumberFive = 5
+
+// scrollText is the text of level five, it is longer than most windows so it has to be scrolled
+var scrollText = []string{
+	"A long file does not fit on the screen, so the window only shows a part of it.",
+	"Scroll with ctrl+d and ctrl+u to move half a window down or up,",
+	"the cursor moves along with the lines it was on.",
+	"ctrl+f and ctrl+b move a whole page, two lines of the old page stay in view.",
+	"",
+	"H, M and L jump to the top, middle and bottom line of the window.",
+	"zz, zt and zb scroll the window so the cursor line is in the middle, top or bottom.",
+	"",
+	"Part 1: the harbour",
+	"    The boats leave the harbour before the sun comes up.",
+	"    Nets are folded on the decks and the engines hum quietly.",
+	"    Gulls follow the boats until the coast is only a thin line.",
+	"    By noon the catch is sorted and the crew rests in the shade.",
+	"",
+	"Part 2: the market",
+	"    Stalls open along the quay as soon as the first boat returns.",
+	"    Cooks from the town come early to pick the freshest fish.",
+	"    Prices are shouted, written on boards and shouted again.",
+	"    When the bell rings at two the market closes for the day.",
+	"",
+	"Part 3: the workshop",
+	"    Behind the market an old workshop repairs sails and ropes.",
+	"    The floor is covered in canvas, thread and wooden pegs.",
+	"    An apprentice learns to splice a rope in under a minute.",
+	"    The master checks every knot before it leaves the bench.",
+	"",
+	"Part 4: the lighthouse",
+	"    On the cliff stands a lighthouse that is older than the town.",
+	"    Its keeper climbs one hundred and twelve steps every evening.",
+	"    The lamp turns once every ten seconds through the night.",
+	"    Ships far out at sea count the flashes to know where they are.",
+	"",
+	"Part 5: the storm",
+	"    In autumn the wind turns and the sky becomes dark and heavy.",
+	"    The boats stay in the harbour, tied twice to the quay.",
+	"    Waves climb over the wall and spray reaches the market.",
+	"    By morning the storm is gone and the town counts the damage.",
+	"",
+	"Part 6: the repairs",
+	"    Everyone helps to carry stones back onto the harbour wall.",
+	"    The workshop sews torn sails late into the evening.",
+	"    New boards are nailed onto the stalls of the market.",
+	"    After a week the town looks just as it did before.",
+	"",
+	"Part 7: the festival",
+	"    When the repairs are done the town holds a festival.",
+	"    Lanterns hang between the houses and music fills the streets.",
+	"    The keeper lets children climb the lighthouse for one night.",
+	"    Boats sail out in a long line with their lights switched on.",
+	"",
+	"Part 8: the winter",
+	"    Snow rarely falls here, but the nights grow long and cold.",
+	"    Fewer boats go out and the market opens only twice a week.",
+	"    The workshop builds a new boat from oak that dried all summer.",
+	"    In the evenings old stories are told by the fire in the inn.",
+	"",
+	"Part 9: the spring",
+	"    The days grow longer and the first swallows return to the cliff.",
+	"    The new boat is painted blue and carried down to the water.",
+	"    The whole town watches as it floats for the very first time.",
+	"    Its crew names it after the keeper of the lighthouse.",
+	"",
+	"Part 10: the end of the file",
+	"    You reached the bottom of a long file.",
+	"    G would have brought you here in a single keystroke,",
+	"    but scrolling lets you read everything on the way.",
+}
+
+// NewLevelFive returns a new instance of models.Level five,
+// it has no par since the best way through depends on the height of the window
+func NewLevelFive() models.Level {
+	return &TextLevel{
+		number:      levelNumberFive,
+		description: "Scroll through a long file",
+		lesson:      "H, M, L, ctrl+d, ctrl+u, ctrl+f, ctrl+b, zz, zt and zb",
+		content:     scrollText,
+		allowed:     slices.Concat(models.BasicMotions, models.LineMotions, models.ScreenMotions),
+		spots: []models.Position{
+			{X: 4, Y: 18}, // the market closes
+			{X: 4, Y: 36}, // the storm is gone
+			{X: 4, Y: 29}, // the lamp turns
+			{X: 4, Y: 53}, // a new boat
+			{X: 0, Y: 62}, // the end of the file
+			{X: 0, Y: 8},  // back to part 1
+		},
+		chars: &models.DefaultCharacters,
+	}
+}
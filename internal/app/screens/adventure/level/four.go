@@ -0,0 +1,42 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const levelNumberFour = 4
+
+// findText is the text of level four, every line has characters worth jumping to
+var findText = []string{
+	"Find a character on the line with f, the cursor lands right on it.",
+	"t stops just before it: call(one, two, three); done",
+	"F and T search backwards: path/to/some/deep/file.go",
+	"Press ; to repeat the last find and , to go back: a-b-c-d-e-f-g",
+	"Counts work too, 3f- jumps to the third dash: 1-2-3-4-5-6-7-8-9",
+	"key: value; other: thing; last: one",
+}
+
+// NewLevelFour returns a new instance of models.Level four
+func NewLevelFour() models.Level {
+	return &TextLevel{
+		number:      levelNumberFour,
+		description: "Jump to characters with find motions",
+		lesson:      "f, F, t, T, ; and ,",
+		content:     findText,
+		allowed:     slices.Concat(models.BasicMotions, models.FindMotions),
+		spots: []models.Position{
+			{X: 35, Y: 0}, // f,
+			{X: 43, Y: 1}, // jt)
+			{X: 34, Y: 2}, // jT/;
+			{X: 57, Y: 3}, // jte
+			{X: 49, Y: 4}, // j4F-
+			{X: 53, Y: 4}, // ,,
+			{X: 3, Y: 5},  // j3F:
+			{X: 10, Y: 5}, // f;
+		},
+		par:   []int{2, 3, 4, 3, 4, 2, 4, 2},
+		chars: &models.DefaultCharacters,
+	}
+}
@@ -0,0 +1,64 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const levelNumberFourteen = 14
+
+// proseWidth is the width the prose of level fourteen is wrapped at
+const proseWidth = 64
+
+// proseText is the text of level fourteen, the first sections of the Vim information as prose
+var proseText = proseLines(models.VimInfoSections[:2], proseWidth)
+
+// proseSteps is the route through level fourteen, every step ends on a target
+var proseSteps = []routeStep{
+	{
+		[]models.Motion{{Kind: models.MotionParagraphForward, Keys: []string{"}"}}},
+		"} jumps forward to the empty line after the paragraph",
+	},
+	{
+		[]models.Motion{{Kind: models.MotionSentenceForward, Count: 2, Keys: []string{"2", ")"}}},
+		") jumps to the next sentence, a sentence ends at a . ! or ? followed by a space or the end of the line",
+	},
+	{
+		[]models.Motion{{Kind: models.MotionParagraphForward, Count: 2, Keys: []string{"2", "}"}}},
+		"A count jumps over that many paragraphs",
+	},
+	{
+		[]models.Motion{{Kind: models.MotionSentenceForward, Count: 3, Keys: []string{"3", ")"}}},
+		") stops on an empty line as well, it is a sentence of its own",
+	},
+	{
+		[]models.Motion{{Kind: models.MotionSentenceForward, Keys: []string{")"}}},
+		"A line that does not end with a period does not end the sentence",
+	},
+	{
+		[]models.Motion{{Kind: models.MotionParagraphBackward, Keys: []string{"{"}}},
+		"{ jumps back to the empty line before the paragraph",
+	},
+	{
+		[]models.Motion{{Kind: models.MotionSentenceBackward, Keys: []string{"("}}},
+		"( jumps back to the start of the sentence, or of the previous one when already at its start",
+	},
+}
+
+// NewLevelFourteen returns a new instance of models.Level fourteen,
+// the targets are where the steps of the route end and every step is its own par
+func NewLevelFourteen() models.Level {
+	spots, par, hints := resolveRoute(proseText, proseSteps)
+	return &TextLevel{
+		number:      levelNumberFourteen,
+		description: "Move through prose by sentences and paragraphs",
+		lesson:      "(, ), { and }",
+		content:     proseText,
+		allowed:     slices.Concat(models.BasicMotions, models.WordMotions, models.ProseMotions),
+		spots:       spots,
+		par:         par,
+		hints:       hints,
+		chars:       &models.DefaultCharacters,
+	}
+}
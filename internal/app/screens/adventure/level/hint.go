@@ -0,0 +1,15 @@
+package level
+
+import (
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/solver"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// nextMotion returns the first motion of the fewest keystrokes from the cursor onto the target
+// and whether there is one, there is none when the cursor is on the target or cannot reach it
+func nextMotion(buf *vim.Buffer, cursor, target models.Position, allowed []models.MotionKind) (string, bool) {
+	solution, ok := solver.Solve(buf, cursor, target, allowed)
+	hint := solution.Hint()
+	return hint, ok && hint != ""
+}
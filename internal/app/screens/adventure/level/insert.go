@@ -0,0 +1,140 @@
+package level
+
+import (
+	"fmt"
+	"slices"
+	"unicode"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// AllowedInserts returns the commands that start insert mode in the level
+func (tl *TextLevel) AllowedInserts() []models.InsertKind {
+	return tl.inserts
+}
+
+// Inserting returns whether the level is in insert mode
+func (tl *TextLevel) Inserting() bool {
+	return tl.inserting
+}
+
+// StartInsert handles a models.Insert, which starts insert mode
+func (tl *TextLevel) StartInsert(insert models.Insert) models.PlayerMovement {
+	if !slices.Contains(tl.inserts, insert.Kind) {
+		return tl.invalidMove(fmt.Sprintf("%s is not used in this level.", insert))
+	}
+	tl.startInsert(insert)
+	tl.spend(insert.Keystrokes())
+	return tl.typed()
+}
+
+// startInsert starts insert mode for the models.Insert and remembers it to be repeated with .
+func (tl *TextLevel) startInsert(insert models.Insert) {
+	tl.history.begin(&repeatable{insert: &insert}, tl.player)
+	tl.player = vim.Insert(tl.buffer, tl.player, insert.Kind)
+	tl.inserting = true
+}
+
+// TypeKey handles a key typed in insert mode, printable characters are inserted in front
+// of the cursor, enter breaks the line and backspace deletes the character before the cursor
+func (tl *TextLevel) TypeKey(key string) models.PlayerMovement {
+	if !tl.inserting {
+		return tl.invalidMove("")
+	}
+	if message := tl.typeKey(key); message != "" {
+		return tl.invalidMove(message)
+	}
+	tl.spend(1)
+	return tl.typed()
+}
+
+// typeKey types the key into the text and adds it to the change that is repeated with .,
+// it returns a message when the key cannot be typed
+func (tl *TextLevel) typeKey(key string) string {
+	switch key {
+	case "enter":
+		tl.player = tl.buffer.SplitLine(tl.player)
+	case "backspace", "ctrl+h":
+		pos, ok := tl.buffer.Backspace(tl.player)
+		if !ok {
+			return "There is nothing in front of the cursor to delete."
+		}
+		tl.player = pos
+	default:
+		r := []rune(key)
+		if len(r) != 1 || !unicode.IsPrint(r[0]) {
+			return fmt.Sprintf("%s cannot be typed in this level.", key)
+		}
+		tl.player = tl.buffer.InsertRune(tl.player, r[0])
+	}
+	tl.history.record(key)
+	return ""
+}
+
+// StopInsert leaves insert mode, like in Vim the cursor moves back onto the last typed character
+func (tl *TextLevel) StopInsert() models.PlayerMovement {
+	if !tl.inserting {
+		return tl.invalidMove("")
+	}
+	tl.leaveInsert()
+	tl.spend(1)
+	return tl.typed()
+}
+
+// leaveInsert leaves insert mode, everything typed since insert mode started is a single change
+func (tl *TextLevel) leaveInsert() {
+	tl.inserting = false
+	tl.player = tl.buffer.Clamp(models.Position{X: tl.player.X - 1, Y: tl.player.Y})
+	tl.commit()
+}
+
+// typed shows the text after it was typed into, a level with a goal checks it against the goal
+func (tl *TextLevel) typed() models.PlayerMovement {
+	tl.window.Follow(tl.buffer, tl.player.Y)
+	if tl.goal != nil {
+		return tl.checkGoal()
+	}
+	tl.drawGrid()
+	return models.PlayerMovement{
+		UpdatedPosition:    tl.player,
+		Completed:          false,
+		ValidMove:          true,
+		InstructionMessage: tl.GetInstructions(),
+	}
+}
+
+// checkGoal completes the level once the text matches the goal, until then the instructions
+// point at the first line that differs from it. A goal with a par has to be matched within it
+func (tl *TextLevel) checkGoal() models.PlayerMovement {
+	matched := vim.FirstDifference(tl.buffer.Lines(), tl.goal) < 0
+	switch par := tl.currentPar(); {
+	case par > 0 && (tl.keys.spent > par || !matched && tl.keys.spent >= par):
+		// the goal can no longer be matched within par
+		return tl.retry(tl.overParMessage())
+	case matched:
+		tl.inserting = false
+		tl.commit()
+		return tl.reach()
+	}
+	tl.drawGrid()
+	return models.PlayerMovement{
+		UpdatedPosition:    tl.player,
+		Completed:          false,
+		ValidMove:          true,
+		InstructionMessage: tl.GetInstructions(),
+	}
+}
+
+// goalDifference describes the first line of the text that differs from the goal
+func (tl *TextLevel) goalDifference() string {
+	y := vim.FirstDifference(tl.buffer.Lines(), tl.goal)
+	switch {
+	case y < 0:
+		return "The text matches the goal"
+	case y >= len(tl.goal):
+		return fmt.Sprintf("Remove line %d", y+1)
+	default:
+		return fmt.Sprintf("Make line %d read %q", y+1, tl.goal[y])
+	}
+}
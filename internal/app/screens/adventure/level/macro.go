@@ -0,0 +1,47 @@
+package level
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// keystrokes counts the keystrokes spent on the current target,
+// the keys a macro replays are not counted again
+type keystrokes struct {
+	spent     int
+	replaying bool
+}
+
+// spend adds keystrokes to the keystrokes spent, unless they are replayed by a macro
+func (k *keystrokes) spend(keystrokes int) {
+	if !k.replaying {
+		k.spent += keystrokes
+	}
+}
+
+// AllowedMacros returns the commands that record and replay macros in the level
+func (tl *TextLevel) AllowedMacros() []models.MacroKind {
+	return tl.macroKinds
+}
+
+// ApplyMacro handles a models.Macro, the keys are recorded and replayed outside the level
+// so only the keys of the command itself are spent
+func (tl *TextLevel) ApplyMacro(macro models.Macro) models.PlayerMovement {
+	if !slices.Contains(tl.macroKinds, macro.Kind) {
+		return tl.invalidMove(fmt.Sprintf("%s is not used in this level.", macro))
+	}
+	tl.spend(macro.Keystrokes())
+	return tl.selected()
+}
+
+// SetReplaying sets whether a macro is being replayed, the keys it replays are not spent
+func (tl *TextLevel) SetReplaying(replaying bool) {
+	tl.keys.replaying = replaying
+}
+
+// spend adds keystrokes to the keystrokes spent on the current target
+func (tl *TextLevel) spend(keystrokes int) {
+	tl.keys.spend(keystrokes)
+}
@@ -0,0 +1,76 @@
+package level
+
+import (
+	"fmt"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// marks are the positions the player marked in the text by their name
+// and the jump list of the positions jumps were made from
+type marks struct {
+	named map[rune]models.Position
+	jumps vim.JumpList
+}
+
+// reset removes every mark and empties the jump list
+func (m *marks) reset() {
+	m.named = make(map[rune]models.Position)
+	m.jumps = vim.JumpList{}
+}
+
+// restore sets the saved marks, marks that do not belong to the text of the buffer are skipped
+func (m *marks) restore(saved map[string]models.Position, buf *vim.Buffer) {
+	m.named = make(map[rune]models.Position, len(saved))
+	for name, pos := range saved {
+		if r := []rune(name); len(r) == 1 && vim.IsMarkName(r[0]) && buf.Contains(pos) {
+			m.named[r[0]] = pos
+		}
+	}
+}
+
+// isSet returns whether the mark with the name is set
+func (m *marks) isSet(name rune) bool {
+	_, ok := m.named[name]
+	return ok
+}
+
+// resolve returns the position a motion to a mark or through the jump list goes to from the cursor,
+// it returns false for other motions
+func (m *marks) resolve(buf *vim.Buffer, cursor models.Position, motion models.Motion) (models.Position, bool) {
+	count := max(motion.Count, 1)
+	switch motion.Kind {
+	case models.MotionJumpMarkLine, models.MotionJumpMark:
+		return vim.ResolveMark(buf, m.named[motion.Char], motion.Kind == models.MotionJumpMarkLine), true
+	case models.MotionJumpOlder:
+		pos, _ := m.jumps.Older(cursor, count)
+		return pos, true
+	case models.MotionJumpNewer:
+		pos, _ := m.jumps.Newer(cursor, count)
+		return pos, true
+	default:
+		return models.Position{}, false
+	}
+}
+
+// setMark sets the mark named by the models.Motion at the cursor, which does not move it
+func (tl *TextLevel) setMark(motion models.Motion) models.PlayerMovement {
+	tl.marks.named[motion.Char] = tl.player
+	tl.spend(motion.Keystrokes())
+	return models.PlayerMovement{
+		UpdatedPosition:    tl.player,
+		Completed:          false,
+		ValidMove:          true,
+		InstructionMessage: fmt.Sprintf("Mark %c set. %s", motion.Char, tl.GetInstructions()),
+	}
+}
+
+// Marks returns the marks set in the level by their name
+func (tl *TextLevel) Marks() map[string]models.Position {
+	marks := make(map[string]models.Position, len(tl.marks.named))
+	for name, pos := range tl.marks.named {
+		marks[string(name)] = pos
+	}
+	return marks
+}
@@ -1,7 +1,11 @@
 package level
 
 import (
+	"fmt"
+	"github.com/dasvh/go-learn-vim/internal/maze"
 	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+	"reflect"
 	"testing"
 )
 
@@ -88,6 +92,21 @@ func Test_MazeGeneration(t *testing.T) {
 			t.Error("expected path to exist between start and end positions")
 		}
 	})
+
+	t.Run("generators", func(t *testing.T) {
+		for _, name := range maze.Names() {
+			generator, _ := maze.Named(name)
+			m := NewMazeWith(generator, 21, 42, 5, 5, 2)
+			target := NewMazeTargets(&models.DefaultCharacters, m).DefineTargets()[0].Position
+			path := findPath(m.StartPosition, target, makeWallMap(m.GetWalls()), m.offsetX, m.offsetY, m.width, m.height)
+			if path == nil {
+				t.Errorf("%s: expected path to exist between start and target", name)
+			}
+			if !reflect.DeepEqual(m.GetWalls(), NewMazeWith(generator, 21, 42, 5, 5, 2).GetWalls()) {
+				t.Errorf("%s: expected maze with same seed to have identical walls", name)
+			}
+		}
+	})
 }
 
 func makeWallMap(walls []models.Position) map[models.Position]bool {
@@ -189,3 +208,40 @@ func isValidCorridor(from, to models.Position, walls map[models.Position]bool) b
 	}
 	return true
 }
+
+// benchmarkSizes are the sizes of the mazes in the benchmarks, 40 is the size the levels suggest
+var benchmarkSizes = []int{suggestedMazeSize, 400, 2000}
+
+func Benchmark_MazeGeneration(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			for b.Loop() {
+				NewMaze(size, 42, 5, 5, 1)
+			}
+		})
+	}
+}
+
+func Benchmark_MazeMovement(b *testing.B) {
+	// the motions walk the cursor around in a square until the walls stop it
+	motions := []models.Motion{
+		{Kind: models.MotionRight, Count: 10},
+		{Kind: models.MotionDown, Count: 10},
+		{Kind: models.MotionLeft, Count: 10},
+		{Kind: models.MotionUp, Count: 10},
+	}
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			maze := NewMaze(size, 42, 5, 5, 1)
+			targets := NewMazeTargets(&models.DefaultCharacters, maze)
+			buf := vim.NewBlankBuffer(size+10, size+10)
+			buf.SetWalls(targets.isPositionCollidingWithWall)
+			pos := maze.StartPosition
+			i := 0
+			for b.Loop() {
+				pos, _ = vim.Resolve(buf, pos, motions[i%len(motions)])
+				i++
+			}
+		})
+	}
+}
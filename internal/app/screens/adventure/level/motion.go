@@ -0,0 +1,99 @@
+package level
+
+import (
+	"fmt"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// lastMotions are the last find for ; and , and the last search for n and N to repeat
+type lastMotions struct {
+	find   *models.Motion
+	search *models.Motion
+}
+
+// complete completes a models.Motion that repeats a find or a search with the last one and
+// remembers a find or a search to repeat, it returns a message when there is nothing to repeat
+func (l *lastMotions) complete(motion models.Motion) (models.Motion, string) {
+	switch {
+	case motion.Kind == models.MotionRepeatFind || motion.Kind == models.MotionRepeatFindReverse:
+		if l.find == nil {
+			return motion, "There is no f, F, t or T to repeat yet."
+		}
+		motion = vim.RepeatFind(*l.find, motion)
+	case motion.Kind.IsFind():
+		// like in Vim the find is remembered even when the character is not found
+		l.find = &motion
+	case motion.Kind == models.MotionSearchNext || motion.Kind == models.MotionSearchPrevious:
+		if l.search == nil {
+			return motion, "There is no search to repeat yet."
+		}
+		motion = vim.RepeatSearch(*l.search, motion)
+	case motion.Kind.NeedsPattern():
+		// like in Vim an empty pattern searches for the last pattern again
+		if motion.Pattern == "" {
+			if l.search == nil {
+				return motion, "There is no previous search pattern."
+			}
+			motion.Pattern = l.search.Pattern
+		}
+		search := motion
+		l.search = &search
+	}
+	return motion, ""
+}
+
+// prepare completes a models.Motion with what the level remembers, like the last find for ;
+// or the last pattern for n, it returns a message when the motion cannot be used
+func (tl *TextLevel) prepare(motion models.Motion) (models.Motion, string) {
+	switch {
+	case motion.Kind.IsMark() && !vim.IsMarkName(motion.Char):
+		return motion, "Marks are named with a letter from a to z."
+	case motion.Kind == models.MotionSetMark:
+		return motion, fmt.Sprintf("%s does not move the cursor.", motion)
+	case motion.Kind.IsMark() && !tl.marks.isSet(motion.Char):
+		return motion, fmt.Sprintf("Mark %c is not set.", motion.Char)
+	}
+	return tl.last.complete(motion)
+}
+
+// notMovedMessage explains why a models.Motion did not move the cursor
+func (tl *TextLevel) notMovedMessage(motion models.Motion) string {
+	switch {
+	case motion.Kind == models.MotionMatchPair:
+		bracket, ok := vim.NextBracket(tl.buffer, tl.player)
+		if !ok {
+			return "There is no bracket under or after the cursor on this line for % to jump from."
+		}
+		return fmt.Sprintf("%c has no matching bracket.", tl.buffer.Line(bracket.Y)[bracket.X])
+	case motion.Kind.IsFind():
+		return fmt.Sprintf("%q was not found %s on this line.", motion.Char, findDirection(motion.Kind))
+	case motion.Kind.NeedsPattern():
+		return fmt.Sprintf("Pattern not found: %s.", motion.Pattern)
+	case motion.Kind == models.MotionJumpOlder || motion.Kind == models.MotionJumpNewer:
+		return "There is no position to jump to in the jump list."
+	default:
+		return ""
+	}
+}
+
+// resolve returns the position reached by applying the models.Motion and whether the cursor or
+// the window moved, marks and the jump list are kept by the level itself
+func (tl *TextLevel) resolve(motion models.Motion) (models.Position, bool) {
+	pos, ok := tl.marks.resolve(tl.buffer, tl.player, motion)
+	if !ok {
+		return tl.window.Resolve(tl.buffer, tl.player, motion)
+	}
+	pos = tl.buffer.Clamp(pos)
+	tl.window.Follow(tl.buffer, pos.Y)
+	return pos, pos != tl.player
+}
+
+// findDirection describes where a find motion searches for its character
+func findDirection(kind models.MotionKind) string {
+	if kind == models.MotionFindBackward || kind == models.MotionTillBackward {
+		return "before the cursor"
+	}
+	return "after the cursor"
+}
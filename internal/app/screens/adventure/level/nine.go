@@ -0,0 +1,46 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const levelNumberNine = 9
+
+// insertText is the text of level nine, most of its lines are missing words
+var insertText = []string{
+	"Insert mode types words, esc goes back to normal mode.",
+	"The quick cat naps on the mat.",
+	"This line ends without a full stop",
+	"line starts in lower case.",
+	"The line below is missing:",
+	"Open a line above this one with O.",
+}
+
+// insertGoal is the text level nine is completed with
+var insertGoal = []string{
+	"Insert mode types words, esc goes back to normal mode.",
+	"The quick brown cat naps on the warm mat.",
+	"This line ends without a full stop.",
+	"A line starts in lower case.",
+	"The line below is missing:",
+	"Here it is, opened with o.",
+	"And this one with O.",
+	"Open a line above this one with O.",
+}
+
+// NewLevelNine returns a new instance of models.Level nine
+func NewLevelNine() models.Level {
+	return &TextLevel{
+		number:      levelNumberNine,
+		description: "Type text in insert mode",
+		lesson:      "i, a, I, A, o and O, esc to leave insert mode",
+		content:     insertText,
+		allowed:     slices.Concat(models.BasicMotions, models.WordMotions, models.LineMotions),
+		operators:   models.Operators,
+		inserts:     models.InsertKinds,
+		goal:        insertGoal,
+		chars:       &models.DefaultCharacters,
+	}
+}
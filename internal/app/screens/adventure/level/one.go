@@ -2,8 +2,12 @@ package level
 
 import (
 	"fmt"
-	"github.com/dasvh/go-learn-vim/internal/models"
 	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/maze"
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/solver"
+	"github.com/dasvh/go-learn-vim/internal/vim"
 )
 
 const (
@@ -11,6 +15,13 @@ const (
 	suggestedMazeSize = 40
 )
 
+// mazeMotions are the motions that can be used in the mazes,
+// they have no text so ^ is left out
+var mazeMotions = slices.Concat(models.BasicMotions, []models.MotionKind{
+	models.MotionStartOfLine, models.MotionEndOfLine,
+	models.MotionFirstLine, models.MotionLastLine,
+})
+
 // One represents level one of the adventure mode
 type One struct {
 	width          int
@@ -18,11 +29,13 @@ type One struct {
 	currentMaze    int
 	totalMazes     int
 	seeds          []int64
+	generators     []maze.Generator
 	mazes          []*Maze
 	completed      bool
 	restore        bool
 	inProgress     bool
 	grid           [][]rune
+	buffer         *vim.Buffer
 	chars          *models.Characters
 	player         models.Position
 	targets        []models.Target
@@ -36,6 +49,7 @@ func NewLevelOne() models.Level {
 		chars:          chars,
 		totalMazes:     2,
 		seeds:          []int64{42, 73},
+		generators:     []maze.Generator{maze.DFS{}, maze.DFS{}},
 		targetBehavior: []*MazeTargets{},
 	}
 }
@@ -47,36 +61,39 @@ func (level1 *One) Number() int {
 
 // Description returns the description of the level
 func (level1 *One) Description() string {
-	return "Navigate two mazes using hjkl keys"
+	return "Navigate two mazes using hjkl and line motions"
 }
 
-// Init initializes the level with the given dimensions
-func (level1 *One) Init(width, height int) {
+// Init initializes the level with the given dimensions, it returns an error when the mazes do not fit
+func (level1 *One) Init(width, height int) error {
 	level1.restore = false
 	level1.completed = false
-	level1.inProgress = true
 	level1.currentMaze = 0
 	level1.width = width
 	level1.height = height
 	level1.setDimensions(width, height)
 
 	if err := level1.setupMazesAndTargets(width, height); err != nil {
-		panic(fmt.Sprintf("Failed to initialize level: %v", err))
+		return err
 	}
 
+	level1.inProgress = true
 	level1.resetTargets()
 	level1.initializeGrid()
+	return nil
+}
+
+// AllowedMotions returns the motions that can be used in the level
+func (level1 *One) AllowedMotions() []models.MotionKind {
+	return mazeMotions
 }
 
 // PlayerMove handles models.PlayerMovement and transitions between mazes
-func (level1 *One) PlayerMove(delta models.Position) models.PlayerMovement {
-	newPos := models.Position{
-		X: level1.player.X + delta.X,
-		Y: level1.player.Y + delta.Y,
-	}
+func (level1 *One) PlayerMove(motion models.Motion) models.PlayerMovement {
+	newPos, moved := vim.Resolve(level1.buffer, level1.player, motion)
 
-	// check if the player collides with a wall
-	if slices.Contains(level1.targetBehavior[level1.currentMaze].maze.GetWalls(), newPos) {
+	// check if the motion is allowed and the player is not stopped by a wall
+	if !slices.Contains(level1.AllowedMotions(), motion.Kind) || !moved {
 		return models.PlayerMovement{
 			UpdatedPosition:    level1.player,
 			Completed:          false,
@@ -158,7 +175,31 @@ func (level1 *One) GetCurrentTarget() int {
 
 // GetInstructions returns the instructions for the level
 func (level1 *One) GetInstructions() string {
-	return fmt.Sprintf("Instructions: Maze %d/%d: Reach the X using hjkl keys", level1.currentMaze+1, level1.totalMazes)
+	return fmt.Sprintf("Instructions: Maze %d/%d: Reach the X using hjkl, 0, $, gg and G", level1.currentMaze+1, level1.totalMazes)
+}
+
+// Par returns the fewest keystrokes it takes to reach the targets of both mazes from their starts
+func (level1 *One) Par() (int, bool) {
+	total := 0
+	for i, behavior := range level1.targetBehavior {
+		buf := vim.NewBlankBuffer(level1.width, level1.height)
+		buf.SetWalls(behavior.isPositionCollidingWithWall)
+		target := behavior.DefineTargets()[0].Position
+		solution, ok := solver.Solve(buf, level1.mazes[i].StartPosition, target, level1.AllowedMotions())
+		if !ok {
+			return 0, false
+		}
+		total += solution.Keystrokes
+	}
+	return total, len(level1.targetBehavior) > 0
+}
+
+// Hint returns the first motion of the fewest keystrokes onto the target of the current maze
+func (level1 *One) Hint() (string, bool) {
+	if level1.completed || len(level1.targets) == 0 {
+		return "", false
+	}
+	return nextMotion(level1.buffer, level1.player, level1.targets[0].Position, level1.AllowedMotions())
 }
 
 // InProgress returns whether the level is in progress
@@ -228,8 +269,8 @@ func (level1 *One) setupMazesAndTargets(width, height int) error {
 	maze1OffsetX, maze2OffsetX, centerY := calculateMazeOffsets(width, height, maxMazeSize)
 
 	level1.mazes = []*Maze{
-		NewMaze(maxMazeSize, level1.seeds[0], maze1OffsetX, centerY, 3),
-		NewMaze(maxMazeSize, level1.seeds[1], maze2OffsetX, centerY, 2),
+		NewMazeWith(level1.generators[0], maxMazeSize, level1.seeds[0], maze1OffsetX, centerY, 3),
+		NewMazeWith(level1.generators[1], maxMazeSize, level1.seeds[1], maze2OffsetX, centerY, 2),
 	}
 
 	level1.targetBehavior = []*MazeTargets{
@@ -268,6 +309,10 @@ func (level1 *One) clearGrid() {
 func (level1 *One) setDimensions(width, height int) {
 	level1.width = width
 	level1.height = height
+	level1.buffer = vim.NewBlankBuffer(width, height)
+	level1.buffer.SetWalls(func(pos models.Position) bool {
+		return level1.targetBehavior[level1.currentMaze].isPositionCollidingWithWall(pos)
+	})
 	level1.grid = make([][]rune, height)
 	for y := range level1.grid {
 		level1.grid[y] = make([]rune, width)
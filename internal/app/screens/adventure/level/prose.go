@@ -0,0 +1,63 @@
+package level
+
+import (
+	"strings"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// proseLines turns the sections into prose wrapped at the width, every title and paragraph is followed by an
+// empty line. Lines of a paragraph are joined, items of a list stay on lines of their own and markup is removed
+func proseLines(sections []models.Section, width int) []string {
+	var lines []string
+	for _, section := range sections {
+		lines = append(lines, section.Title, "")
+		var items []string
+		flush := func() {
+			for _, item := range items {
+				lines = append(lines, wrap(item, width)...)
+			}
+			if len(items) > 0 {
+				lines = append(lines, "")
+			}
+			items = nil
+		}
+		for _, line := range strings.Split(section.Content, "\n") {
+			line = strings.TrimSpace(strings.ReplaceAll(line, "**", ""))
+			switch {
+			case line == "":
+				flush()
+			case strings.HasPrefix(line, "- ") || len(items) == 0:
+				items = append(items, line)
+			default:
+				items[len(items)-1] += " " + line
+			}
+		}
+		flush()
+	}
+	// the text does not end with an empty line
+	return lines[:len(lines)-1]
+}
+
+// wrap breaks the text into lines at most width long, the lines after the first line of an item of a list
+// are indented to line up with its text
+func wrap(text string, width int) []string {
+	indent := ""
+	if strings.HasPrefix(text, "- ") {
+		indent = "  "
+	}
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) > width:
+			lines = append(lines, line)
+			line = indent + word
+		default:
+			line += " " + word
+		}
+	}
+	return append(lines, line)
+}
@@ -0,0 +1,32 @@
+package level
+
+import (
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// routeStep is a step of the route through a level, the target of the step
+// is where its motions end and the hint explains how they get there
+type routeStep struct {
+	motions []models.Motion
+	hint    string
+}
+
+// resolveRoute resolves the steps from the start of the text, it returns the spot every step ends at,
+// the keystrokes of the motions of the step as its par and the hint of the step
+func resolveRoute(content []string, steps []routeStep) ([]models.Position, []int, []string) {
+	buf := vim.NewBuffer(content)
+	var pos models.Position
+	spots := make([]models.Position, len(steps))
+	par := make([]int, len(steps))
+	hints := make([]string, len(steps))
+	for i, step := range steps {
+		for _, motion := range step.motions {
+			pos, _ = vim.Resolve(buf, pos, motion)
+			par[i] += motion.Keystrokes()
+		}
+		spots[i] = pos
+		hints[i] = step.hint
+	}
+	return spots, par, hints
+}
@@ -0,0 +1,77 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const levelNumberSeven = 7
+
+// harbourText is the text of level seven, a story long enough that coming back to a place
+// is quicker with a mark than with any other motion
+var harbourText = []string{
+	"The harbour master kept a small book of marks for every pier in town.",
+	"Each page named a letter and the place it stood for, nothing more.",
+	"Sailors who knew the book could find their way in the thickest fog.",
+	"",
+	"A mark is set with m and a letter, like ma or mb.",
+	"Nothing moves when you set it, the cursor simply stays where it is.",
+	"Later a backtick and the letter takes you to the very same column,",
+	"while a quote and the letter takes you to the start of that line.",
+	"",
+	"The old pier at the north end had the letter a painted on a post.",
+	"Fishing boats left from there at dawn and came back before noon.",
+	"The new pier at the south end was marked with a b on the crane.",
+	"Cargo ships tied up there and stayed for days while they unloaded.",
+	"",
+	"Big jumps like G, gg and the mark jumps are kept in a jump list.",
+	"ctrl+o walks back through that list to where you jumped from.",
+	"ctrl+i walks forward again, just like the arrows of a browser.",
+	"",
+	"In the middle of the harbour stood a tall stone lighthouse.",
+	"Its keeper climbed the stairs each night to light the lamp.",
+	"He wrote the weather in a log and the names of passing ships.",
+	"On stormy nights he tied a rope to the anchor by the door.",
+	"The anchor had been there longer than anyone could remember.",
+	"",
+	"Children liked to sit on the wall and count the gulls.",
+	"Some of them swore that one gull always came back to the same stone.",
+	"The keeper called it the harbour master, and it did not object.",
+	"",
+	"In winter the ice crept along the quay and the boats stayed home.",
+	"The market moved indoors and sold smoked fish and warm bread.",
+	"Nets were mended, hulls were painted and ropes were spliced.",
+	"",
+	"When spring came the first boat out was always the oldest one.",
+	"Its captain said it knew the way better than any chart.",
+	"Nobody argued, because it had never once run aground.",
+	"",
+	"Visitors sometimes asked how the town had got its name.",
+	"Each family told a different story and each one was sure.",
+	"The harbour master only smiled and turned another page.",
+	"",
+	"At the far end of the book was a page with no letter at all.",
+	"It said that a good mark is one you can always come back to.",
+}
+
+// NewLevelSeven returns a new instance of models.Level seven
+func NewLevelSeven() models.Level {
+	return &TextLevel{
+		number:      levelNumberSeven,
+		description: "Set marks and jump back to them",
+		lesson:      "m{a-z}, `{a-z}, '{a-z}, ctrl+o and ctrl+i",
+		content:     harbourText,
+		allowed:     slices.Concat(models.BasicMotions, models.WordMotions, models.LineMotions, models.MarkMotions),
+		spots: []models.Position{
+			{X: 39, Y: 21}, // anchor
+			{X: 0, Y: 41},  // maG
+			{X: 39, Y: 21}, // `a
+			{X: 34, Y: 29}, // smoked
+			{X: 0, Y: 0},   // gg
+			{X: 39, Y: 21}, // `a
+		},
+		par:   []int{0, 3, 2, 0, 2, 2},
+		chars: &models.DefaultCharacters,
+	}
+}
@@ -0,0 +1,60 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const levelNumberSix = 6
+
+// logText is the text of level six, a server log that is easiest to read by searching it
+var logText = []string{
+	"09:00:01 INFO  server started on port 8080",
+	"09:00:02 INFO  loading configuration from settings.yaml",
+	"09:00:02 INFO  connected to database primary",
+	"09:00:05 WARN  cache is cold, first requests will be slow",
+	"09:01:12 INFO  GET /home 200 in 35ms",
+	"09:01:13 INFO  GET /about 200 in 12ms",
+	"09:01:20 INFO  POST /login 200 in 80ms",
+	"09:02:41 WARN  slow query on table orders took 950ms",
+	"09:02:44 INFO  GET /orders 200 in 990ms",
+	"09:03:02 ERROR payment service did not answer within 5s",
+	"09:03:02 INFO  retrying payment in 2s",
+	"09:03:04 INFO  payment accepted on second attempt",
+	"09:04:10 INFO  GET /profile 200 in 18ms",
+	"09:05:33 WARN  disk usage above 80 percent",
+	"09:06:01 INFO  GET /orders 200 in 40ms",
+	"09:06:02 INFO  GET /orders/42 200 in 22ms",
+	"09:07:15 ERROR could not send email to customer 1337",
+	"09:07:15 INFO  email queued for another try",
+	"09:08:00 INFO  nightly report scheduled",
+	"09:09:45 WARN  certificate ends in 14 days",
+	"09:10:30 INFO  GET /home 200 in 30ms",
+	"09:11:11 ERROR database connection lost",
+	"09:11:12 INFO  reconnecting to database primary",
+	"09:11:14 INFO  connected to database primary",
+	"09:12:00 INFO  GET /status 200 in 3ms",
+	"09:13:37 INFO  server is healthy",
+}
+
+// NewLevelSix returns a new instance of models.Level six
+func NewLevelSix() models.Level {
+	return &TextLevel{
+		number:      levelNumberSix,
+		description: "Search through a log file",
+		lesson:      "/, ?, n and N",
+		content:     logText,
+		allowed:     slices.Concat(models.BasicMotions, models.LineMotions, models.SearchMotions),
+		spots: []models.Position{
+			{X: 9, Y: 9},   // /ERROR
+			{X: 9, Y: 16},  // n
+			{X: 9, Y: 21},  // n
+			{X: 9, Y: 19},  // ?WARN
+			{X: 48, Y: 16}, // ?1337
+			{X: 25, Y: 25}, // /healthy
+			{X: 42, Y: 1},  // /settings
+		},
+		chars: &models.DefaultCharacters,
+	}
+}
@@ -1,10 +1,6 @@
 package level
 
-import (
-	"slices"
-
-	"github.com/dasvh/go-learn-vim/internal/models"
-)
+import "github.com/dasvh/go-learn-vim/internal/models"
 
 // CornerTargets defines the behavior of a target that is placed in the corners of the screen
 type CornerTargets struct {
@@ -50,8 +46,9 @@ func (ct *CornerTargets) GetTargetCount() int {
 
 // MazeTargets integrates Maze for walls and manages targets
 type MazeTargets struct {
-	chars *models.Characters
-	maze  *Maze
+	chars  *models.Characters
+	maze   *Maze
+	target *models.Position // the target is placed once, so the par and the game agree on it
 }
 
 // NewMazeTargets creates a new MazeTargets instance
@@ -62,8 +59,11 @@ func NewMazeTargets(chars *models.Characters, maze *Maze) *MazeTargets {
 	}
 }
 
-// DefineTargets dynamically places a single target in an open cell
+// DefineTargets dynamically places a single target in an open cell, later calls return the same target
 func (mt *MazeTargets) DefineTargets() []models.Target {
+	if mt.target != nil {
+		return []models.Target{{Position: *mt.target, Reached: false}}
+	}
 	var openCells []models.Position
 
 	// get all open cells
@@ -87,6 +87,7 @@ func (mt *MazeTargets) DefineTargets() []models.Target {
 
 	// select a "random" open cell, not truly random since the maze is deterministic
 	targetPos := openCells[mt.maze.rand.Intn(len(openCells))]
+	mt.target = &targetPos
 	return []models.Target{
 		{Position: targetPos, Reached: false},
 	}
@@ -109,5 +110,5 @@ func (mt *MazeTargets) UpdateGrid(grid [][]rune, targets []models.Target, curren
 
 // Helper function to check if a position collides with walls
 func (mt *MazeTargets) isPositionCollidingWithWall(pos models.Position) bool {
-	return slices.Contains(mt.maze.GetWalls(), pos)
+	return mt.maze.isWallAt(pos)
 }
@@ -0,0 +1,47 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+const levelNumberTen = 10
+
+// visualText is the text of level ten, the text of every edit is selected before it is changed
+var visualText = []string{
+	"Visual mode shows what an operator is about to act on.",
+	"This sentence has too many words in it.",
+	"Yank the two lines below whole with V, j and y.",
+	"first line to yank",
+	"second line to yank",
+	"Delete the column of numbers below with a block.",
+	"  1 apples",
+	"  2 pears",
+	"  3 plums",
+	"A selection can run over lines, delete from here",
+	"up to here and keep the rest.",
+}
+
+// NewLevelTen returns a new instance of models.Level ten,
+// the spans of the edits are positions in the text as it is after the edits before them
+func NewLevelTen() models.Level {
+	return &TextLevel{
+		number:      levelNumberTen,
+		description: "Select text in visual mode before acting on it",
+		lesson:      "v, V and ctrl+v followed by an operator",
+		content:     visualText,
+		allowed:     slices.Concat(models.BasicMotions, models.WordMotions, models.LineMotions, models.FindMotions),
+		operators:   models.Operators,
+		visualModes: models.VisualModes,
+		edits: []edit{
+			{models.OperatorDelete, vim.Span{Start: models.Position{X: 18, Y: 1}, End: models.Position{X: 27, Y: 1}}},            // j3wvtwd
+			{models.OperatorYank, vim.Span{Start: models.Position{Y: 3}, End: models.Position{Y: 4}, Linewise: true}},            // 2jVjy
+			{models.OperatorDelete, vim.Span{Start: models.Position{X: 2, Y: 6}, End: models.Position{X: 4, Y: 8}, Block: true}}, // 3j^ctrl+v2jld
+			{models.OperatorDelete, vim.Span{Start: models.Position{X: 39, Y: 9}, End: models.Position{X: 6, Y: 10}}},            // 3j$2bvj0thd
+		},
+		par:   []int{7, 5, 8, 11},
+		chars: &models.DefaultCharacters,
+	}
+}
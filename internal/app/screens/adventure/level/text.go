@@ -0,0 +1,506 @@
+package level
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/solver"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// offsets of the line numbers and text inside the level grid
+const (
+	textOffsetX = 2
+	textOffsetY = 1
+)
+
+// TextLevel represents a level that is played on a buffer of text,
+// the player has to reach every target in order using the motions the level teaches.
+// A level with edits instead of spots is played with operators, every edit is a target
+// that is reached by deleting, changing or yanking its text. A level with a goal has a single
+// target that is reached once the text, which is typed into in insert mode, matches the goal.
+// In a level with visual modes the text of every edit has to be selected exactly before the operator acts on it.
+// Every change to the text is kept in an undo tree, in a level with history commands the changes can be undone,
+// redone and repeated but never further back than where the attempt at the current target started.
+// In a level with macros the keys a macro replays are not spent again, only the keys that replay it.
+// When a level defines a par for a target, the target has to be reached within that
+// many keystrokes or the player is sent back to retry it. A level can explain for every target
+// how the motion it teaches gets there, the explanation is added to the instructions.
+// A level with walls has a character in its text that the cursor cannot move onto.
+// A level whose text could not be loaded returns the error when it is initialized or restored
+type TextLevel struct {
+	number        int
+	description   string
+	lesson        string
+	content       []string
+	loadErr       error
+	wall          rune
+	start         models.Position
+	allowed       []models.MotionKind
+	operators     []models.Operator
+	inserts       []models.InsertKind
+	visualModes   []models.VisualMode
+	historyKinds  []models.HistoryKind
+	macroKinds    []models.MacroKind
+	spots         []models.Position
+	edits         []edit
+	goal          []string
+	par           []int
+	hints         []string
+	keys          keystrokes
+	attemptStart  models.Position
+	attemptLines  []string
+	history       undoHistory
+	register      vim.Register
+	inserting     bool
+	selection     selection
+	last          lastMotions
+	marks         marks
+	width         int
+	height        int
+	currentTarget int
+	completed     bool
+	inProgress    bool
+	grid          [][]rune
+	buffer        *vim.Buffer
+	window        *vim.Window
+	chars         *models.Characters
+	player        models.Position
+	targets       []models.Target
+}
+
+// Number returns the number of the level
+func (tl *TextLevel) Number() int {
+	return tl.number
+}
+
+// Description returns the description of the level
+func (tl *TextLevel) Description() string {
+	return tl.description
+}
+
+// AllowedMotions returns the motions that can be used in the level
+func (tl *TextLevel) AllowedMotions() []models.MotionKind {
+	return tl.allowed
+}
+
+// Init initializes the level with the given dimensions
+func (tl *TextLevel) Init(width, height int) error {
+	if tl.loadErr != nil {
+		return tl.loadErr
+	}
+	tl.completed = false
+	tl.inProgress = true
+	tl.setDimensions(width, height)
+	tl.resetTargets()
+	tl.last = lastMotions{}
+	tl.marks.reset()
+	tl.register = vim.Register{}
+	tl.inserting = false
+	tl.selection.clear()
+	tl.history.reset(tl.buffer.Lines())
+	tl.keys.replaying = false
+	tl.PlacePlayer(tl.GetStartPosition())
+	tl.startAttempt()
+	return nil
+}
+
+// PlayerMove handles models.PlayerMovement and models.Target interaction
+func (tl *TextLevel) PlayerMove(motion models.Motion) models.PlayerMovement {
+	if !slices.Contains(tl.allowed, motion.Kind) {
+		return tl.invalidMove(fmt.Sprintf("%s is not used in this level.", motion))
+	}
+	if motion.Kind == models.MotionSetMark && vim.IsMarkName(motion.Char) {
+		return tl.setMark(motion)
+	}
+
+	motion, message := tl.prepare(motion)
+	if message != "" {
+		return tl.invalidMove(message)
+	}
+	previous := tl.player
+	newPos, moved := tl.resolve(motion)
+	if !moved {
+		return tl.invalidMove(tl.notMovedMessage(motion))
+	}
+	if motion.Kind.IsJump() {
+		tl.marks.jumps.Push(previous)
+	}
+	tl.player = newPos
+	tl.spend(motion.Keystrokes())
+
+	// check if player has reached the target, only spots are reached by moving onto them
+	if tl.spots != nil && tl.targets[tl.currentTarget].Position == newPos {
+		return tl.reach()
+	}
+	if par := tl.currentPar(); par > 0 && tl.keys.spent >= par {
+		// the target can no longer be reached within par
+		return tl.retry(tl.overParMessage())
+	}
+
+	tl.drawGrid()
+	return models.PlayerMovement{
+		UpdatedPosition:    newPos,
+		Completed:          false,
+		ValidMove:          true,
+		InstructionMessage: tl.GetInstructions(),
+	}
+}
+
+// Text returns the lines of the text as the player changed them,
+// it is nil for a level in which the text cannot be changed
+func (tl *TextLevel) Text() []string {
+	if len(tl.operators) == 0 && len(tl.inserts) == 0 {
+		return nil
+	}
+	return tl.buffer.Lines()
+}
+
+// reach marks the current target as reached and moves on to the next one
+func (tl *TextLevel) reach() models.PlayerMovement {
+	tl.targets[tl.currentTarget].Reached = true
+	if tl.currentTarget == len(tl.targets)-1 {
+		tl.completed = true
+		tl.inProgress = false
+		return models.PlayerMovement{
+			UpdatedPosition:    tl.player,
+			Completed:          true,
+			ValidMove:          true,
+			InstructionMessage: "Level completed!",
+		}
+	}
+	tl.currentTarget++
+	tl.startAttempt()
+	tl.drawGrid()
+	return models.PlayerMovement{
+		UpdatedPosition:    tl.player,
+		Completed:          false,
+		ValidMove:          true,
+		InstructionMessage: tl.GetInstructions(),
+	}
+}
+
+// retry sends the player back to where the attempt at the current target started,
+// the text is restored to how it was at that moment
+func (tl *TextLevel) retry(message string) models.PlayerMovement {
+	tl.selection.clear()
+	tl.inserting = false
+	tl.loadText(tl.history.revert())
+	tl.PlacePlayer(tl.attemptStart)
+	tl.keys.spent = 0
+	return models.PlayerMovement{
+		UpdatedPosition:    tl.player,
+		Completed:          false,
+		ValidMove:          true,
+		InstructionMessage: message,
+	}
+}
+
+// overParMessage tells the player that the current target was not reached within par
+func (tl *TextLevel) overParMessage() string {
+	return fmt.Sprintf("Over par! Target %d can be reached in %d keystrokes, try again", tl.currentTarget+1, tl.currentPar())
+}
+
+// invalidMove returns the models.PlayerMovement of a motion that could not be applied,
+// the message is followed by the instructions
+func (tl *TextLevel) invalidMove(message string) models.PlayerMovement {
+	if message != "" {
+		message += " "
+	}
+	return models.PlayerMovement{
+		UpdatedPosition:    tl.player,
+		Completed:          tl.completed,
+		ValidMove:          false,
+		InstructionMessage: message + tl.GetInstructions(),
+	}
+}
+
+// PlacePlayer places the player at the given models.Position in the text
+func (tl *TextLevel) PlacePlayer(position models.Position) {
+	tl.player = tl.buffer.Clamp(position)
+	tl.window.Follow(tl.buffer, tl.player.Y)
+	tl.drawGrid()
+}
+
+// Render provides the visual representation of the level, the whole text is rendered
+// and Viewport tells which rows of it are visible
+func (tl *TextLevel) Render() [][]rune {
+	return tl.grid
+}
+
+// Viewport returns the rows of the rendered level that are visible in the window
+func (tl *TextLevel) Viewport() models.Viewport {
+	return models.Viewport{Top: tl.window.Top, Height: tl.height}
+}
+
+// GetStartPosition returns the starting player models.Position
+func (tl *TextLevel) GetStartPosition() models.Position {
+	return tl.start
+}
+
+// GetCurrentPosition returns the current player models.Position
+func (tl *TextLevel) GetCurrentPosition() models.Position {
+	return tl.player
+}
+
+// GetTargets returns the models.Target's for the level
+func (tl *TextLevel) GetTargets() []models.Target {
+	return tl.targets
+}
+
+// GetCurrentTarget returns the currentTarget
+func (tl *TextLevel) GetCurrentTarget() int {
+	return tl.currentTarget
+}
+
+// GetInstructions returns the instructions for the level
+func (tl *TextLevel) GetInstructions() string {
+	goal := "Reach the X"
+	switch {
+	case tl.edits != nil:
+		goal = tl.editGoal()
+	case tl.goal != nil:
+		goal = tl.goalDifference()
+	}
+	instructions := fmt.Sprintf("Instructions: Target %d/%d: %s using %s", tl.currentTarget+1, tl.targetCount(), goal, tl.lesson)
+	if par := tl.currentPar(); par > 0 {
+		instructions = fmt.Sprintf("Instructions: Target %d/%d: %s within %d keystrokes using %s",
+			tl.currentTarget+1, tl.targetCount(), goal, par, tl.lesson)
+	}
+	if hint := tl.currentHint(); hint != "" {
+		instructions += ". " + hint
+	}
+	return instructions
+}
+
+// Par returns the fewest keystrokes it takes to reach every target, a target with a par defined by the level
+// counts with it. The spots without one are solved from the spot before them, the other targets need a defined par
+func (tl *TextLevel) Par() (int, bool) {
+	total := 0
+	from := tl.start
+	for i := range tl.targetCount() {
+		if i < len(tl.par) && tl.par[i] > 0 {
+			total += tl.par[i]
+		} else if i < len(tl.spots) {
+			solution, ok := solver.Solve(tl.buffer, from, tl.spots[i], tl.allowed)
+			if !ok {
+				return 0, false
+			}
+			total += solution.Keystrokes
+		} else {
+			return 0, false
+		}
+		if i < len(tl.spots) {
+			from = tl.spots[i]
+		}
+	}
+	return total, total > 0
+}
+
+// Hint returns the first motion of the fewest keystrokes onto the current target,
+// only spots are reached by moving so a level with edits or a goal has no hint
+func (tl *TextLevel) Hint() (string, bool) {
+	if tl.completed || tl.currentTarget >= len(tl.spots) {
+		return "", false
+	}
+	return nextMotion(tl.buffer, tl.player, tl.spots[tl.currentTarget], tl.allowed)
+}
+
+// InProgress returns whether the level is in progress
+func (tl *TextLevel) InProgress() bool {
+	return tl.inProgress
+}
+
+// IsCompleted returns whether the level is completed
+func (tl *TextLevel) IsCompleted() bool {
+	return tl.completed
+}
+
+// Restore a models.SavedLevel from a game state
+func (tl *TextLevel) Restore(state models.SavedLevel) error {
+	if tl.loadErr != nil {
+		return tl.loadErr
+	}
+	if state.Width <= 0 || state.Height <= 0 {
+		return fmt.Errorf("invalid dimensions in save state")
+	}
+	if state.CurrentTarget < 0 || state.CurrentTarget >= tl.targetCount() {
+		return fmt.Errorf("invalid target %d in save state", state.CurrentTarget)
+	}
+
+	tl.setDimensions(state.Width, state.Height)
+	tl.resetTargets()
+	for i := range tl.targets {
+		if i < len(state.Targets) {
+			tl.targets[i].Reached = state.Targets[i].Reached
+		}
+	}
+	tl.currentTarget = state.CurrentTarget
+	// saves without the changed text get it back by making the edits that were already made
+	if state.Lines != nil {
+		tl.loadText(state.Lines)
+	} else {
+		tl.replayEdits(state.CurrentTarget, state.Completed)
+	}
+	tl.inserting = false
+	tl.selection.clear()
+	tl.keys.replaying = false
+	tl.history.restore(state.Changes, state.CurrentChange, tl.buffer.Lines(), tl.GetStartPosition())
+	tl.marks.restore(state.Marks, tl.buffer)
+	tl.completed = state.Completed
+	tl.inProgress = state.InProgress
+
+	// fallback to the starting position if the saved one is not part of the text or is a wall
+	position := state.PlayerPosition
+	if !tl.buffer.Contains(position) || tl.buffer.IsWall(position) {
+		position = tl.GetStartPosition()
+	}
+	tl.PlacePlayer(position)
+	tl.startAttempt()
+	if tl.goal != nil {
+		// the attempt at a goal started with the text of the level, so a loaded save can undo all the way
+		tl.history.attempt = 0
+	}
+	return nil
+}
+
+// Exit exits the level
+func (tl *TextLevel) Exit() {
+	tl.inProgress = false
+}
+
+// setDimensions sets the level dimensions and loads the text into the buffer,
+// the grid holds the whole text even when it does not fit in the visible height
+func (tl *TextLevel) setDimensions(width, height int) {
+	tl.width = width
+	tl.height = height
+	tl.loadText(tl.content)
+	tl.window = vim.NewWindow(height - 2*textOffsetY)
+	tl.grid = nil
+	tl.fitGrid()
+}
+
+// loadText loads the lines into the buffer, the wall character of the level stops the cursor
+func (tl *TextLevel) loadText(lines []string) {
+	buf := vim.NewBuffer(lines)
+	if tl.wall != 0 {
+		buf.SetWalls(func(pos models.Position) bool {
+			return pos.Y >= 0 && pos.Y < buf.LineCount() && pos.X >= 0 && pos.X < len(buf.Line(pos.Y)) &&
+				buf.Line(pos.Y)[pos.X] == tl.wall
+		})
+	}
+	tl.buffer = buf
+}
+
+// fitGrid grows the grid when the text has more lines than it holds
+func (tl *TextLevel) fitGrid() {
+	for len(tl.grid) < max(tl.height, tl.buffer.LineCount()+2*textOffsetY) {
+		tl.grid = append(tl.grid, make([]rune, tl.width))
+	}
+}
+
+// resetTargets places the targets on their spots in the text, the target of an edit is
+// placed at the start of its span and the target of a goal at the start of the text
+func (tl *TextLevel) resetTargets() {
+	tl.currentTarget = 0
+	tl.targets = make([]models.Target, 0, tl.targetCount())
+	for _, spot := range tl.spots {
+		tl.targets = append(tl.targets, models.Target{Position: spot, Reached: false})
+	}
+	for _, e := range tl.edits {
+		tl.targets = append(tl.targets, models.Target{Position: e.span.Start, Reached: false})
+	}
+	if tl.goal != nil {
+		tl.targets = append(tl.targets, models.Target{Position: tl.GetStartPosition(), Reached: false})
+	}
+}
+
+// targetCount returns the number of targets, which are the spots, the edits or the goal of the level
+func (tl *TextLevel) targetCount() int {
+	count := len(tl.spots) + len(tl.edits)
+	if tl.goal != nil {
+		count++
+	}
+	return count
+}
+
+// startAttempt starts counting the keystrokes spent on the current target
+// and remembers the text to restore it when the attempt fails
+func (tl *TextLevel) startAttempt() {
+	tl.keys.spent = 0
+	tl.attemptStart = tl.player
+	tl.attemptLines = tl.buffer.Lines()
+	tl.history.startAttempt()
+}
+
+// currentPar returns the par of the current target, zero if the target has no par
+func (tl *TextLevel) currentPar() int {
+	if tl.currentTarget < len(tl.par) {
+		return tl.par[tl.currentTarget]
+	}
+	return 0
+}
+
+// currentHint returns the explanation of how to reach the current target, empty if the target has none
+func (tl *TextLevel) currentHint() string {
+	if tl.currentTarget < len(tl.hints) {
+		return tl.hints[tl.currentTarget]
+	}
+	return ""
+}
+
+// gutterWidth returns the width of the line numbers in front of the text
+func (tl *TextLevel) gutterWidth() int {
+	return len(strconv.Itoa(tl.buffer.LineCount())) + 1
+}
+
+// drawGrid draws the line numbers, the text, the active target and the player onto the grid
+func (tl *TextLevel) drawGrid() {
+	tl.fitGrid()
+	for y := range tl.grid {
+		for x := range tl.grid[y] {
+			tl.grid[y][x] = ' '
+		}
+	}
+
+	gutter := tl.gutterWidth()
+	for y := range tl.buffer.LineCount() {
+		number := fmt.Sprintf("%*d", gutter-1, y+1)
+		for x, r := range number {
+			tl.setCell(models.Position{X: x - gutter, Y: y}, r)
+		}
+		for x, r := range tl.buffer.Line(y) {
+			if r == tl.wall {
+				r = tl.chars.Wall.Rune
+			}
+			tl.setCell(models.Position{X: x, Y: y}, r)
+		}
+	}
+
+	// the text of an edit or a goal is named in the instructions instead of being covered by a target
+	if !tl.completed && tl.spots != nil {
+		tl.setCell(tl.targets[tl.currentTarget].Position, tl.chars.Target.Active.Rune)
+	}
+	tl.setCell(tl.player, tl.chars.Player.Cursor.Rune)
+}
+
+// setCell sets the rune of a text position on the grid if it is visible
+func (tl *TextLevel) setCell(pos models.Position, r rune) {
+	if x, y, ok := tl.cell(pos); ok {
+		tl.grid[y][x] = r
+	}
+}
+
+// Cell returns the cell of the grid a text position is drawn in and whether it is on the grid
+func (tl *TextLevel) Cell(pos models.Position) (models.Position, bool) {
+	x, y, ok := tl.cell(pos)
+	return models.Position{X: x, Y: y}, ok
+}
+
+// cell returns the cell of the grid a text position is drawn in and whether it is on the grid
+func (tl *TextLevel) cell(pos models.Position) (int, int, bool) {
+	x, y := pos.X+textOffsetX+tl.gutterWidth(), pos.Y+textOffsetY
+	return x, y, y >= 0 && y < len(tl.grid) && x >= 0 && x < tl.width
+}
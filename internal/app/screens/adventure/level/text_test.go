@@ -0,0 +1,684 @@
+package level
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// keys splits typed keys into single key strings
+func keys(typed string) []string {
+	var split []string
+	for _, r := range typed {
+		split = append(split, string(r))
+	}
+	return split
+}
+
+// move returns a models.Motion typed with the given keys
+func move(kind models.MotionKind, count int, typed string) models.Motion {
+	return models.Motion{Kind: kind, Count: count, Keys: keys(typed)}
+}
+
+// operate returns a models.Operation on a models.Motion typed with the given keys
+func operate(operator models.Operator, motion models.Motion) models.Operation {
+	return models.Operation{Operator: operator, Count: motion.Count, Motion: motion, Keys: motion.Keys}
+}
+
+func Test_EditLevel(t *testing.T) {
+	// each step is either a motion or an operation, the solution of level eight within par
+	type step struct {
+		motion    *models.Motion
+		operation *models.Operation
+	}
+	m := func(motion models.Motion) step { return step{motion: &motion} }
+	o := func(operation models.Operation) step { return step{operation: &operation} }
+
+	findComma := move(models.MotionFindForward, 0, "f,")
+	findComma.Char = ','
+	tillColon := move(models.MotionTillForward, 0, "dt:")
+	tillColon.Char = ':'
+	solution := []step{
+		m(move(models.MotionDown, 0, "j")),
+		m(move(models.MotionWordForward, 2, "2w")),
+		o(operate(models.OperatorDelete, move(models.MotionWordForward, 0, "dw"))),
+		m(move(models.MotionDown, 2, "2j")),
+		o(models.Operation{Operator: models.OperatorDelete, Linewise: true, Keys: keys("dd")}),
+		m(findComma),
+		o(operate(models.OperatorDelete, move(models.MotionEndOfLine, 0, "d$"))),
+		m(move(models.MotionDown, 0, "j")),
+		m(move(models.MotionWordBackward, 0, "b")),
+		o(operate(models.OperatorChange, move(models.MotionWordForward, 0, "cw"))),
+		m(move(models.MotionDown, 0, "j")),
+		m(move(models.MotionWordBackward, 6, "6b")),
+		o(models.Operation{Operator: models.OperatorYank, Object: models.TextObjectInnerWord, Keys: keys("yiw")}),
+		m(move(models.MotionDown, 0, "j")),
+		m(move(models.MotionStartOfLine, 0, "0")),
+		o(operate(models.OperatorDelete, tillColon)),
+		m(move(models.MotionDown, 2, "2j")),
+		m(move(models.MotionWORDForward, 5, "5W")),
+		o(operate(models.OperatorDelete, move(models.MotionWordForward, 2, "d2w"))),
+		m(move(models.MotionDown, 0, "j")),
+		m(move(models.MotionWordBackward, 3, "3b")),
+		o(models.Operation{Operator: models.OperatorDelete, Object: models.TextObjectAWord, Keys: keys("daw")}),
+	}
+
+	level := NewLevelEight().(*TextLevel)
+	level.Init(80, 20)
+	var result models.PlayerMovement
+	for i, s := range solution {
+		if s.motion != nil {
+			result = level.PlayerMove(*s.motion)
+		} else {
+			result = level.ApplyOperation(*s.operation)
+		}
+		if !result.ValidMove {
+			t.Fatalf("step %d: expected a valid move, got %q", i, result.InstructionMessage)
+		}
+	}
+	if !result.Completed {
+		t.Fatalf("expected the level to be completed, at target %d: %q", level.GetCurrentTarget()+1, result.InstructionMessage)
+	}
+
+	want := []string{
+		"Operators act on the words that a motion moves over.",
+		"Delete the unwanted word on this line with dw.",
+		"A doubled operator works on whole lines, like dd.",
+		"Cut the end of this line from the comma on",
+		"The c operator changes a word, cw on  keeps the space after it.",
+		"Yank the word yellow with yiw, the line stays as it is.",
+		": the rest stays.",
+		"",
+		"Counts work too, d2w takes words at once.",
+		"Use daw on the word to take its space along.",
+	}
+	if !slices.Equal(level.buffer.Lines(), want) {
+		t.Errorf("expected text %q, got %q", want, level.buffer.Lines())
+	}
+}
+
+func Test_EditLevelRetry(t *testing.T) {
+	level := NewLevelEight().(*TextLevel)
+	level.Init(80, 20)
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+
+	// deleting the wrong word restores the text and the cursor
+	result := level.ApplyOperation(operate(models.OperatorDelete, move(models.MotionWordForward, 0, "dw")))
+	if !result.ValidMove || result.Completed {
+		t.Fatalf("expected a valid move that does not complete the level, got %+v", result)
+	}
+	if !slices.Equal(level.buffer.Lines(), editText) {
+		t.Errorf("expected the text to be restored, got %q", level.buffer.Lines())
+	}
+	if level.GetCurrentPosition() != (models.Position{}) || level.GetCurrentTarget() != 0 {
+		t.Errorf("expected to retry target 1 from the start, got target %d at %v", level.GetCurrentTarget()+1, level.GetCurrentPosition())
+	}
+
+	// operators are not used in the levels before
+	three := NewLevelThree().(*TextLevel)
+	three.Init(80, 20)
+	if result := three.ApplyOperation(operate(models.OperatorDelete, move(models.MotionWordForward, 0, "dw"))); result.ValidMove {
+		t.Errorf("expected d to be rejected in level three")
+	}
+}
+
+func Test_EditLevelRestore(t *testing.T) {
+	level := NewLevelEight().(*TextLevel)
+	level.Init(80, 20)
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	level.PlayerMove(move(models.MotionWordForward, 2, "2w"))
+	level.ApplyOperation(operate(models.OperatorDelete, move(models.MotionWordForward, 0, "dw")))
+
+	restored := NewLevelEight().(*TextLevel)
+	err := restored.Restore(models.SavedLevel{
+		Width:          80,
+		Height:         20,
+		PlayerPosition: level.GetCurrentPosition(),
+		Targets:        level.GetTargets(),
+		CurrentTarget:  level.GetCurrentTarget(),
+		InProgress:     true,
+	})
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if !slices.Equal(restored.buffer.Lines(), level.buffer.Lines()) {
+		t.Errorf("expected the edits to be replayed, got %q", restored.buffer.Lines())
+	}
+}
+
+func Test_InsertLevel(t *testing.T) {
+	level := NewLevelNine().(*TextLevel)
+	level.Init(80, 20)
+
+	// insert starts insert mode, typed types its keys and esc leaves insert mode again
+	insert := func(kind models.InsertKind, key string) {
+		if result := level.StartInsert(models.Insert{Kind: kind, Keys: keys(key)}); !result.ValidMove {
+			t.Fatalf("expected %s to start insert mode, got %q", key, result.InstructionMessage)
+		}
+	}
+	var result models.PlayerMovement
+	typed := func(text string) {
+		for _, k := range keys(text) {
+			if result = level.TypeKey(k); !result.ValidMove {
+				t.Fatalf("expected %q to be typed, got %q", k, result.InstructionMessage)
+			}
+		}
+		if !result.Completed {
+			level.StopInsert()
+		}
+	}
+
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	level.PlayerMove(move(models.MotionWordForward, 2, "2w"))
+	insert(models.InsertBefore, "i")
+	typed("brown ")
+	level.PlayerMove(move(models.MotionWordForward, 5, "5w"))
+	insert(models.InsertBefore, "i")
+	typed("warm ")
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	insert(models.InsertLineEnd, "A")
+	typed(".")
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	insert(models.InsertLineStart, "I")
+	typed("A ")
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	insert(models.InsertOpenBelow, "o")
+	typed("Here it is, opened with o.")
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	insert(models.InsertOpenAbove, "O")
+	if level.GetInstructions() == "" || !level.Inserting() {
+		t.Fatalf("expected to be inserting")
+	}
+	typed("And this one with O.")
+
+	if !result.Completed {
+		t.Fatalf("expected the level to be completed, got %q with text %q", result.InstructionMessage, level.buffer.Lines())
+	}
+	if level.Inserting() {
+		t.Errorf("expected insert mode to end with the level")
+	}
+}
+
+func Test_InsertLevelTypeKey(t *testing.T) {
+	level := NewLevelNine().(*TextLevel)
+	level.Init(80, 20)
+
+	if result := level.TypeKey("a"); result.ValidMove {
+		t.Errorf("expected keys to be rejected outside insert mode")
+	}
+	level.StartInsert(models.Insert{Kind: models.InsertBefore, Keys: keys("i")})
+	if result := level.TypeKey("backspace"); result.ValidMove {
+		t.Errorf("expected backspace at the start of the text to be rejected")
+	}
+	if result := level.TypeKey("ctrl+x"); result.ValidMove {
+		t.Errorf("expected ctrl+x not to be typed")
+	}
+	level.TypeKey("a")
+	level.TypeKey("enter")
+	if got := level.buffer.Lines()[:2]; !slices.Equal(got, []string{"a", insertText[0]}) {
+		t.Errorf("expected the line to be broken after the typed a, got %q", got)
+	}
+	level.TypeKey("backspace")
+	level.StopInsert()
+	if level.buffer.Lines()[0] != "a"+insertText[0] || level.GetCurrentPosition() != (models.Position{}) {
+		t.Errorf("expected the lines to be joined with the cursor on the a, got %q at %v", level.buffer.Lines()[0], level.GetCurrentPosition())
+	}
+
+	// a change continues in insert mode in a level with inserts
+	level.ApplyOperation(operate(models.OperatorChange, move(models.MotionWordForward, 0, "cw")))
+	if !level.Inserting() {
+		t.Errorf("expected cw to start insert mode")
+	}
+}
+
+func Test_VisualLevel(t *testing.T) {
+	// each step is a motion, a visual command or an operation on the selection,
+	// the solution of level ten within par
+	type step struct {
+		motion    *models.Motion
+		visual    *models.Visual
+		operation *models.Operation
+	}
+	m := func(motion models.Motion) step { return step{motion: &motion} }
+	v := func(mode models.VisualMode, typed string) step {
+		return step{visual: &models.Visual{Mode: mode, Keys: []string{typed}}}
+	}
+	o := func(operator models.Operator) step {
+		return step{operation: &models.Operation{Operator: operator, Visual: true, Keys: []string{operator.String()}}}
+	}
+
+	tillW := move(models.MotionTillForward, 0, "tw")
+	tillW.Char = 'w'
+	tillH := move(models.MotionTillForward, 0, "th")
+	tillH.Char = 'h'
+	solution := []step{
+		m(move(models.MotionDown, 0, "j")),
+		m(move(models.MotionWordForward, 3, "3w")),
+		v(models.VisualChar, "v"),
+		m(tillW),
+		o(models.OperatorDelete),
+		m(move(models.MotionDown, 2, "2j")),
+		v(models.VisualLine, "V"),
+		m(move(models.MotionDown, 0, "j")),
+		o(models.OperatorYank),
+		m(move(models.MotionDown, 3, "3j")),
+		m(move(models.MotionFirstNonBlank, 0, "^")),
+		v(models.VisualBlock, "ctrl+v"),
+		m(move(models.MotionDown, 2, "2j")),
+		m(move(models.MotionRight, 0, "l")),
+		o(models.OperatorDelete),
+		m(move(models.MotionDown, 3, "3j")),
+		m(move(models.MotionEndOfLine, 0, "$")),
+		m(move(models.MotionWordBackward, 2, "2b")),
+		v(models.VisualChar, "v"),
+		m(move(models.MotionDown, 0, "j")),
+		m(move(models.MotionStartOfLine, 0, "0")),
+		m(tillH),
+		o(models.OperatorDelete),
+	}
+
+	level := NewLevelTen().(*TextLevel)
+	level.Init(80, 20)
+	var result models.PlayerMovement
+	for i, s := range solution {
+		switch {
+		case s.motion != nil:
+			result = level.PlayerMove(*s.motion)
+		case s.visual != nil:
+			result = level.StartVisual(*s.visual)
+		default:
+			result = level.ApplyOperation(*s.operation)
+		}
+		if !result.ValidMove {
+			t.Fatalf("step %d: expected a valid move, got %q", i, result.InstructionMessage)
+		}
+	}
+	if !result.Completed {
+		t.Fatalf("expected the level to be completed, at target %d: %q", level.GetCurrentTarget()+1, result.InstructionMessage)
+	}
+
+	want := []string{
+		"Visual mode shows what an operator is about to act on.",
+		"This sentence has words in it.",
+		"Yank the two lines below whole with V, j and y.",
+		"first line to yank",
+		"second line to yank",
+		"Delete the column of numbers below with a block.",
+		"  apples",
+		"  pears",
+		"  plums",
+		"A selection can run over lines, delete here and keep the rest.",
+	}
+	if !slices.Equal(level.buffer.Lines(), want) {
+		t.Errorf("expected text %q, got %q", want, level.buffer.Lines())
+	}
+}
+
+func Test_VisualLevelSelection(t *testing.T) {
+	level := NewLevelTen().(*TextLevel)
+	level.Init(80, 20)
+
+	// operators only act on a selection
+	if result := level.ApplyOperation(operate(models.OperatorDelete, move(models.MotionWordForward, 0, "dw"))); result.ValidMove {
+		t.Errorf("expected dw to be rejected outside visual mode")
+	}
+
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	level.PlayerMove(move(models.MotionWordForward, 3, "3w"))
+	level.StartVisual(models.Visual{Mode: models.VisualChar, Keys: keys("v")})
+	level.PlayerMove(move(models.MotionWordEnd, 0, "e"))
+	if level.VisualMode() != models.VisualChar {
+		t.Fatalf("expected to be in visual mode, got %d", level.VisualMode())
+	}
+
+	// the selection is highlighted over the region of the edit
+	overlays := level.Overlays()
+	x, y, _ := level.cell(models.Position{X: 19, Y: 1})
+	if overlays[y][x] != models.OverlaySelection {
+		t.Errorf("expected the selected cell to be highlighted as selection, got %d", overlays[y][x])
+	}
+	x, y, _ = level.cell(models.Position{X: 24, Y: 1})
+	if overlays[y][x] != models.OverlayRegion {
+		t.Errorf("expected the rest of the edit to be highlighted as region, got %d", overlays[y][x])
+	}
+
+	// a selection that does not match the edit is not acted on
+	result := level.ApplyOperation(models.Operation{Operator: models.OperatorDelete, Visual: true, Keys: keys("d")})
+	if !result.ValidMove || level.GetCurrentTarget() != 0 || level.VisualMode() != models.VisualNone {
+		t.Errorf("expected to retry target 1 outside visual mode, got target %d in mode %d", level.GetCurrentTarget()+1, level.VisualMode())
+	}
+	if !slices.Equal(level.buffer.Lines(), visualText) {
+		t.Errorf("expected the text to be restored, got %q", level.buffer.Lines())
+	}
+
+	// typing the key of the active mode again leaves visual mode
+	level.StartVisual(models.Visual{Mode: models.VisualLine, Keys: keys("V")})
+	level.StartVisual(models.Visual{Mode: models.VisualLine, Keys: keys("V")})
+	if level.VisualMode() != models.VisualNone {
+		t.Errorf("expected V to leave visual line mode")
+	}
+}
+
+func Test_HistoryLevel(t *testing.T) {
+	level := NewLevelEleven().(*TextLevel)
+	level.Init(80, 20)
+
+	history := func(kind models.HistoryKind, typed string) models.PlayerMovement {
+		return level.ApplyHistory(models.History{Kind: kind, Keys: keys(typed)})
+	}
+	if result := history(models.HistoryRepeat, "."); result.ValidMove {
+		t.Errorf("expected . to be rejected before any change")
+	}
+
+	search := move(models.MotionSearchForward, 0, "/")
+	search.Pattern = "very"
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	level.PlayerMove(search)
+	level.ApplyOperation(operate(models.OperatorDelete, move(models.MotionWordForward, 0, "dw")))
+
+	// the change can be undone and redone
+	history(models.HistoryUndo, "u")
+	if !slices.Equal(level.buffer.Lines(), repeatText) || level.GetCurrentPosition() != (models.Position{X: 4, Y: 1}) {
+		t.Fatalf("expected u to restore the text with the cursor where the change was made, got %q at %v", level.buffer.Lines(), level.GetCurrentPosition())
+	}
+	if result := history(models.HistoryUndo, "u"); result.ValidMove {
+		t.Errorf("expected u to be rejected at the oldest change")
+	}
+	history(models.HistoryRedo, "ctrl+r")
+	if got := level.buffer.Line(1); string(got) != "The old, very slow and very tired dog sleeps." {
+		t.Fatalf("expected ctrl+r to redo the change, got %q", string(got))
+	}
+
+	level.PlayerMove(move(models.MotionSearchNext, 0, "n"))
+	history(models.HistoryRepeat, ".")
+	level.PlayerMove(move(models.MotionSearchNext, 0, "n"))
+	history(models.HistoryRepeat, ".")
+	level.PlayerMove(move(models.MotionDown, 2, "2j"))
+	level.StartInsert(models.Insert{Kind: models.InsertLineEnd, Keys: keys("A")})
+	level.TypeKey(";")
+	level.StopInsert()
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	history(models.HistoryRepeat, ".")
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	result := history(models.HistoryRepeat, ".")
+
+	if !result.Completed {
+		t.Fatalf("expected the level to be completed, got %q with text %q", result.InstructionMessage, level.buffer.Lines())
+	}
+}
+
+func Test_HistoryLevelRepeatCount(t *testing.T) {
+	level := NewLevelEleven().(*TextLevel)
+	level.Init(80, 20)
+
+	level.ApplyOperation(operate(models.OperatorDelete, move(models.MotionWordForward, 0, "dw")))
+	level.ApplyHistory(models.History{Kind: models.HistoryRepeat, Count: 2, Keys: keys("2.")})
+	if got := string(level.buffer.Line(0)); got != "the last change, u undoes it and ctrl+r redoes it." {
+		t.Errorf("expected 2. to delete two words, got %q", got)
+	}
+
+	// an insert is repeated with the text typed after it as a single change
+	level.StartInsert(models.Insert{Kind: models.InsertBefore, Keys: keys("i")})
+	level.TypeKey("x")
+	level.TypeKey("y")
+	level.StopInsert()
+	level.ApplyHistory(models.History{Kind: models.HistoryRepeat, Keys: keys(".")})
+	if got := string(level.buffer.Line(0)); got != "xxyythe last change, u undoes it and ctrl+r redoes it." {
+		t.Errorf("expected . to type xy again, got %q", got)
+	}
+	level.ApplyHistory(models.History{Kind: models.HistoryUndo, Keys: keys("u")})
+	if got := string(level.buffer.Line(0)); got != "xythe last change, u undoes it and ctrl+r redoes it." {
+		t.Errorf("expected u to undo the repeated insert at once, got %q", got)
+	}
+
+	// history commands are not used in the levels before
+	nine := NewLevelNine().(*TextLevel)
+	nine.Init(80, 20)
+	if result := nine.ApplyHistory(models.History{Kind: models.HistoryUndo, Keys: keys("u")}); result.ValidMove {
+		t.Errorf("expected u to be rejected in level nine")
+	}
+}
+
+func Test_MacroLevel(t *testing.T) {
+	// fix quotes the line the cursor is on and moves down to the next one,
+	// it stops early when the fix completes the level
+	fix := func(level *TextLevel) models.PlayerMovement {
+		level.StartInsert(models.Insert{Kind: models.InsertLineStart, Keys: keys("I")})
+		level.TypeKey(`"`)
+		level.StopInsert()
+		level.StartInsert(models.Insert{Kind: models.InsertLineEnd, Keys: keys("A")})
+		level.TypeKey(`"`)
+		level.TypeKey(",")
+		if result := level.StopInsert(); result.Completed {
+			return result
+		}
+		return level.PlayerMove(move(models.MotionDown, 0, "j"))
+	}
+
+	t.Run("replayed keys are not spent", func(t *testing.T) {
+		level := NewLevelTwelve().(*TextLevel)
+		level.Init(80, 30)
+
+		level.PlayerMove(move(models.MotionDown, 2, "2j"))
+		level.ApplyMacro(models.Macro{Kind: models.MacroRecord, Register: 'a', Keys: keys("qa")})
+		fix(level)
+		level.ApplyMacro(models.Macro{Kind: models.MacroRecord, Keys: keys("q")})
+		result := level.ApplyMacro(models.Macro{Kind: models.MacroReplay, Register: 'a', Count: 19, Keys: keys("19@a")})
+
+		level.SetReplaying(true)
+		for range 19 {
+			result = fix(level)
+		}
+		level.SetReplaying(false)
+
+		if !result.Completed {
+			t.Fatalf("expected the level to be completed, got %q with text %q", result.InstructionMessage, level.buffer.Lines())
+		}
+	})
+
+	t.Run("fixing every line by hand goes over par", func(t *testing.T) {
+		level := NewLevelTwelve().(*TextLevel)
+		level.Init(80, 30)
+
+		level.PlayerMove(move(models.MotionDown, 2, "2j"))
+		fix(level)
+		fix(level)
+		if result := fix(level); result.Completed {
+			t.Fatalf("expected the level not to be completed")
+		}
+		if !slices.Equal(level.buffer.Lines(), macroText) {
+			t.Errorf("expected the text to be restored once over par, got %q", level.buffer.Lines())
+		}
+	})
+
+	// macros are not used in the levels before
+	eleven := NewLevelEleven().(*TextLevel)
+	eleven.Init(80, 20)
+	if result := eleven.ApplyMacro(models.Macro{Kind: models.MacroRecord, Register: 'a', Keys: keys("qa")}); result.ValidMove {
+		t.Errorf("expected qa to be rejected in level eleven")
+	}
+}
+
+func Test_TextObjectLevel(t *testing.T) {
+	level := NewLevelThirteen().(*TextLevel)
+	level.Init(80, 20)
+
+	object := func(operator models.Operator, object models.TextObject, typed string) models.PlayerMovement {
+		return level.ApplyOperation(models.Operation{Operator: operator, Object: object, Keys: keys(typed)})
+	}
+	find := move(models.MotionFindForward, 0, "fy")
+	find.Char = 'y'
+
+	level.PlayerMove(find)
+	object(models.OperatorDelete, models.TextObjectAWord, "daw")
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	object(models.OperatorChange, models.TextObjectInnerDoubleQuote, `ci"`)
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	level.PlayerMove(move(models.MotionRight, 0, "l"))
+	object(models.OperatorYank, models.TextObjectAParen, "ya(")
+	if level.register.Text != "(price, tax)" {
+		t.Errorf("expected ya( to yank the parentheses, got %q", level.register.Text)
+	}
+	level.PlayerMove(move(models.MotionDown, 2, "2j"))
+	object(models.OperatorDelete, models.TextObjectInnerBrace, "di{")
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	level.PlayerMove(move(models.MotionWORDForward, 0, "W"))
+	object(models.OperatorDelete, models.TextObjectATag, "dat")
+	level.PlayerMove(move(models.MotionDown, 2, "2j"))
+	result := object(models.OperatorDelete, models.TextObjectAParagraph, "dap")
+
+	if !result.Completed {
+		t.Fatalf("expected the level to be completed within par, got %q with text %q", result.InstructionMessage, level.buffer.Lines())
+	}
+}
+
+func Test_ProseLines(t *testing.T) {
+	sections := []models.Section{
+		{Title: "One", Content: "A **bold** start\nthat goes on.\n\nItems:\n- first item that wraps\n- second"},
+		{Title: "Two", Content: "Last."},
+	}
+	want := []string{
+		"One", "",
+		"A bold start that", "goes on.", "",
+		"Items:", "- first item that", "  wraps", "- second", "",
+		"Two", "",
+		"Last.",
+	}
+
+	got := proseLines(sections, 17)
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func Test_ProseLevel(t *testing.T) {
+	level := NewLevelFourteen().(*TextLevel)
+	level.Init(80, 20)
+
+	if message := level.GetInstructions(); !strings.HasSuffix(message, proseSteps[0].hint) {
+		t.Errorf("expected the instructions to explain the motion, got %q", message)
+	}
+
+	// reaching the empty line with j takes as many keys as } and still reaches the target
+	result := level.PlayerMove(move(models.MotionDown, 0, "j"))
+	if level.GetCurrentTarget() != 1 {
+		t.Fatalf("expected the first target to be reached, got %q", result.InstructionMessage)
+	}
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	result = level.PlayerMove(move(models.MotionDown, 0, "j"))
+	if !strings.HasPrefix(result.InstructionMessage, "Over par!") {
+		t.Errorf("expected moving by lines to the sentence to be over par, got %q", result.InstructionMessage)
+	}
+
+	for _, step := range proseSteps[1:] {
+		for _, motion := range step.motions {
+			result = level.PlayerMove(motion)
+		}
+	}
+	if !result.Completed {
+		t.Fatalf("expected the level to be completed within par, got %q at %v", result.InstructionMessage, level.player)
+	}
+}
+
+func Test_SnippetLines(t *testing.T) {
+	if _, err := snippetLines(pairSnippet); err != nil {
+		t.Fatalf("expected the snippet of level fifteen to load, got %v", err)
+	}
+	_, err := snippetLines("missing.go.txt")
+	if err == nil {
+		t.Fatal("expected an error for a snippet that is not bundled")
+	}
+
+	// a level whose text could not be loaded returns the error instead of being played
+	level := &TextLevel{number: levelNumberFifteen, loadErr: err}
+	if got := level.Init(80, 40); !errors.Is(got, err) {
+		t.Errorf("expected Init to return %v, got %v", err, got)
+	}
+	if got := level.Restore(models.SavedLevel{Width: 80, Height: 40}); !errors.Is(got, err) {
+		t.Errorf("expected Restore to return %v, got %v", err, got)
+	}
+}
+
+func Test_PairLevel(t *testing.T) {
+	level := NewLevelFifteen().(*TextLevel)
+	level.Init(80, 40)
+	pair := move(models.MotionMatchPair, 0, "%")
+
+	result := level.PlayerMove(pair)
+	if result.ValidMove || !strings.HasPrefix(result.InstructionMessage, "There is no bracket") {
+		t.Errorf("expected %% without a bracket to fail with a message, got %q", result.InstructionMessage)
+	}
+	level.PlacePlayer(models.Position{X: 50, Y: 26})
+	result = level.PlayerMove(pair)
+	if result.ValidMove || !strings.HasPrefix(result.InstructionMessage, ") has no matching bracket.") {
+		t.Errorf("expected %% on the smiley in the comment to fail with a message, got %q", result.InstructionMessage)
+	}
+
+	level.Init(80, 40)
+	for _, step := range pairSteps {
+		for _, motion := range step.motions {
+			result = level.PlayerMove(motion)
+		}
+	}
+	if !result.Completed {
+		t.Fatalf("expected the level to be completed within par, got %q at %v", result.InstructionMessage, level.player)
+	}
+}
+
+func Test_DefinedLevel(t *testing.T) {
+	def := models.LevelDefinition{
+		Number:      100,
+		Description: "Find the way around the walls",
+		Lesson:      "hjkl and $",
+		Text:        []string{"#######", "#  #  #", "#     #", "#######"},
+		Wall:        '#',
+		Start:       models.Position{X: 1, Y: 1},
+		Allowed:     slices.Concat(models.BasicMotions, []models.MotionKind{models.MotionEndOfLine}),
+		Targets: []models.TargetDefinition{
+			{Position: models.Position{X: 2, Y: 1}, Par: 1, Hint: "$ stops in front of the wall"},
+			{Position: models.Position{X: 5, Y: 2}},
+		},
+	}
+	level := NewDefinedLevel(def).(*TextLevel)
+	level.Init(40, 10)
+
+	if level.GetCurrentPosition() != def.Start {
+		t.Errorf("expected the player at %v, got %v", def.Start, level.GetCurrentPosition())
+	}
+	// the par of the first target is defined, the second one is reached from the first with j$
+	if par, ok := level.Par(); !ok || par != 3 {
+		t.Errorf("expected a par of 3, got %d (%v)", par, ok)
+	}
+	if got := level.Render()[textOffsetY][textOffsetX+level.gutterWidth()]; got != models.DefaultCharacters.Wall.Rune {
+		t.Errorf("expected the wall to be drawn as %q, got %q", models.DefaultCharacters.Wall.Rune, got)
+	}
+	if message := level.GetInstructions(); !strings.HasSuffix(message, "within 1 keystrokes using hjkl and $. $ stops in front of the wall") {
+		t.Errorf("expected the instructions to name the par and the hint, got %q", message)
+	}
+	if result := level.PlayerMove(move(models.MotionUp, 0, "k")); result.ValidMove {
+		t.Errorf("expected k to be stopped by the wall, got %v", result.UpdatedPosition)
+	}
+	if hint, ok := level.Hint(); !ok || hint != "l" {
+		t.Errorf("expected a hint of l, got %q (%v)", hint, ok)
+	}
+
+	level.PlayerMove(move(models.MotionEndOfLine, 0, "$"))
+	if level.GetCurrentTarget() != 1 {
+		t.Fatalf("expected the first target to be reached at %v, got %v", def.Targets[0].Position, level.GetCurrentPosition())
+	}
+	// the second target is reached from the first with j$
+	if hint, ok := level.Hint(); !ok || hint != "j" {
+		t.Errorf("expected a hint of j, got %q (%v)", hint, ok)
+	}
+	level.PlayerMove(move(models.MotionDown, 0, "j"))
+	result := level.PlayerMove(move(models.MotionEndOfLine, 0, "$"))
+	if !result.Completed {
+		t.Errorf("expected the level to be completed, got %q at %v", result.InstructionMessage, level.GetCurrentPosition())
+	}
+	if hint, ok := level.Hint(); ok {
+		t.Errorf("expected no hint in a completed level, got %q", hint)
+	}
+}
@@ -0,0 +1,49 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+const levelNumberThirteen = 13
+
+// objectText is the text of level thirteen, code with quoted strings, brackets, tags and paragraphs to act on
+var objectText = []string{
+	"Text objects act on the really whole thing the cursor is in.",
+	`log.Print("remove this message")`,
+	"total := add(price, tax) * (count + extra)",
+	"func cleanup() {",
+	`    os.Remove("tmp")`,
+	"}",
+	"<li>Drop <em>the emphasis</em> here</li>",
+	"",
+	"This paragraph repeats itself and can go.",
+	"This paragraph repeats itself and can go.",
+	"",
+	"The end stays.",
+}
+
+// NewLevelThirteen returns a new instance of models.Level thirteen,
+// the spans of the edits are positions in the text as it is after the edits before them
+func NewLevelThirteen() models.Level {
+	return &TextLevel{
+		number:      levelNumberThirteen,
+		description: "Act on words, strings, blocks, tags and paragraphs with text objects",
+		lesson:      `i and a text objects like iw, aw, i", a(, i{, at and ap`,
+		content:     objectText,
+		allowed:     slices.Concat(models.BasicMotions, models.WordMotions, models.LineMotions, models.FindMotions),
+		operators:   models.Operators,
+		edits: []edit{
+			{models.OperatorDelete, vim.Span{Start: models.Position{X: 24, Y: 0}, End: models.Position{X: 31, Y: 0}}},   // fydaw
+			{models.OperatorChange, vim.Span{Start: models.Position{X: 11, Y: 1}, End: models.Position{X: 30, Y: 1}}},   // jci"
+			{models.OperatorYank, vim.Span{Start: models.Position{X: 12, Y: 2}, End: models.Position{X: 24, Y: 2}}},     // jlya(
+			{models.OperatorDelete, vim.Span{Start: models.Position{Y: 4}, End: models.Position{Y: 4}, Linewise: true}}, // 2jdi{
+			{models.OperatorDelete, vim.Span{Start: models.Position{X: 9, Y: 5}, End: models.Position{X: 30, Y: 5}}},    // jWdat
+			{models.OperatorDelete, vim.Span{Start: models.Position{Y: 7}, End: models.Position{Y: 9}, Linewise: true}}, // 2jdap
+		},
+		par:   []int{5, 4, 5, 5, 5, 5},
+		chars: &models.DefaultCharacters,
+	}
+}
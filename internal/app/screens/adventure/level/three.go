@@ -0,0 +1,47 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const levelNumberThree = 3
+
+// linesText is the text of level three, it mixes indented and unindented lines of different lengths
+var linesText = []string{
+	"Line motions move the cursor along a line or through the whole file.",
+	"    Indented lines start after some blanks, ^ skips them for you.",
+	"",
+	"0 jumps to the very first column, even when the line is indented.",
+	"The dollar sign jumps to the last character of the current line.",
+	"",
+	"    gg jumps to the first line and G jumps to the last line.",
+	"    Type a number before G to jump to that line, like 4G.",
+	"",
+	"Use the line numbers on the left to find the line you want.",
+	"        Deeply indented lines are a good place to practice ^ and 0.",
+	"The end.",
+}
+
+// NewLevelThree returns a new instance of models.Level three
+func NewLevelThree() models.Level {
+	return &TextLevel{
+		number:      levelNumberThree,
+		description: "Move through lines with line motions",
+		lesson:      "0, ^, $, gg, G and {count}G",
+		content:     linesText,
+		allowed:     slices.Concat(models.BasicMotions, models.LineMotions),
+		spots: []models.Position{
+			{X: 67, Y: 0}, // $
+			{X: 4, Y: 1},  // j^
+			{X: 0, Y: 11}, // G^
+			{X: 4, Y: 6},  // 7G^
+			{X: 8, Y: 10}, // 11G^
+			{X: 63, Y: 4}, // 5G$
+			{X: 0, Y: 0},  // gg0
+		},
+		par:   []int{1, 2, 2, 3, 4, 3, 3},
+		chars: &models.DefaultCharacters,
+	}
+}
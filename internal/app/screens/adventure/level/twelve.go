@@ -0,0 +1,54 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const levelNumberTwelve = 12
+
+// fruits are the lines of level twelve that all need the same fix
+var fruits = []string{
+	"apple", "banana", "cherry", "date", "elderberry",
+	"fig", "grape", "honeydew", "kiwi", "lemon",
+	"mango", "nectarine", "orange", "papaya", "quince",
+	"raspberry", "strawberry", "tangerine", "ugli", "watermelon",
+}
+
+// macroText is the text of level twelve, twenty lines that are fixed the same way
+var macroText = slices.Concat([]string{
+	"Record the fix of one line with qa, stop with q and replay it with @a.",
+	"Quote every fruit below and end it with a comma:",
+}, fruits)
+
+// macroGoal is the text level twelve is completed with
+var macroGoal = slices.Concat(macroText[:2], quoted(fruits))
+
+// quoted returns the lines as quoted strings followed by a comma
+func quoted(lines []string) []string {
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = `"` + line + `",`
+	}
+	return result
+}
+
+// NewLevelTwelve returns a new instance of models.Level twelve,
+// fixing every line by hand takes far more keystrokes than its par
+func NewLevelTwelve() models.Level {
+	return &TextLevel{
+		number:       levelNumberTwelve,
+		description:  "Record and replay macros",
+		lesson:       "q{a-z} to record, q to stop and @{a-z} or @@ to replay",
+		content:      macroText,
+		allowed:      slices.Concat(models.BasicMotions, models.WordMotions, models.LineMotions),
+		operators:    models.Operators,
+		inserts:      models.InsertKinds,
+		historyKinds: models.HistoryKinds,
+		macroKinds:   models.MacroKinds,
+		goal:         macroGoal,
+		par:          []int{25}, // 18 with 2jqaI"<esc>A",<esc>jq19@a, leaving room for a slip while recording
+		chars:        &models.DefaultCharacters,
+	}
+}
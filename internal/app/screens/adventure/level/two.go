@@ -0,0 +1,49 @@
+package level
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const levelNumberTwo = 2
+
+// wordsText is the text of level two, it mixes words, punctuation and long WORDs
+var wordsText = []string{
+	"Words are the building blocks of every sentence you edit in Vim.",
+	"",
+	"A word is a run of letters, digits or underscores like snake_case_name,",
+	"or a run of other characters such as ... or ==> or ().",
+	"",
+	"Use w to jump to the start of the following word, b to jump back to the",
+	"start of the previous word and e to jump to the end of the word.",
+	"",
+	"Punctuation splits words: don't, foo.bar and key=value contain",
+	"several words each, yet each of them is only a single WORD.",
+	"",
+	"A WORD is any run of non-blank characters. W, B and E leap over",
+	"long paths like ~/projects/go-learn-vim/main.go or user@host:22 at once.",
+}
+
+// NewLevelTwo returns a new instance of models.Level two
+func NewLevelTwo() models.Level {
+	return &TextLevel{
+		number:      levelNumberTwo,
+		description: "Jump through text with word motions",
+		lesson:      "w, b, e, W, B and E",
+		content:     wordsText,
+		allowed:     slices.Concat(models.BasicMotions, models.WordMotions),
+		spots: []models.Position{
+			{X: 14, Y: 0},  // building
+			{X: 28, Y: 0},  // end of blocks
+			{X: 55, Y: 2},  // snake_case_name
+			{X: 44, Y: 3},  // ==>
+			{X: 37, Y: 8},  // bar of foo.bar
+			{X: 33, Y: 8},  // foo of foo.bar
+			{X: 16, Y: 12}, // ~/projects/go-learn-vim/main.go
+			{X: 62, Y: 12}, // end of user@host:22
+			{X: 0, Y: 12},  // long
+		},
+		chars: &models.DefaultCharacters,
+	}
+}
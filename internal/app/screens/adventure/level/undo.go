@@ -0,0 +1,183 @@
+package level
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// undoHistory keeps every change to the text of a level in an undo tree and the last change for . to repeat,
+// changes made before the attempt at the current target started cannot be undone
+type undoHistory struct {
+	tree        *vim.UndoTree
+	changeStart models.Position // where the change that is being made started
+	lastChange  *repeatable
+	attempt     int // the change the attempt at the current target started at
+}
+
+// repeatable is a change that . repeats, either an operation or an insert
+// together with the keys that were typed in insert mode after it
+type repeatable struct {
+	operation *models.Operation
+	insert    *models.Insert
+	typed     []string
+}
+
+// reset starts a new undo tree at the lines without a change to repeat
+func (h *undoHistory) reset(lines []string) {
+	h.tree = vim.NewUndoTree(lines)
+	h.lastChange = nil
+}
+
+// restore restores the saved changes, a save without them or with changes that do not form a tree
+// starts a new tree at the lines. Text that was typed without leaving insert mode is added as a change made at pos
+func (h *undoHistory) restore(changes []models.Change, current int, lines []string, pos models.Position) {
+	tree, ok := vim.RestoreUndoTree(changes, current)
+	if !ok {
+		tree = vim.NewUndoTree(lines)
+	}
+	h.tree = tree
+	h.lastChange = nil
+	h.changeStart = pos
+	h.commit(lines)
+}
+
+// begin starts a change at pos, the change is repeated with . unless it is nil
+func (h *undoHistory) begin(change *repeatable, pos models.Position) {
+	h.lastChange = change
+	h.changeStart = pos
+}
+
+// commit adds the lines to the undo tree once a change is complete
+func (h *undoHistory) commit(lines []string) {
+	h.tree.Commit(lines, h.changeStart)
+}
+
+// record adds a key typed in insert mode to the change that is repeated with .
+func (h *undoHistory) record(key string) {
+	if h.lastChange != nil {
+		h.lastChange.typed = append(h.lastChange.typed, key)
+	}
+}
+
+// startAttempt marks the current change as the one the attempt at the current target started at
+func (h *undoHistory) startAttempt() {
+	h.attempt = h.tree.Current()
+}
+
+// revert goes back to the change the attempt started at and returns its lines
+func (h *undoHistory) revert() []string {
+	h.tree.Revert(h.attempt)
+	return h.tree.Lines()
+}
+
+// undo undoes the current change and returns where it was made, changes made before the
+// attempt at the current target started cannot be undone
+func (h *undoHistory) undo() (models.Position, bool) {
+	if h.tree.Current() == h.attempt {
+		return models.Position{}, false
+	}
+	return h.tree.Undo()
+}
+
+// redo redoes the change after the current one and returns where it was made
+func (h *undoHistory) redo() (models.Position, bool) {
+	return h.tree.Redo()
+}
+
+// AllowedHistory returns the commands that undo, redo and repeat changes in the level
+func (tl *TextLevel) AllowedHistory() []models.HistoryKind {
+	return tl.historyKinds
+}
+
+// ApplyHistory handles a models.History, which undoes, redoes or repeats changes to the text
+func (tl *TextLevel) ApplyHistory(history models.History) models.PlayerMovement {
+	if !slices.Contains(tl.historyKinds, history.Kind) {
+		return tl.invalidMove(fmt.Sprintf("%s is not used in this level.", history))
+	}
+
+	switch history.Kind {
+	case models.HistoryRepeat:
+		return tl.repeat(history)
+	case models.HistoryUndo:
+		return tl.travel(history, "Already at oldest change.", tl.history.undo)
+	default:
+		return tl.travel(history, "Already at newest change.", tl.history.redo)
+	}
+}
+
+// travel takes count steps through the undo tree and shows the text it ends up at, the cursor goes to
+// where the last change that was undone or redone was made. The message is shown when no step can be taken
+func (tl *TextLevel) travel(history models.History, message string, step func() (models.Position, bool)) models.PlayerMovement {
+	var pos models.Position
+	steps := 0
+	for range max(history.Count, 1) {
+		p, ok := step()
+		if !ok {
+			break
+		}
+		pos = p
+		steps++
+	}
+	if steps == 0 {
+		return tl.invalidMove(message)
+	}
+	tl.loadText(tl.history.tree.Lines())
+	tl.player = tl.buffer.Clamp(pos)
+	tl.spend(history.Keystrokes())
+	return tl.typed()
+}
+
+// repeat makes the last change again at the cursor, like in Vim a count replaces the count of the change
+// and the text that was typed in insert mode is typed again. Only the keys of . are spent on the target
+func (tl *TextLevel) repeat(history models.History) models.PlayerMovement {
+	change := tl.history.lastChange
+	if change == nil {
+		return tl.invalidMove("There is no change to repeat yet.")
+	}
+
+	var result models.PlayerMovement
+	if change.operation != nil {
+		operation := *change.operation
+		if history.Count > 0 {
+			operation.Count = history.Count
+			operation.Motion.Count = history.Count
+		}
+		if result = tl.operate(operation, history.Keystrokes()); result.Completed || !result.ValidMove {
+			return result
+		}
+	} else {
+		tl.startInsert(*change.insert)
+		tl.spend(history.Keystrokes())
+	}
+	for _, key := range change.typed {
+		if !tl.inserting {
+			break
+		}
+		tl.typeKey(key)
+	}
+	if tl.inserting {
+		tl.leaveInsert()
+	}
+	if change.operation != nil && tl.goal == nil {
+		// the operation already checked the edit
+		return result
+	}
+	return tl.typed()
+}
+
+// Changes returns the changes in the undo tree of the level and the index of the current one,
+// it is nil for a level without history commands
+func (tl *TextLevel) Changes() ([]models.Change, int) {
+	if len(tl.historyKinds) == 0 {
+		return nil, 0
+	}
+	return tl.history.tree.Changes(), tl.history.tree.Current()
+}
+
+// commit adds the text to the undo tree once a change is complete
+func (tl *TextLevel) commit() {
+	tl.history.commit(tl.buffer.Lines())
+}
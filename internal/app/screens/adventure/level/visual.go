@@ -0,0 +1,134 @@
+package level
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// selection is the text selected in visual mode, from the anchor where visual mode started up to the cursor
+type selection struct {
+	mode   models.VisualMode
+	anchor models.Position
+}
+
+// toggle starts selecting in the mode from the cursor, switches to the mode while keeping
+// the selection or leaves visual mode when the mode is already active
+func (s *selection) toggle(mode models.VisualMode, cursor models.Position) {
+	switch s.mode {
+	case mode:
+		s.mode = models.VisualNone
+	case models.VisualNone:
+		s.mode = mode
+		s.anchor = cursor
+	default:
+		s.mode = mode
+	}
+}
+
+// clear leaves visual mode
+func (s *selection) clear() {
+	s.mode = models.VisualNone
+}
+
+// active returns whether text is being selected
+func (s *selection) active() bool {
+	return s.mode != models.VisualNone
+}
+
+// span returns the vim.Span of the text selected up to the cursor
+func (s *selection) span(buf *vim.Buffer, cursor models.Position) vim.Span {
+	return vim.VisualSpan(buf, s.anchor, cursor, s.mode)
+}
+
+// AllowedVisualModes returns the modes in which text can be selected in the level
+func (tl *TextLevel) AllowedVisualModes() []models.VisualMode {
+	return tl.visualModes
+}
+
+// VisualMode returns the mode in which text is being selected, models.VisualNone outside visual mode
+func (tl *TextLevel) VisualMode() models.VisualMode {
+	return tl.selection.mode
+}
+
+// StartVisual handles a models.Visual, like in Vim it starts selecting from the cursor,
+// switches to another mode while keeping the selection or leaves visual mode when the mode is already active
+func (tl *TextLevel) StartVisual(visual models.Visual) models.PlayerMovement {
+	if !slices.Contains(tl.visualModes, visual.Mode) {
+		return tl.invalidMove(fmt.Sprintf("%s is not used in this level.", visual))
+	}
+	tl.selection.toggle(visual.Mode, tl.player)
+	tl.spend(visual.Keystrokes())
+	return tl.selected()
+}
+
+// StopVisual leaves visual mode without acting on the selection
+func (tl *TextLevel) StopVisual() models.PlayerMovement {
+	if !tl.selection.active() {
+		return tl.invalidMove("")
+	}
+	tl.selection.clear()
+	tl.spend(1)
+	return tl.selected()
+}
+
+// selected shows the text after the selection changed or a macro command was typed, neither changes the text
+func (tl *TextLevel) selected() models.PlayerMovement {
+	if par := tl.currentPar(); par > 0 && tl.keys.spent >= par {
+		// the edit can no longer be made within par
+		return tl.retry(tl.overParMessage())
+	}
+	tl.drawGrid()
+	return models.PlayerMovement{
+		UpdatedPosition:    tl.player,
+		Completed:          false,
+		ValidMove:          true,
+		InstructionMessage: tl.GetInstructions(),
+	}
+}
+
+// Overlays returns the highlight of every cell of the grid in a level with visual modes,
+// the selection is highlighted over the text of the current edit
+func (tl *TextLevel) Overlays() [][]models.Overlay {
+	if len(tl.visualModes) == 0 {
+		return nil
+	}
+	overlays := make([][]models.Overlay, len(tl.grid))
+	for y := range overlays {
+		overlays[y] = make([]models.Overlay, tl.width)
+	}
+	if len(tl.visualModes) > 0 && tl.edits != nil && !tl.completed {
+		tl.highlight(overlays, tl.edits[tl.currentTarget].span, models.OverlayRegion)
+	}
+	if tl.selection.active() {
+		tl.highlight(overlays, tl.selection.span(tl.buffer, tl.player), models.OverlaySelection)
+	}
+	return overlays
+}
+
+// highlight sets the models.Overlay of the cells of the text covered by the vim.Span,
+// an empty line has a single cell that can be highlighted
+func (tl *TextLevel) highlight(overlays [][]models.Overlay, span vim.Span, overlay models.Overlay) {
+	for y := span.Start.Y; y <= min(span.End.Y, tl.buffer.LineCount()-1); y++ {
+		for x := range max(len(tl.buffer.Line(y)), 1) {
+			pos := models.Position{X: x, Y: y}
+			if gx, gy, ok := tl.cell(pos); ok && span.Covers(pos) {
+				overlays[gy][gx] = overlay
+			}
+		}
+	}
+}
+
+// visualKey returns the key of the visual mode that selects the vim.Span
+func visualKey(span vim.Span) string {
+	switch {
+	case span.Linewise:
+		return "V"
+	case span.Block:
+		return "ctrl+v"
+	default:
+		return "v"
+	}
+}
@@ -2,8 +2,12 @@ package level
 
 import (
 	"fmt"
-	"github.com/dasvh/go-learn-vim/internal/models"
+	"slices"
 	"time"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/solver"
+	"github.com/dasvh/go-learn-vim/internal/vim"
 )
 
 const levelNumberZero = 0
@@ -18,6 +22,7 @@ type Zero struct {
 	inProgress     bool
 	movementBlock  bool
 	grid           [][]rune
+	buffer         *vim.Buffer
 	chars          *models.Characters
 	player         models.Position
 	targets        []models.Target
@@ -45,7 +50,7 @@ func (level0 *Zero) Description() string {
 }
 
 // Init initializes the level with the given dimensions
-func (level0 *Zero) Init(width, height int) {
+func (level0 *Zero) Init(width, height int) error {
 	level0.restore = false
 	level0.completed = false
 	level0.inProgress = true
@@ -53,10 +58,16 @@ func (level0 *Zero) Init(width, height int) {
 	level0.setDimensions(width, height)
 	level0.resetTargets()
 	level0.initializeGrid()
+	return nil
+}
+
+// AllowedMotions returns the motions that can be used in the level
+func (level0 *Zero) AllowedMotions() []models.MotionKind {
+	return models.BasicMotions
 }
 
 // PlayerMove handles models.PlayerMovement and models.Target interaction
-func (level0 *Zero) PlayerMove(delta models.Position) models.PlayerMovement {
+func (level0 *Zero) PlayerMove(motion models.Motion) models.PlayerMovement {
 	// block movement if cooldown is active
 	if level0.movementBlock && time.Now().Before(level0.blockEnds) {
 		return models.PlayerMovement{
@@ -72,13 +83,10 @@ func (level0 *Zero) PlayerMove(delta models.Position) models.PlayerMovement {
 		level0.movementBlock = false
 	}
 
-	newPos := models.Position{
-		X: level0.player.X + delta.X,
-		Y: level0.player.Y + delta.Y,
-	}
+	newPos, moved := vim.Resolve(level0.buffer, level0.player, motion)
 
-	// check if player is within bounds
-	if newPos.X < 0 || newPos.X >= level0.width || newPos.Y < 0 || newPos.Y >= level0.height {
+	// check if the motion is allowed and the player stays within bounds
+	if !slices.Contains(level0.AllowedMotions(), motion.Kind) || !moved {
 		return models.PlayerMovement{
 			UpdatedPosition:    level0.player,
 			Completed:          level0.completed,
@@ -169,6 +177,28 @@ func (level0 *Zero) GetInstructions() string {
 	return fmt.Sprintf("Instructions: Target %d/%d: Reach the X using hjkl keys", level0.currentTarget+1, level0.targetBehavior.GetTargetCount())
 }
 
+// Par returns the fewest keystrokes it takes to reach every target, the player starts
+// from the middle of the screen for each of them
+func (level0 *Zero) Par() (int, bool) {
+	total := 0
+	for _, target := range level0.targets {
+		solution, ok := solver.Solve(level0.buffer, level0.GetStartPosition(), target.Position, level0.AllowedMotions())
+		if !ok {
+			return 0, false
+		}
+		total += solution.Keystrokes
+	}
+	return total, len(level0.targets) > 0
+}
+
+// Hint returns the first motion of the fewest keystrokes onto the current target
+func (level0 *Zero) Hint() (string, bool) {
+	if level0.completed || level0.currentTarget >= len(level0.targets) {
+		return "", false
+	}
+	return nextMotion(level0.buffer, level0.player, level0.targets[level0.currentTarget].Position, level0.AllowedMotions())
+}
+
 // InProgress returns whether the level is in progress
 func (level0 *Zero) InProgress() bool {
 	return level0.inProgress
@@ -223,6 +253,7 @@ func (level0 *Zero) clearGrid() {
 func (level0 *Zero) setDimensions(width, height int) {
 	level0.width = width
 	level0.height = height
+	level0.buffer = vim.NewBlankBuffer(width, height)
 	level0.grid = make([][]rune, height)
 	for y := range level0.grid {
 		level0.grid[y] = make([]rune, width)
@@ -0,0 +1,99 @@
+package adventure
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// macroRecorder records the keys typed into a register and keeps the registers with the
+// recorded macros, like in Vim the keys are kept as they were typed and replayed as such
+type macroRecorder struct {
+	registers map[rune][]tea.KeyMsg
+	recording rune // register the keys are recorded into, zero when not recording
+	keys      []tea.KeyMsg
+	last      rune // register that was replayed last, for @@
+}
+
+// Start starts recording keys into the register
+func (mr *macroRecorder) Start(register rune) {
+	mr.recording = register
+	mr.keys = nil
+}
+
+// Recording returns the register keys are being recorded into and whether keys are being recorded
+func (mr *macroRecorder) Recording() (rune, bool) {
+	return mr.recording, mr.recording != 0
+}
+
+// Record adds the key message to the keys being recorded, it does nothing when not recording
+func (mr *macroRecorder) Record(msg tea.KeyMsg) {
+	if mr.recording != 0 {
+		mr.keys = append(mr.keys, msg)
+	}
+}
+
+// Stop stops recording and stores the recorded keys in the register,
+// the q that stopped recording was recorded last and is left out
+func (mr *macroRecorder) Stop() {
+	if mr.recording == 0 {
+		return
+	}
+	if mr.registers == nil {
+		mr.registers = make(map[rune][]tea.KeyMsg)
+	}
+	mr.registers[mr.recording] = slices.Clone(mr.keys[:max(len(mr.keys)-1, 0)])
+	mr.recording = 0
+	mr.keys = nil
+}
+
+// Keys returns the keys recorded in the register and whether it holds any, the register
+// models.LastMacroRegister stands for the register that was replayed last
+func (mr *macroRecorder) Keys(register rune) ([]tea.KeyMsg, bool) {
+	if register == models.LastMacroRegister {
+		register = mr.last
+	}
+	keys, ok := mr.registers[register]
+	if !ok || len(keys) == 0 {
+		return nil, false
+	}
+	mr.last = register
+	return keys, true
+}
+
+// Registers returns a line for every register that holds a macro, sorted by name
+func (mr *macroRecorder) Registers() []string {
+	names := make([]rune, 0, len(mr.registers))
+	for name := range mr.registers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = fmt.Sprintf("%c  %s", name, formatKeys(mr.registers[name]))
+	}
+	return lines
+}
+
+// formatKeys returns the keys the way Vim shows them in a register,
+// keys that do not type a character are written between angle brackets
+func formatKeys(keys []tea.KeyMsg) string {
+	var sb strings.Builder
+	for _, msg := range keys {
+		if isCharKey(msg) {
+			sb.WriteString(string(msg.Runes))
+		} else {
+			sb.WriteString("<" + msg.String() + ">")
+		}
+	}
+	return sb.String()
+}
+
+// isRegisterName reports whether r can be used as the name of a register to record a macro into
+func isRegisterName(r rune) bool {
+	return r >= 'a' && r <= 'z'
+}
@@ -0,0 +1,44 @@
+package adventure
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+func Test_MacroRecorder(t *testing.T) {
+	char := func(r rune) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+	}
+
+	var mr macroRecorder
+	mr.Record(char('x'))
+	if _, ok := mr.Keys('a'); ok {
+		t.Fatalf("expected no keys to be recorded before recording starts")
+	}
+
+	mr.Start('a')
+	if register, ok := mr.Recording(); !ok || register != 'a' {
+		t.Fatalf("expected to record into a, got %q, %v", register, ok)
+	}
+	for _, msg := range []tea.KeyMsg{char('I'), char('"'), {Type: tea.KeyEsc}, char('j'), char('q')} {
+		mr.Record(msg)
+	}
+	mr.Stop()
+	if _, ok := mr.Recording(); ok {
+		t.Errorf("expected recording to stop")
+	}
+
+	keys, ok := mr.Keys('a')
+	if !ok || formatKeys(keys) != `I"<esc>j` {
+		t.Fatalf("expected register a to hold the keys without the q that stopped recording, got %q", formatKeys(keys))
+	}
+	if keys, ok := mr.Keys(models.LastMacroRegister); !ok || len(keys) != 4 {
+		t.Errorf("expected @@ to replay register a, got %v, %v", keys, ok)
+	}
+	if want := []string{`a  I"<esc>j`}; !slices.Equal(mr.Registers(), want) {
+		t.Errorf("expected registers %q, got %q", want, mr.Registers())
+	}
+}
@@ -0,0 +1,172 @@
+package editor
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+const (
+	// wall is the character of the walls painted on the canvas
+	wall = '#'
+	// maxCanvasWidth and maxCanvasHeight limit the size of the canvas
+	maxCanvasWidth  = 80
+	maxCanvasHeight = 30
+)
+
+// canvas holds the text of a level that is being drawn, with its ordered targets, the start position and
+// the cursor that moves over it. Every line of the text is as wide as the canvas
+type canvas struct {
+	lines   [][]rune
+	targets []models.TargetDefinition
+	start   models.Position
+	cursor  models.Position
+}
+
+// newCanvas creates a canvas of the size that is surrounded by walls, the start and the cursor are inside them
+func newCanvas(width, height int) *canvas {
+	c := &canvas{}
+	c.resize(width, height)
+	for y := range c.lines {
+		for x := range c.lines[y] {
+			if y == 0 || y == height-1 || x == 0 || x == width-1 {
+				c.lines[y][x] = wall
+			}
+		}
+	}
+	c.start = models.Position{X: min(1, width-1), Y: min(1, height-1)}
+	c.cursor = c.start
+	return c
+}
+
+// size returns the width and height of the canvas
+func (c *canvas) size() (int, int) {
+	return len(c.lines[0]), len(c.lines)
+}
+
+// resize changes the size of the canvas within its limits, new cells are empty. Targets that are cut off
+// are removed, the start and the cursor are moved back onto the canvas
+func (c *canvas) resize(width, height int) {
+	width = min(max(width, 1), maxCanvasWidth)
+	height = min(max(height, 1), maxCanvasHeight)
+	lines := make([][]rune, height)
+	for y := range lines {
+		lines[y] = make([]rune, width)
+		for x := range lines[y] {
+			lines[y][x] = ' '
+			if y < len(c.lines) && x < len(c.lines[y]) {
+				lines[y][x] = c.lines[y][x]
+			}
+		}
+	}
+	c.lines = lines
+	c.targets = slices.DeleteFunc(c.targets, func(target models.TargetDefinition) bool {
+		return !c.contains(target.Position)
+	})
+	c.start = c.clamp(c.start)
+	c.cursor = c.clamp(c.cursor)
+}
+
+// move moves the cursor by the offsets, it stops at the edges of the canvas
+func (c *canvas) move(dx, dy int) {
+	c.cursor = c.clamp(models.Position{X: c.cursor.X + dx, Y: c.cursor.Y + dy})
+}
+
+// moveTo moves the cursor to the column of its line
+func (c *canvas) moveTo(x int) {
+	c.cursor = c.clamp(models.Position{X: x, Y: c.cursor.Y})
+}
+
+// toggleWall paints a wall under the cursor, or clears the wall that is there
+func (c *canvas) toggleWall() {
+	if c.at(c.cursor) == wall {
+		c.replace(' ')
+		return
+	}
+	c.replace(wall)
+}
+
+// replace sets the character under the cursor
+func (c *canvas) replace(r rune) {
+	c.lines[c.cursor.Y][c.cursor.X] = r
+}
+
+// at returns the character at the position
+func (c *canvas) at(pos models.Position) rune {
+	return c.lines[pos.Y][pos.X]
+}
+
+// addTarget adds a target under the cursor after the other targets and reports whether it was added,
+// there can only be one target at a position
+func (c *canvas) addTarget() bool {
+	if c.targetAt(c.cursor) >= 0 {
+		return false
+	}
+	c.targets = append(c.targets, models.TargetDefinition{Position: c.cursor})
+	return true
+}
+
+// removeTarget removes the target under the cursor and reports whether there was one,
+// the targets after it move up in the order
+func (c *canvas) removeTarget() bool {
+	i := c.targetAt(c.cursor)
+	if i < 0 {
+		return false
+	}
+	c.targets = slices.Delete(c.targets, i, i+1)
+	return true
+}
+
+// targetAt returns the index of the target at the position, or -1 when there is none
+func (c *canvas) targetAt(pos models.Position) int {
+	return slices.IndexFunc(c.targets, func(target models.TargetDefinition) bool { return target.Position == pos })
+}
+
+// setStart makes the position under the cursor the start of the level
+func (c *canvas) setStart() {
+	c.start = c.cursor
+}
+
+// text returns the lines of the canvas
+func (c *canvas) text() []string {
+	text := make([]string, len(c.lines))
+	for y, line := range c.lines {
+		text[y] = string(line)
+	}
+	return text
+}
+
+// render returns the canvas the way it is shown, with the first target active and the others
+// inactive, the start drawn as the cursor of the player and walls drawn as walls
+func (c *canvas) render(chars *models.Characters) [][]rune {
+	grid := make([][]rune, len(c.lines))
+	for y, line := range c.lines {
+		grid[y] = slices.Clone(line)
+		for x, r := range line {
+			if r == wall {
+				grid[y][x] = chars.Wall.Rune
+			}
+		}
+	}
+	for i, target := range c.targets {
+		r := chars.Target.Inactive.Rune
+		if i == 0 {
+			r = chars.Target.Active.Rune
+		}
+		grid[target.Position.Y][target.Position.X] = r
+	}
+	grid[c.start.Y][c.start.X] = chars.Player.Cursor.Rune
+	return grid
+}
+
+// contains reports whether the position is on the canvas
+func (c *canvas) contains(pos models.Position) bool {
+	width, height := c.size()
+	return pos.X >= 0 && pos.X < width && pos.Y >= 0 && pos.Y < height
+}
+
+// clamp returns the position on the canvas that is closest to pos
+func (c *canvas) clamp(pos models.Position) models.Position {
+	width, height := c.size()
+	return models.Position{X: min(max(pos.X, 0), width-1), Y: min(max(pos.Y, 0), height-1)}
+}
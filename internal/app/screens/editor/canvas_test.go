@@ -0,0 +1,106 @@
+package editor
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+func Test_NewCanvas(t *testing.T) {
+	c := newCanvas(5, 3)
+	want := []string{"#####", "#   #", "#####"}
+	if got := c.text(); !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if c.start != (models.Position{X: 1, Y: 1}) || c.cursor != c.start {
+		t.Errorf("expected the start and the cursor at [1, 1], got %v and %v", c.start, c.cursor)
+	}
+}
+
+func Test_CanvasTargets(t *testing.T) {
+	c := newCanvas(6, 3)
+	for _, x := range []int{1, 3, 4} {
+		c.moveTo(x)
+		if !c.addTarget() {
+			t.Fatalf("expected a target to be added at %d", x)
+		}
+	}
+	if c.addTarget() {
+		t.Errorf("expected no second target at the same position")
+	}
+
+	c.moveTo(3)
+	if !c.removeTarget() || c.removeTarget() {
+		t.Fatalf("expected the target at 3 to be removed once")
+	}
+	want := []models.Position{{X: 1, Y: 1}, {X: 4, Y: 1}}
+	for i, target := range c.targets {
+		if target.Position != want[i] {
+			t.Errorf("expected target %d at %v, got %v", i+1, want[i], target.Position)
+		}
+	}
+	if i := c.targetAt(models.Position{X: 4, Y: 1}); i != 1 {
+		t.Errorf("expected the target at [4, 1] to be the second, got %d", i)
+	}
+}
+
+func Test_CanvasResize(t *testing.T) {
+	tests := []struct {
+		name        string
+		width       int
+		height      int
+		wantText    []string
+		wantTargets int
+		wantCursor  models.Position
+	}{
+		{name: "wider", width: 6, height: 3, wantText: []string{"##### ", "#   # ", "##### "}, wantTargets: 2, wantCursor: models.Position{X: 3, Y: 1}},
+		{name: "narrower", width: 3, height: 3, wantText: []string{"###", "#  ", "###"}, wantTargets: 1, wantCursor: models.Position{X: 2, Y: 1}},
+		{name: "shorter", width: 5, height: 1, wantText: []string{"#####"}, wantTargets: 0, wantCursor: models.Position{X: 3}},
+		{name: "limits", width: 0, height: maxCanvasHeight + 1, wantTargets: 0, wantCursor: models.Position{Y: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCanvas(5, 3)
+			c.addTarget()
+			c.moveTo(3)
+			c.addTarget()
+
+			c.resize(tt.width, tt.height)
+			if tt.wantText != nil && !slices.Equal(c.text(), tt.wantText) {
+				t.Errorf("expected %q, got %q", tt.wantText, c.text())
+			}
+			if width, height := c.size(); width < 1 || height > maxCanvasHeight {
+				t.Errorf("expected the size to stay within the limits, got %dx%d", width, height)
+			}
+			if len(c.targets) != tt.wantTargets {
+				t.Errorf("expected %d targets, got %d", tt.wantTargets, len(c.targets))
+			}
+			if c.cursor != tt.wantCursor || !c.contains(c.start) {
+				t.Errorf("expected the cursor at %v and the start on the canvas, got %v and %v", tt.wantCursor, c.cursor, c.start)
+			}
+		})
+	}
+}
+
+func Test_CanvasRender(t *testing.T) {
+	chars := &models.DefaultCharacters
+	c := newCanvas(6, 3)
+	c.moveTo(2)
+	c.addTarget()
+	c.moveTo(3)
+	c.addTarget()
+	c.moveTo(4)
+	c.replace('a')
+
+	grid := c.render(chars)
+	want := string([]rune{chars.Wall.Rune, chars.Player.Cursor.Rune, chars.Target.Active.Rune,
+		chars.Target.Inactive.Rune, 'a', chars.Wall.Rune})
+	if got := string(grid[1]); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if c.text()[1] != "#   a#" {
+		t.Errorf("expected the text to keep the characters under the targets, got %q", c.text()[1])
+	}
+}
@@ -0,0 +1,106 @@
+package editor
+
+import "github.com/charmbracelet/bubbles/key"
+
+// Controls represents the controls of the level editor
+type Controls struct {
+	MoveLeft    key.Binding
+	MoveRight   key.Binding
+	MoveUp      key.Binding
+	MoveDown    key.Binding
+	StartOfLine key.Binding
+	EndOfLine   key.Binding
+	Wall        key.Binding
+	Replace     key.Binding
+	Target      key.Binding
+	Delete      key.Binding
+	Start       key.Binding
+	Wider       key.Binding
+	Narrower    key.Binding
+	Taller      key.Binding
+	Shorter     key.Binding
+	Playtest    key.Binding
+	Command     key.Binding
+	Escape      key.Binding
+	Quit        key.Binding
+}
+
+// NewControls creates a new Controls instance with predefined key bindings
+func NewControls() Controls {
+	return Controls{
+		MoveLeft: key.NewBinding(
+			key.WithKeys("h", "left"),
+			key.WithHelp("h", "move left")),
+		MoveRight: key.NewBinding(
+			key.WithKeys("l", "right"),
+			key.WithHelp("l", "move right")),
+		MoveUp: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k", "move up")),
+		MoveDown: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j", "move down")),
+		StartOfLine: key.NewBinding(
+			key.WithKeys("0"),
+			key.WithHelp("0", "start of line")),
+		EndOfLine: key.NewBinding(
+			key.WithKeys("$"),
+			key.WithHelp("$", "end of line")),
+		Wall: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "paint wall")),
+		Replace: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r{char}", "replace char")),
+		Target: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "add target")),
+		Delete: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "remove target")),
+		Start: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "set start")),
+		Wider: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">/<", "wider/narrower")),
+		Narrower: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "narrower")),
+		Taller: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+/-", "taller/shorter")),
+		Shorter: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "shorter")),
+		Playtest: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "playtest")),
+		Command: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "command line")),
+		Escape: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "leave")),
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", "quit")),
+	}
+}
+
+// Help returns a slice of key bindings for displaying the controls of the editor,
+// the keys that move the cursor are left out as they are the same as in the levels
+func (c Controls) Help() []key.Binding {
+	return []key.Binding{
+		c.Wall,
+		c.Replace,
+		c.Target,
+		c.Delete,
+		c.Start,
+		c.Wider,
+		c.Taller,
+		c.Playtest,
+		c.Command,
+		c.Escape,
+	}
+}
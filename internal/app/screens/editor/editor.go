@@ -0,0 +1,412 @@
+package editor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dasvh/go-learn-vim/internal/app/controllers"
+	"github.com/dasvh/go-learn-vim/internal/app/screens/adventure"
+	"github.com/dasvh/go-learn-vim/internal/app/screens/adventure/level"
+	"github.com/dasvh/go-learn-vim/internal/components"
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/storage"
+	"github.com/dasvh/go-learn-vim/internal/views"
+)
+
+const (
+	editorMode = "Editor"
+	// PackName is the name of the pack the levels of the editor are written to
+	PackName = "Custom"
+	// the size of a new canvas
+	canvasWidth  = 24
+	canvasHeight = 8
+	// the first number a new level can have, far from the numbers of the levels of the game
+	firstNumber = 1000
+)
+
+// Editor represents the level editor screen, a level is drawn on a canvas with Vim keys,
+// played in place and written to a level file in the pack directory
+type Editor struct {
+	controls Controls
+	canvas   *canvas
+	def      models.LevelDefinition // the number, description, lesson and allowed motions of the level
+	command  components.CommandLine
+	replace  bool // r was typed, the next key is the character that replaces the one under the cursor
+	unsaved  bool // the level changed since it was last written
+	playtest *adventure.Adventure
+	lc       *controllers.Level // the loaded levels, whose numbers cannot be used
+	view     views.AdventureView
+	dir      string
+	chars    *models.Characters
+}
+
+// NewLevelEditor creates a new Editor that writes levels to the pack in the directory,
+// the level gets the first number from firstNumber on that no loaded level has
+func NewLevelEditor(dir string, lc *controllers.Level) *Editor {
+	controls := NewControls()
+	view := views.InitializeAdventureView()
+	view.SetMode(editorMode)
+	view.Help = controls.Help()
+	e := &Editor{
+		controls: controls,
+		canvas:   newCanvas(canvasWidth, canvasHeight),
+		def: models.LevelDefinition{
+			Number:      firstNumber,
+			Description: "Custom level",
+			Lesson:      "hjkl",
+			Allowed:     models.BasicMotions,
+		},
+		lc:    lc,
+		view:  view,
+		dir:   dir,
+		chars: &models.DefaultCharacters,
+	}
+	for e.inUse(e.def.Number) {
+		e.def.Number++
+	}
+	e.view.SetInfo("Paint walls, add targets in order and set the start, " +
+		"then name the level with :number, :description, :lesson and :allowed and write it with :w")
+	e.syncView()
+	return e
+}
+
+// definition returns the models.LevelDefinition of the level that is drawn
+func (e *Editor) definition() models.LevelDefinition {
+	def := e.def
+	def.Text = e.canvas.text()
+	def.Wall = wall
+	def.Start = e.canvas.start
+	def.Targets = append([]models.TargetDefinition(nil), e.canvas.targets...)
+	return def
+}
+
+func (e *Editor) Init() tea.Cmd { return nil }
+
+func (e *Editor) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if e.playtest != nil {
+		return e.updatePlaytest(msg)
+	}
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		e.view.Size = msg
+	case tea.KeyMsg:
+		return e, e.updateKey(msg)
+	}
+	return e, nil
+}
+
+// updatePlaytest passes the message to the level that is played, until it is left
+func (e *Editor) updatePlaytest(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case models.PlaytestDoneMsg:
+		e.playtest = nil
+		e.view.SetInfo(e.playtestResult(msg))
+		return e, nil
+	case tea.WindowSizeMsg:
+		e.view.Size = msg
+	}
+	_, cmd := e.playtest.Update(msg)
+	return e, cmd
+}
+
+// playtestResult describes how the playtest ended, a completed level is compared with its par
+func (e *Editor) playtestResult(msg models.PlaytestDoneMsg) string {
+	if !msg.Completed {
+		return "Playtest left"
+	}
+	par := 0
+	for _, target := range e.canvas.targets {
+		par += target.Par
+	}
+	if par == 0 {
+		return fmt.Sprintf("Playtest completed in %d keystrokes", msg.Keystrokes)
+	}
+	return fmt.Sprintf("Playtest completed in %d keystrokes, the par of the level is %d", msg.Keystrokes, par)
+}
+
+// updateKey handles a key message on the canvas or on the command line
+func (e *Editor) updateKey(msg tea.KeyMsg) tea.Cmd {
+	if e.command.Active() {
+		state := e.command.Feed(msg)
+		e.view.SetCommandLine(e.command.String())
+		if state == components.CommandSubmitted {
+			e.view.SetCommandLine("")
+			return e.execute(e.command.Value())
+		}
+		if state == components.CommandCancelled {
+			e.view.SetCommandLine("")
+		}
+		return nil
+	}
+	if e.replace {
+		e.replace = false
+		e.view.SetPending("")
+		if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
+			e.canvas.replace(msg.Runes[0])
+			e.changed()
+		}
+		return nil
+	}
+
+	switch {
+	case key.Matches(msg, e.controls.MoveLeft):
+		e.canvas.move(-1, 0)
+	case key.Matches(msg, e.controls.MoveRight):
+		e.canvas.move(1, 0)
+	case key.Matches(msg, e.controls.MoveUp):
+		e.canvas.move(0, -1)
+	case key.Matches(msg, e.controls.MoveDown):
+		e.canvas.move(0, 1)
+	case key.Matches(msg, e.controls.StartOfLine):
+		e.canvas.moveTo(0)
+	case key.Matches(msg, e.controls.EndOfLine):
+		e.canvas.moveTo(maxCanvasWidth)
+	case key.Matches(msg, e.controls.Wall):
+		e.canvas.toggleWall()
+		e.changed()
+	case key.Matches(msg, e.controls.Replace):
+		e.replace = true
+		e.view.SetPending("r")
+	case key.Matches(msg, e.controls.Target):
+		if e.canvas.addTarget() {
+			e.changed()
+		}
+	case key.Matches(msg, e.controls.Delete):
+		if e.canvas.removeTarget() {
+			e.changed()
+		}
+	case key.Matches(msg, e.controls.Start):
+		e.canvas.setStart()
+		e.changed()
+	case key.Matches(msg, e.controls.Wider), key.Matches(msg, e.controls.Narrower),
+		key.Matches(msg, e.controls.Taller), key.Matches(msg, e.controls.Shorter):
+		e.resize(msg)
+	case key.Matches(msg, e.controls.Playtest):
+		return e.startPlaytest()
+	case key.Matches(msg, e.controls.Command):
+		e.command.Open(":")
+		e.view.SetCommandLine(e.command.String())
+	case key.Matches(msg, e.controls.Escape):
+		return e.leave(false)
+	case key.Matches(msg, e.controls.Quit):
+		return tea.Quit
+	}
+	e.syncView()
+	return nil
+}
+
+// resize grows or shrinks the canvas by one column or line
+func (e *Editor) resize(msg tea.KeyMsg) {
+	width, height := e.canvas.size()
+	switch {
+	case key.Matches(msg, e.controls.Wider):
+		width++
+	case key.Matches(msg, e.controls.Narrower):
+		width--
+	case key.Matches(msg, e.controls.Taller):
+		height++
+	case key.Matches(msg, e.controls.Shorter):
+		height--
+	}
+	e.canvas.resize(width, height)
+	e.changed()
+}
+
+// startPlaytest plays the level that is drawn, when it can be played
+func (e *Editor) startPlaytest() tea.Cmd {
+	def := e.definition()
+	if err := storage.ValidateLevel(def); err != nil {
+		e.view.SetInfo(fmt.Sprintf("The level cannot be played: %v", err))
+		return nil
+	}
+	e.playtest = adventure.NewPlaytest(level.NewDefinedLevel(def), e.view.Size)
+	return e.playtest.Init()
+}
+
+// execute runs a command typed on the command line, like in Vim a ! after the name forces the command
+func (e *Editor) execute(text string) tea.Cmd {
+	name, arg, _ := strings.Cut(strings.TrimSpace(text), " ")
+	arg = strings.TrimSpace(arg)
+	bang := strings.HasSuffix(name, "!")
+	name = strings.TrimSuffix(name, "!")
+
+	defer e.syncView()
+	switch name {
+	case "":
+	case "w", "write":
+		e.write()
+	case "q", "quit":
+		return e.leave(bang)
+	case "wq", "x", "xit":
+		if e.write() {
+			return e.leave(false)
+		}
+	case "number":
+		e.setNumber(arg)
+	case "description":
+		e.setText(&e.def.Description, arg)
+	case "lesson":
+		e.setText(&e.def.Lesson, arg)
+	case "allowed":
+		e.setAllowed(arg)
+	case "par":
+		e.setPar(arg)
+	case "hint":
+		e.setHint(arg)
+	default:
+		e.view.SetInfo(fmt.Sprintf("E492: Not an editor command: %s", text))
+	}
+	return nil
+}
+
+// write writes the level to its file in the pack directory and reports whether it was written
+func (e *Editor) write() bool {
+	def := e.definition()
+	if err := storage.ValidateLevel(def); err != nil {
+		e.view.SetInfo(fmt.Sprintf("The level cannot be written: %v", err))
+		return false
+	}
+	if e.dir == "" {
+		e.view.SetInfo("The level cannot be written: there is no directory for level packs")
+		return false
+	}
+	if e.inUse(def.Number) {
+		e.view.SetInfo(fmt.Sprintf("The level cannot be written: %s", inUseMessage(def.Number)))
+		return false
+	}
+	path, err := storage.WriteLevelFile(e.dir, PackName, def)
+	if err != nil {
+		e.view.SetInfo(fmt.Sprintf("The level cannot be written: %v", err))
+		return false
+	}
+	e.unsaved = false
+	e.view.SetInfo(fmt.Sprintf("Level %d written to %s, it is loaded the next time the game starts", def.Number, path))
+	return true
+}
+
+// leave goes back to the main menu, unless the level changed since it was last written
+// and leaving was not forced
+func (e *Editor) leave(force bool) tea.Cmd {
+	if e.unsaved && !force {
+		e.view.SetInfo("E37: No write since last change (add ! to override)")
+		return nil
+	}
+	return models.ChangeScreen(models.MainMenuScreen)
+}
+
+// setNumber sets the number of the level
+func (e *Editor) setNumber(arg string) {
+	number, err := strconv.Atoi(arg)
+	if err != nil || number < 0 {
+		e.view.SetInfo(fmt.Sprintf("E521: Number required: %s", arg))
+		return
+	}
+	if e.inUse(number) {
+		e.view.SetInfo(inUseMessage(number))
+		return
+	}
+	e.def.Number = number
+	e.changed()
+}
+
+// inUse reports whether a loaded level has the number, a pack with a level on the number
+// of another level is left out when the game starts so the number cannot be written
+func (e *Editor) inUse(number int) bool {
+	_, exists := e.lc.GetLevels()[number]
+	return exists
+}
+
+// inUseMessage tells that the number is used by another level
+func inUseMessage(number int) string {
+	return fmt.Sprintf("level %d is already in use, choose a number no other level has", number)
+}
+
+// setText sets a text of the level that cannot be empty
+func (e *Editor) setText(text *string, arg string) {
+	if arg == "" {
+		e.view.SetInfo("E471: Argument required")
+		return
+	}
+	*text = arg
+	e.changed()
+}
+
+// setAllowed sets the motions that can be used in the level, they are named the way they are in level files
+func (e *Editor) setAllowed(arg string) {
+	var allowed []models.MotionKind
+	for _, name := range strings.Fields(arg) {
+		kinds, ok := models.ParseMotions(name)
+		if !ok {
+			e.view.SetInfo(fmt.Sprintf("E475: Invalid argument: %s", name))
+			return
+		}
+		allowed = append(allowed, kinds...)
+	}
+	if len(allowed) == 0 {
+		e.view.SetInfo("E471: Argument required")
+		return
+	}
+	e.def.Allowed = allowed
+	e.changed()
+}
+
+// setPar sets the par of the target under the cursor
+func (e *Editor) setPar(arg string) {
+	i := e.canvas.targetAt(e.canvas.cursor)
+	par, err := strconv.Atoi(arg)
+	switch {
+	case i < 0:
+		e.view.SetInfo("There is no target under the cursor")
+	case err != nil || par < 0:
+		e.view.SetInfo(fmt.Sprintf("E521: Number required: %s", arg))
+	default:
+		e.canvas.targets[i].Par = par
+		e.changed()
+	}
+}
+
+// setHint sets the hint of the target under the cursor, an empty hint removes it
+func (e *Editor) setHint(arg string) {
+	i := e.canvas.targetAt(e.canvas.cursor)
+	if i < 0 {
+		e.view.SetInfo("There is no target under the cursor")
+		return
+	}
+	e.canvas.targets[i].Hint = arg
+	e.changed()
+}
+
+// changed marks the level as changed since it was last written
+func (e *Editor) changed() {
+	e.unsaved = true
+}
+
+// syncView shows the level, the size of the canvas and the position of the cursor in the top bar
+func (e *Editor) syncView() {
+	width, height := e.canvas.size()
+	e.view.SetLevel(e.def.Number)
+	e.view.Player.SetText("%s", e.def.Description)
+	status := fmt.Sprintf("%dx%d  %d,%d", width, height, e.canvas.cursor.X, e.canvas.cursor.Y)
+	if i := e.canvas.targetAt(e.canvas.cursor); i >= 0 {
+		status += fmt.Sprintf("  target %d", i+1)
+	}
+	e.view.Stats.SetText("%s", status)
+}
+
+func (e *Editor) View() string {
+	if e.playtest != nil {
+		return e.playtest.View()
+	}
+	grid := e.canvas.render(e.chars)
+	overlays := make([][]models.Overlay, len(grid))
+	for y := range grid {
+		overlays[y] = make([]models.Overlay, len(grid[y]))
+	}
+	overlays[e.canvas.cursor.Y][e.canvas.cursor.X] = models.OverlaySelection
+	e.view.GameMap.Field = grid
+	e.view.GameMap.Overlays = overlays
+	return e.view.RenderScreen()
+}
@@ -0,0 +1,138 @@
+package editor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dasvh/go-learn-vim/internal/app/controllers"
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/storage"
+)
+
+// typeKeys feeds the keys to the editor one after another and returns the command of the last key,
+// enter is typed as a line break
+func typeKeys(e *Editor, keys string) tea.Cmd {
+	var cmd tea.Cmd
+	for _, r := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+		switch r {
+		case '\n':
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case ' ':
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}}
+		}
+		_, cmd = e.Update(msg)
+	}
+	return cmd
+}
+
+func Test_EditorDraw(t *testing.T) {
+	e := NewLevelEditor(t.TempDir(), controllers.NewLevel())
+	e.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+
+	typeKeys(e, "lll tlllltjrb")
+	def := e.definition()
+	if def.Text[1][4] != '#' || def.Text[2][8] != 'b' {
+		t.Errorf("expected a wall at [4, 1] and b at [8, 2], got %q", def.Text)
+	}
+	if len(def.Targets) != 2 || def.Targets[0].Position != (models.Position{X: 4, Y: 1}) ||
+		def.Targets[1].Position != (models.Position{X: 8, Y: 1}) {
+		t.Errorf("expected targets at [4, 1] and [8, 1], got %+v", def.Targets)
+	}
+
+	typeKeys(e, "ks")
+	if e.canvas.start != (models.Position{X: 8, Y: 1}) {
+		t.Errorf("expected the start at [8, 1], got %v", e.canvas.start)
+	}
+	typeKeys(e, "x>>-")
+	if width, height := e.canvas.size(); width != canvasWidth+2 || height != canvasHeight-1 || len(e.canvas.targets) != 1 {
+		t.Errorf("expected a %dx%d canvas with one target, got %dx%d with %d", canvasWidth+2, canvasHeight-1, width, height, len(e.canvas.targets))
+	}
+}
+
+func Test_EditorCommands(t *testing.T) {
+	dir := t.TempDir()
+	e := NewLevelEditor(filepath.Join(dir, "custom"), controllers.NewLevel())
+	e.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+
+	if cmd := typeKeys(e, ":w\n"); cmd != nil || !strings.Contains(e.view.Info.Text, "a level needs at least one target") {
+		t.Fatalf("expected :w to report the missing target, got %q", e.view.Info.Text)
+	}
+
+	typeKeys(e, "lllt:par 3\n:hint Three steps right\n:number 1200\n:description Step right\n:lesson l\n:allowed basic w\n")
+	def := e.definition()
+	if def.Number != 1200 || def.Description != "Step right" || def.Lesson != "l" || len(def.Allowed) != len(models.BasicMotions)+1 {
+		t.Errorf("expected the commands to name the level, got %+v", def)
+	}
+	if def.Targets[0].Par != 3 || def.Targets[0].Hint != "Three steps right" {
+		t.Errorf("expected par 3 and a hint on the target, got %+v", def.Targets[0])
+	}
+
+	typeKeys(e, ":number x\n")
+	if !strings.HasPrefix(e.view.Info.Text, "E521") || e.def.Number != 1200 {
+		t.Errorf("expected an invalid number to be refused, got %q", e.view.Info.Text)
+	}
+	if typeKeys(e, ":q\n") != nil || !strings.HasPrefix(e.view.Info.Text, "E37") {
+		t.Errorf("expected :q to stay in a level that was not written, got %q", e.view.Info.Text)
+	}
+
+	cmd := typeKeys(e, ":wq\n")
+	if cmd == nil || cmd() != models.MainMenuScreen {
+		t.Fatalf("expected :wq to leave the editor, got %q", e.view.Info.Text)
+	}
+	packs, errs := storage.LoadLevelPacks(os.DirFS(dir))
+	if len(errs) != 0 || len(packs) != 1 || packs[0].Name != PackName || packs[0].Levels[0].Number != 1200 {
+		t.Errorf("expected the written level in the pack %s, got %+v, %v", PackName, packs, errs)
+	}
+}
+
+func Test_EditorNumberInUse(t *testing.T) {
+	lc := controllers.NewLevel()
+	if err := lc.Define(models.LevelDefinition{Number: firstNumber, Description: "Loaded"}); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	e := NewLevelEditor(dir, lc)
+	e.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	if e.def.Number != firstNumber+1 {
+		t.Errorf("expected a new level to get the first free number %d, got %d", firstNumber+1, e.def.Number)
+	}
+
+	typeKeys(e, "lllt:number 3\n")
+	if !strings.Contains(e.view.Info.Text, "level 3 is already in use") || e.def.Number != firstNumber+1 {
+		t.Errorf("expected the number of a built-in level to be refused, got %q", e.view.Info.Text)
+	}
+
+	// a level loaded after the number was set cannot be written over either
+	if err := lc.Define(models.LevelDefinition{Number: firstNumber + 1, Description: "Loaded later"}); err != nil {
+		t.Fatal(err)
+	}
+	if typeKeys(e, ":w\n"); !strings.Contains(e.view.Info.Text, "cannot be written: level 1001 is already in use") {
+		t.Errorf("expected :w to refuse a number in use, got %q", e.view.Info.Text)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected no level to be written, got %d entries", len(entries))
+	}
+}
+
+func Test_EditorPlaytest(t *testing.T) {
+	e := NewLevelEditor("", controllers.NewLevel())
+	e.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	typeKeys(e, "lllt:par 1\n")
+
+	if typeKeys(e, "p"); e.playtest == nil {
+		t.Fatalf("expected p to play the level, got %q", e.view.Info.Text)
+	}
+	// a count of 3 and l reach the target
+	cmd := typeKeys(e, "3l")
+	if cmd == nil {
+		t.Fatalf("expected the playtest to be completed")
+	}
+	e.Update(cmd())
+	if e.playtest != nil || e.view.Info.Text != "Playtest completed in 2 keystrokes, the par of the level is 1" {
+		t.Errorf("expected the editor to show the result of the playtest, got %q", e.view.Info.Text)
+	}
+}
@@ -11,25 +11,32 @@ import (
 // VimInfo represents the screen model for vim information screens
 type VimInfo struct {
 	*views.ContentView
+	back models.Screen
 }
 
-// newScreenModel creates a new VimInfo screen model
-func newScreenModel(title string, sections []models.Section, extraContent string) *VimInfo {
+// newScreenModel creates a new VimInfo screen model that goes back to the back screen
+func newScreenModel(title string, sections []models.Section, extraContent string, back models.Screen) *VimInfo {
 	display := views.NewContentView(title)
 	content := lipgloss.JoinVertical(lipgloss.Center,
 		display.RenderSections(sections), extraContent)
 	display.SetContent(content)
-	return &VimInfo{ContentView: display}
+	return &VimInfo{ContentView: display, back: back}
 }
 
 // NewVimInfo creates a new VimInfo screen model for the Vim Information screen
 func NewVimInfo() *VimInfo {
-	return newScreenModel("Vim Information", models.VimInfoSections, models.BramTribute)
+	return newScreenModel("Vim Information", models.VimInfoSections, models.BramTribute, models.InfoMenuScreen)
 }
 
 // NewVimCheatsheet creates a new VimInfo screen model for the Vim Cheatsheet
 func NewVimCheatsheet() *VimInfo {
-	return newScreenModel("Vim Cheatsheet", models.CheatsheetSection, "")
+	return newScreenModel("Vim Cheatsheet", models.CheatsheetSection, "", models.InfoMenuScreen)
+}
+
+// NewVimHelp creates a new VimInfo screen model for the help opened with :help,
+// it shows the given sections of the cheatsheet and goes back to the back screen
+func NewVimHelp(sections []models.Section, back models.Screen) *VimInfo {
+	return newScreenModel("Vim Help", sections, "", back)
 }
 
 // Update handles messages and updates the VimInfo screen model accordingly
@@ -39,7 +46,7 @@ func (vi *VimInfo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if key.Matches(msg, vi.Controls().Back) {
-			return vi, models.ChangeScreen(models.InfoMenuScreen)
+			return vi, models.ChangeScreen(vi.back)
 		}
 	}
 
@@ -28,6 +28,10 @@ func NewStatsScreen(repo storage.GameRepository) *StatsScreen {
 		{Title: "Player", Width: models.PlayerNameMaxLength},
 		{Title: "Games Played", Width: 12},
 		{Title: "Keystrokes", Width: 12},
+		{Title: "Command Keys", Width: 12},
+		{Title: "Insert Keys", Width: 12},
+		{Title: "Repeat Keys", Width: 12},
+		{Title: "Hints", Width: 6},
 		{Title: "Playtime (s)", Width: 12},
 		{Title: "Key Presses", Width: 30},
 	})
@@ -96,6 +100,10 @@ func (sv *StatsScreen) populateTable() {
 			"Global",
 			strconv.Itoa(sv.lifetimeStats.TotalGames),
 			strconv.Itoa(sv.lifetimeStats.TotalKeystrokes),
+			strconv.Itoa(sv.lifetimeStats.CommandKeystrokes),
+			strconv.Itoa(sv.lifetimeStats.InsertKeystrokes),
+			strconv.Itoa(sv.lifetimeStats.RepeatKeystrokes),
+			strconv.Itoa(sv.lifetimeStats.Hints),
 			strconv.Itoa(sv.lifetimeStats.TotalPlaytime),
 			formatKeyPresses(sv.lifetimeStats.KeyPresses),
 		},
@@ -106,6 +114,10 @@ func (sv *StatsScreen) populateTable() {
 				player,
 				strconv.Itoa(stats.TotalGames),
 				strconv.Itoa(stats.TotalKeystrokes),
+				strconv.Itoa(stats.CommandKeystrokes),
+				strconv.Itoa(stats.InsertKeystrokes),
+				strconv.Itoa(stats.RepeatKeystrokes),
+				strconv.Itoa(stats.Hints),
 				strconv.Itoa(stats.TotalPlaytime),
 				formatKeyPresses(stats.KeyPresses),
 			})
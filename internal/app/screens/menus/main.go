@@ -11,6 +11,7 @@ const (
 	ButtonInfo   = "Info"
 	ButtonLoad   = "Load Game"
 	ButtonNew    = "New Game"
+	ButtonEditor = "Level Editor"
 	ButtonScores = "Scores"
 	ButtonStats  = "Stats"
 	ButtonQuit   = "Quit"
@@ -27,6 +28,7 @@ func NewMainMenu(canLoadGame bool) views.Menu {
 		{Label: ButtonInfo},
 		{Label: ButtonLoad, Inactive: !canLoadGame},
 		{Label: ButtonNew},
+		{Label: ButtonEditor},
 		{Label: ButtonScores},
 		{Label: ButtonStats},
 		{Label: ButtonQuit},
@@ -66,6 +68,8 @@ func (m *Main) HandleSelection() tea.Cmd {
 		return models.ChangeScreen(models.LoadSaveSelectionScreen)
 	case ButtonNew:
 		return models.ChangeScreen(models.PlayerSelectionScreen)
+	case ButtonEditor:
+		return models.ChangeScreen(models.LevelEditorScreen)
 	case ButtonScores:
 		return models.ChangeScreen(models.ScoresScreen)
 	case ButtonStats:
@@ -8,7 +8,9 @@ import (
 	"github.com/dasvh/go-learn-vim/internal/models"
 	"github.com/dasvh/go-learn-vim/internal/style"
 	"github.com/dasvh/go-learn-vim/internal/views"
+	"slices"
 	"strconv"
+	"strings"
 )
 
 // LevelSelection is a screen that allows the user to select a level
@@ -19,17 +21,40 @@ type LevelSelection struct {
 	items []cl.Item
 }
 
-// NewLevelSelection creates a new LevelSelection screen
+// noLevel is the number of the items that group the levels and that report problems, they cannot be played
+const noLevel = -1
+
+// NewLevelSelection creates a new LevelSelection screen, the levels are grouped by the pack they come from
+// after the levels of the game, and the packs that could not be loaded are reported at the end
 func NewLevelSelection(lc *controllers.Level) *LevelSelection {
 	levels := lc.GetLevels()
-	items := make([]cl.Item, len(levels))
-
-	for i, level := range levels {
-		n := level.Number()
-		items[i] = cl.Item{
-			Name:    "Level " + strconv.Itoa(n),
-			Details: level.Description(),
-			Number:  n,
+	inPack := make(map[int]bool)
+	for _, pack := range lc.GetPacks() {
+		for _, def := range pack.Levels {
+			inPack[def.Number] = true
+		}
+	}
+
+	items := []cl.Item{groupItem("Adventure", "the levels of the game")}
+	start := len(items)
+	for _, level := range levels {
+		if !inPack[level.Number()] {
+			items = append(items, levelItem(level))
+		}
+	}
+	slices.SortFunc(items[start:], func(a, b cl.Item) int { return a.Number - b.Number })
+
+	for _, pack := range lc.GetPacks() {
+		items = append(items, groupItem(pack.Name, packDetails(pack)))
+		for _, def := range pack.Levels {
+			items = append(items, levelItem(levels[def.Number]))
+		}
+	}
+
+	if problems := lc.GetProblems(); len(problems) > 0 {
+		items = append(items, groupItem("Invalid packs", "these packs could not be loaded"))
+		for _, err := range problems {
+			items = append(items, cl.Item{Name: "Invalid pack", Details: err.Error(), Number: noLevel})
 		}
 	}
 	return &LevelSelection{
@@ -38,6 +63,35 @@ func NewLevelSelection(lc *controllers.Level) *LevelSelection {
 	}
 }
 
+// levelItem returns the item of a level
+func levelItem(level models.Level) cl.Item {
+	return cl.Item{
+		Name:    "Level " + strconv.Itoa(level.Number()),
+		Details: level.Description(),
+		Number:  level.Number(),
+	}
+}
+
+// groupItem returns the item that starts a group of items
+func groupItem(name, details string) cl.Item {
+	return cl.Item{Name: "── " + name + " ──", Details: details, Number: noLevel}
+}
+
+// packDetails describes the author and version of the pack
+func packDetails(pack models.LevelPack) string {
+	var details []string
+	if pack.Author != "" {
+		details = append(details, "by "+pack.Author)
+	}
+	if pack.Version != "" {
+		details = append(details, "version "+pack.Version)
+	}
+	if len(details) == 0 {
+		return "a pack of levels"
+	}
+	return strings.Join(details, ", ")
+}
+
 // setSelectionView sets the view of the level selection screen
 func (ls *LevelSelection) setSelectionView() {
 
@@ -55,6 +109,8 @@ func (ls *LevelSelection) setSelectionView() {
 		cl.WithFilterMatchStyle(style.LevelSelection.FilterMatch),
 		cl.WithDimmedTitleStyle(style.LevelSelection.DimmedItem),
 	)
+	// the first item starts a group, the level after it is selected instead
+	levelList.Model.Select(1)
 
 	ls.view = views.NewSelectionView(
 		"Level Selection",
@@ -70,7 +126,8 @@ func (ls *LevelSelection) handleSelect(item cl.Item) tea.Cmd {
 	if lvl, ok := ls.lc.GetLevels()[item.Number]; ok {
 		ls.lc.SetLevel(lvl)
 	} else {
-		return nil // todo: return batch with an error msg
+		// groups and problems are not levels
+		return nil
 	}
 	return tea.Batch(
 		models.ChangeScreen(models.AdventureModeScreen),
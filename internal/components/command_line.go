@@ -0,0 +1,71 @@
+package components
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CommandLineState is the state of the CommandLine after it was fed a key
+type CommandLineState int
+
+const (
+	CommandTyping CommandLineState = iota
+	CommandSubmitted
+	CommandCancelled
+)
+
+// CommandLine represents the line at the bottom of the screen where a search pattern or an ex
+// command is typed, it opens with a prompt like /, ? or : and closes on enter or escape
+type CommandLine struct {
+	prompt string
+	input  []rune
+	active bool
+}
+
+// Open opens the CommandLine with the given prompt
+func (cl *CommandLine) Open(prompt string) {
+	cl.prompt = prompt
+	cl.input = nil
+	cl.active = true
+}
+
+// Active reports whether the CommandLine is open
+func (cl *CommandLine) Active() bool {
+	return cl.active
+}
+
+// Feed passes a key message to the CommandLine and returns its state afterward
+func (cl *CommandLine) Feed(msg tea.KeyMsg) CommandLineState {
+	switch msg.Type {
+	case tea.KeyEnter:
+		cl.active = false
+		return CommandSubmitted
+	case tea.KeyEsc, tea.KeyCtrlC:
+		cl.active = false
+		return CommandCancelled
+	case tea.KeyBackspace:
+		// like in Vim deleting past the start of the line closes it
+		if len(cl.input) == 0 {
+			cl.active = false
+			return CommandCancelled
+		}
+		cl.input = cl.input[:len(cl.input)-1]
+	case tea.KeyRunes, tea.KeySpace:
+		cl.input = append(cl.input, msg.Runes...)
+	}
+	return CommandTyping
+}
+
+// Prompt returns the prompt the CommandLine was opened with
+func (cl *CommandLine) Prompt() string {
+	return cl.prompt
+}
+
+// Value returns the text typed into the CommandLine
+func (cl *CommandLine) Value() string {
+	return string(cl.input)
+}
+
+// String returns the prompt followed by the typed text
+func (cl *CommandLine) String() string {
+	return cl.prompt + string(cl.input)
+}
@@ -0,0 +1,73 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func Test_CommandLine(t *testing.T) {
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	tests := []struct {
+		name       string
+		keys       []tea.KeyMsg
+		wantState  CommandLineState
+		wantValue  string
+		wantActive bool
+	}{
+		{
+			name:       "typing",
+			keys:       []tea.KeyMsg{runes("f"), runes("o")},
+			wantState:  CommandTyping,
+			wantValue:  "fo",
+			wantActive: true,
+		},
+		{
+			name:       "submit",
+			keys:       []tea.KeyMsg{runes("f"), {Type: tea.KeySpace, Runes: []rune(" ")}, runes("o"), {Type: tea.KeyEnter}},
+			wantState:  CommandSubmitted,
+			wantValue:  "f o",
+			wantActive: false,
+		},
+		{
+			name:       "backspace deletes",
+			keys:       []tea.KeyMsg{runes("f"), runes("o"), {Type: tea.KeyBackspace}},
+			wantState:  CommandTyping,
+			wantValue:  "f",
+			wantActive: true,
+		},
+		{
+			name:       "backspace on empty line cancels",
+			keys:       []tea.KeyMsg{{Type: tea.KeyBackspace}},
+			wantState:  CommandCancelled,
+			wantActive: false,
+		},
+		{
+			name:       "escape cancels",
+			keys:       []tea.KeyMsg{runes("q"), {Type: tea.KeyEsc}},
+			wantState:  CommandCancelled,
+			wantValue:  "q",
+			wantActive: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cl CommandLine
+			cl.Open("/")
+			var state CommandLineState
+			for _, msg := range tt.keys {
+				state = cl.Feed(msg)
+			}
+			if state != tt.wantState {
+				t.Errorf("expected state %d, got %d", tt.wantState, state)
+			}
+			if cl.Value() != tt.wantValue {
+				t.Errorf("expected value %q, got %q", tt.wantValue, cl.Value())
+			}
+			if cl.Active() != tt.wantActive {
+				t.Errorf("expected active to be %v, got %v", tt.wantActive, cl.Active())
+			}
+		})
+	}
+}
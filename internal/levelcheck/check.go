@@ -0,0 +1,236 @@
+package levelcheck
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dasvh/go-learn-vim/internal/app/screens/adventure/level"
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/solver"
+	"github.com/dasvh/go-learn-vim/internal/storage"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// Problem is a problem found in a level file or a pack manifest, a warning does not fail the check
+type Problem struct {
+	File    string
+	Line    int // zero when the problem is not on a line
+	Message string
+	Warning bool
+}
+
+// String returns the problem the way compilers report them, as file:line: message
+func (p Problem) String() string {
+	location := p.File
+	if p.Line > 0 {
+		location = fmt.Sprintf("%s:%d", p.File, p.Line)
+	}
+	if p.Warning {
+		return fmt.Sprintf("%s: warning: %s", location, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", location, p.Message)
+}
+
+// Result is a level that was checked with the fewest keystrokes each of its targets is reached in,
+// which is zero for a target that cannot be reached
+type Result struct {
+	File    string
+	Level   models.LevelDefinition
+	Minimum []int
+}
+
+// Report holds the levels that were checked and the problems that were found in them
+type Report struct {
+	Levels   []Result
+	Problems []Problem
+}
+
+// Failed reports whether a problem that is not a warning was found
+func (r *Report) Failed() bool {
+	return slices.ContainsFunc(r.Problems, func(p Problem) bool { return !p.Warning })
+}
+
+// Check checks the level files at the path, which is a level file, the directory of a pack or a directory
+// that holds packs. Every level has to be valid, every target has to be reachable from the start or the target
+// before it with the allowed motions within its par, and no two levels can have the same number
+// nor the number of a level built into the game
+func Check(root string) *Report {
+	r := &Report{}
+	info, err := os.Stat(root)
+	switch {
+	case err != nil:
+		r.add(Problem{File: root, Message: err.Error()})
+	case !info.IsDir():
+		data, err := os.ReadFile(root)
+		if err != nil {
+			r.add(Problem{File: root, Message: err.Error()})
+			break
+		}
+		r.checkLevelFile(root, data)
+	default:
+		r.checkDir(os.DirFS(root), root)
+	}
+	r.checkNumbers()
+	return r
+}
+
+// checkDir checks the pack in the directory, or every pack in its directories when it is not a pack
+func (r *Report) checkDir(fsys fs.FS, root string) {
+	if _, err := fs.Stat(fsys, storage.PackManifestFile); err == nil {
+		r.checkPack(fsys, ".", root)
+		return
+	}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		r.add(Problem{File: root, Message: err.Error()})
+		return
+	}
+	packs := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			if _, err := fs.Stat(fsys, path.Join(entry.Name(), storage.PackManifestFile)); err == nil {
+				r.checkPack(fsys, entry.Name(), filepath.Join(root, entry.Name()))
+				packs++
+			}
+		}
+	}
+	if packs == 0 {
+		r.add(Problem{File: root, Message: "no level pack was found"})
+	}
+}
+
+// checkPack checks the manifest and the level files of the pack in the directory of the file system,
+// the files are reported as files of dirName
+func (r *Report) checkPack(fsys fs.FS, dir, dirName string) {
+	_, files, err := storage.ReadPackManifest(fsys, dir)
+	if err != nil {
+		data, _ := fs.ReadFile(fsys, path.Join(dir, storage.PackManifestFile))
+		r.addError(filepath.Join(dirName, storage.PackManifestFile), data, err)
+		return
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, path.Join(dir, file))
+		if err != nil {
+			r.add(Problem{File: filepath.Join(dirName, file), Message: err.Error()})
+			continue
+		}
+		r.checkLevelFile(filepath.Join(dirName, file), data)
+	}
+}
+
+// checkLevelFile parses the level file and checks that its targets can be reached within their par
+func (r *Report) checkLevelFile(file string, data []byte) {
+	def, err := storage.ParseLevelFile(data)
+	if err != nil {
+		r.addError(file, data, err)
+		return
+	}
+
+	var unsupported []models.MotionKind
+	for _, kind := range def.Allowed {
+		if !solver.Supported(kind) {
+			unsupported = append(unsupported, kind)
+		}
+	}
+	// the targets might be reached with the motions the solver does not use, so its problems are only warnings
+	warning := len(unsupported) > 0
+	note := ""
+	if warning {
+		note = fmt.Sprintf(", the check does not use %s", strings.Join(models.MotionNames(unsupported), " "))
+	}
+
+	result := Result{File: file, Level: def, Minimum: make([]int, len(def.Targets))}
+	buf := levelBuffer(def)
+	from, fromName := def.Start, "the start"
+	for i, target := range def.Targets {
+		solution, ok := solver.Solve(buf, from, target.Position, def.Allowed)
+		switch {
+		case !ok:
+			r.add(Problem{
+				File:    file,
+				Line:    storage.KeyLine(data, storage.TargetKey(i, "position")),
+				Message: fmt.Sprintf("target %d cannot be reached from %s with the allowed motions%s", i+1, fromName, note),
+				Warning: warning,
+			})
+		case target.Par > 0 && solution.Keystrokes > target.Par:
+			r.add(Problem{
+				File: file,
+				Line: storage.KeyLine(data, storage.TargetKey(i, "par")),
+				Message: fmt.Sprintf("target %d takes at least %d keystrokes (%s), more than its par of %d%s",
+					i+1, solution.Keystrokes, solutionKeys(solution), target.Par, note),
+				Warning: warning,
+			})
+		}
+		if ok {
+			result.Minimum[i] = solution.Keystrokes
+		}
+		from, fromName = target.Position, fmt.Sprintf("target %d", i+1)
+	}
+	r.Levels = append(r.Levels, result)
+}
+
+// checkNumbers reports the levels that have the number of a level built into the game,
+// which the game does not load, or of a level that was checked before them
+func (r *Report) checkNumbers() {
+	builtIn := level.BuiltInNumbers()
+	files := make(map[int]string)
+	for _, result := range r.Levels {
+		number := result.Level.Number
+		message := ""
+		if slices.Contains(builtIn, number) {
+			message = fmt.Sprintf("level %d uses the number of a level built into the game", number)
+		} else if other, ok := files[number]; ok {
+			message = fmt.Sprintf("level %d is already defined in %s", number, other)
+		}
+		if message == "" {
+			files[number] = result.File
+			continue
+		}
+		data, _ := os.ReadFile(result.File)
+		r.add(Problem{File: result.File, Line: storage.KeyLine(data, "number"), Message: message})
+	}
+}
+
+// addError adds the error of parsing the data of the file as a problem on the line it is on
+func (r *Report) addError(file string, data []byte, err error) {
+	message := err.Error()
+	var levelErr *storage.LevelError
+	if errors.As(err, &levelErr) {
+		// the line is part of the location of the problem
+		message = levelErr.Message
+	}
+	r.add(Problem{File: file, Line: storage.ErrorLine(data, err), Message: message})
+}
+
+// add adds the problem to the report
+func (r *Report) add(problem Problem) {
+	r.Problems = append(r.Problems, problem)
+}
+
+// levelBuffer returns the text of the level as a buffer, the wall character of the level stops the cursor
+// the way it does when the level is played
+func levelBuffer(def models.LevelDefinition) *vim.Buffer {
+	buf := vim.NewBuffer(def.Text)
+	if def.Wall != 0 {
+		buf.SetWalls(func(pos models.Position) bool {
+			line := buf.Line(pos.Y)
+			return pos.X >= 0 && pos.X < len(line) && line[pos.X] == def.Wall
+		})
+	}
+	return buf
+}
+
+// solutionKeys returns the keys of the motions of the solution separated by spaces
+func solutionKeys(solution solver.Solution) string {
+	keys := make([]string, len(solution.Motions))
+	for i, motion := range solution.Motions {
+		keys[i] = motion.String()
+	}
+	return strings.Join(keys, " ")
+}
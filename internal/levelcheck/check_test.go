@@ -0,0 +1,181 @@
+package levelcheck
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// line is a level of a single line, the second target has a par of one keystroke
+const line = `number = 200
+description = "Along the line"
+lesson = "hjkl"
+allowed = ["basic"]
+text = '''
+abcdefghij
+'''
+
+[[targets]]
+position = [9, 0]
+par = 2
+
+[[targets]]
+position = [8, 0]
+par = 1
+`
+
+// writeFiles writes the files into a temporary directory and returns it
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func Test_Check(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        map[string]string
+		path         string
+		wantMinimum  [][]int
+		wantProblems []string
+		wantFailed   bool
+	}{
+		{
+			name:        "level file",
+			files:       map[string]string{"line.toml": line},
+			path:        "line.toml",
+			wantMinimum: [][]int{{2, 1}},
+		},
+		{
+			name:        "pack",
+			files:       map[string]string{"pack.toml": `name = "Lines"`, "line.toml": line},
+			wantMinimum: [][]int{{2, 1}},
+		},
+		{
+			name: "directory of packs",
+			files: map[string]string{
+				"a/pack.toml": `name = "A"`, "a/line.toml": line,
+				"b/pack.toml": `name = "B"`, "b/line.toml": strings.Replace(line, "200", "201", 1),
+			},
+			wantMinimum: [][]int{{2, 1}, {2, 1}},
+		},
+		{
+			name:         "invalid TOML",
+			files:        map[string]string{"line.toml": strings.Replace(line, "par = 2", "par =", 1)},
+			path:         "line.toml",
+			wantProblems: []string{"line.toml:11: incomplete number"},
+			wantFailed:   true,
+		},
+		{
+			name:         "invalid value",
+			files:        map[string]string{"line.toml": strings.Replace(line, "[8, 0]", "[8, 1]", 1)},
+			path:         "line.toml",
+			wantProblems: []string{"line.toml:14: target 2 at {8 1} is not on the text"},
+			wantFailed:   true,
+		},
+		{
+			name:         "invalid manifest",
+			files:        map[string]string{"pack.toml": `author = "me"`, "line.toml": line},
+			wantProblems: []string{"pack.toml: name is missing"},
+			wantFailed:   true,
+		},
+		{
+			name:         "over par",
+			files:        map[string]string{"line.toml": strings.Replace(line, "par = 2", "par = 1", 1)},
+			path:         "line.toml",
+			wantMinimum:  [][]int{{2, 1}},
+			wantProblems: []string{"line.toml:11: target 1 takes at least 2 keystrokes (9l), more than its par of 1"},
+			wantFailed:   true,
+		},
+		{
+			name:         "unreachable",
+			files:        map[string]string{"line.toml": strings.Replace(line, `["basic"]`, `["l"]`, 1)},
+			path:         "line.toml",
+			wantMinimum:  [][]int{{2, 0}},
+			wantProblems: []string{"line.toml:14: target 2 cannot be reached from target 1 with the allowed motions"},
+			wantFailed:   true,
+		},
+		{
+			name:        "unsupported motions",
+			files:       map[string]string{"line.toml": strings.Replace(line, `["basic"]`, `["l", "search"]`, 1)},
+			path:        "line.toml",
+			wantMinimum: [][]int{{2, 0}},
+			wantProblems: []string{"line.toml:14: warning: target 2 cannot be reached from target 1 " +
+				"with the allowed motions, the check does not use search"},
+		},
+		{
+			name: "same number",
+			files: map[string]string{
+				"a/pack.toml": `name = "A"`, "a/line.toml": line,
+				"b/pack.toml": `name = "B"`, "b/line.toml": line,
+			},
+			wantMinimum:  [][]int{{2, 1}, {2, 1}},
+			wantProblems: []string{"b/line.toml:1: level 200 is already defined in a/line.toml"},
+			wantFailed:   true,
+		},
+		{
+			name:         "number of a built-in level",
+			files:        map[string]string{"line.toml": "# a level on a built-in number\n" + strings.Replace(line, "number = 200", "number = 3", 1)},
+			path:         "line.toml",
+			wantMinimum:  [][]int{{2, 1}},
+			wantProblems: []string{"line.toml:2: level 3 uses the number of a level built into the game"},
+			wantFailed:   true,
+		},
+		{
+			name:         "no packs",
+			files:        map[string]string{"line.toml": line},
+			wantProblems: []string{": no level pack was found"},
+			wantFailed:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+			report := Check(filepath.Join(dir, tt.path))
+
+			var minimum [][]int
+			for _, result := range report.Levels {
+				minimum = append(minimum, result.Minimum)
+			}
+			if !reflect.DeepEqual(minimum, tt.wantMinimum) {
+				t.Errorf("expected minimum keystrokes %v, got %v", tt.wantMinimum, minimum)
+			}
+			var problems []string
+			for _, problem := range report.Problems {
+				problems = append(problems, strings.ReplaceAll(problem.String(), dir+string(filepath.Separator), ""))
+			}
+			// the directory itself has no separator after it
+			for i := range problems {
+				problems[i] = strings.TrimPrefix(problems[i], dir)
+			}
+			if !reflect.DeepEqual(problems, tt.wantProblems) {
+				t.Errorf("expected problems %q, got %q", tt.wantProblems, problems)
+			}
+			if failed := report.Failed(); failed != tt.wantFailed {
+				t.Errorf("expected failed %v, got %v", tt.wantFailed, failed)
+			}
+		})
+	}
+}
+
+func Test_CheckEmbedded(t *testing.T) {
+	report := Check(filepath.Join("..", "..", "levels"))
+	if len(report.Levels) == 0 {
+		t.Fatal("expected the embedded levels to be checked")
+	}
+	if len(report.Problems) > 0 {
+		t.Errorf("expected no problems, got %v", report.Problems)
+	}
+}
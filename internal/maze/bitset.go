@@ -0,0 +1,56 @@
+package maze
+
+import "math/bits"
+
+// bitset is a dense set of the positions of a width by height area, it holds one bit per position row by row
+type bitset struct {
+	width  int
+	height int
+	words  []uint64
+}
+
+// newBitset returns a bitset of the area with every position in it when full is set
+func newBitset(width, height int, full bool) *bitset {
+	b := &bitset{width: width, height: height, words: make([]uint64, (width*height+63)/64)}
+	if full {
+		for i := range b.words {
+			b.words[i] = ^uint64(0)
+		}
+		// the bits after the last position are left out so they are not counted
+		if rest := width * height % 64; rest > 0 {
+			b.words[len(b.words)-1] = 1<<rest - 1
+		}
+	}
+	return b
+}
+
+// contains reports whether the position is in the area
+func (b *bitset) contains(x, y int) bool {
+	return x >= 0 && x < b.width && y >= 0 && y < b.height
+}
+
+// has reports whether the position is in the set, positions outside the area never are
+func (b *bitset) has(x, y int) bool {
+	if !b.contains(x, y) {
+		return false
+	}
+	i := y*b.width + x
+	return b.words[i/64]&(1<<(i%64)) != 0
+}
+
+// remove removes the position from the set
+func (b *bitset) remove(x, y int) {
+	if b.contains(x, y) {
+		i := y*b.width + x
+		b.words[i/64] &^= 1 << (i % 64)
+	}
+}
+
+// count returns the number of positions in the set
+func (b *bitset) count() int {
+	n := 0
+	for _, word := range b.words {
+		n += bits.OnesCount64(word)
+	}
+	return n
+}
@@ -0,0 +1,39 @@
+package maze
+
+import "testing"
+
+func Test_Bitset(t *testing.T) {
+	// 9 by 8 positions do not fill the second word
+	b := newBitset(9, 8, true)
+	if got := b.count(); got != 72 {
+		t.Fatalf("expected 72 positions, got %d", got)
+	}
+	b.remove(8, 7)
+	b.remove(0, 0)
+	b.remove(0, 0)
+	b.remove(9, 0)
+	if got := b.count(); got != 70 {
+		t.Errorf("expected 70 positions, got %d", got)
+	}
+	tests := []struct {
+		x, y int
+		want bool
+	}{
+		{x: 0, y: 0, want: false},
+		{x: 8, y: 7, want: false},
+		{x: 1, y: 0, want: true},
+		{x: 0, y: 1, want: true},
+		{x: 7, y: 7, want: true},
+		{x: 9, y: 0, want: false},
+		{x: -1, y: 3, want: false},
+		{x: 3, y: 8, want: false},
+	}
+	for _, tt := range tests {
+		if got := b.has(tt.x, tt.y); got != tt.want {
+			t.Errorf("expected has(%d, %d) to be %v, got %v", tt.x, tt.y, tt.want, got)
+		}
+	}
+	if got := newBitset(3, 3, false).count(); got != 0 {
+		t.Errorf("expected an empty set, got %d positions", got)
+	}
+}
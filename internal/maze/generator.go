@@ -0,0 +1,358 @@
+package maze
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// Cell is the column and row of a cell of a maze, neighbouring cells are joined by passages
+type Cell struct {
+	Col int
+	Row int
+}
+
+// Passage joins two neighbouring cells
+type Passage struct {
+	From Cell
+	To   Cell
+}
+
+// key returns the passage with its cells in order, so a passage and its reverse have the same key
+func (p Passage) key() Passage {
+	if p.To.Row < p.From.Row || p.To.Row == p.From.Row && p.To.Col < p.From.Col {
+		return Passage{From: p.To, To: p.From}
+	}
+	return p
+}
+
+// Generator chooses the passages of a maze
+type Generator interface {
+	// Passages returns the passages between the neighbouring cells of a grid of cols by rows cells, every cell
+	// has to be reachable from every other cell through them. The random numbers are drawn from r so the same
+	// seed gives the same passages
+	Passages(cols, rows int, r *rand.Rand) []Passage
+}
+
+// generators holds the generators by the name they are selected with in level files
+var generators = map[string]Generator{
+	"dfs":      DFS{},
+	"prim":     Prim{},
+	"kruskal":  Kruskal{},
+	"division": Division{},
+	"wilson":   Wilson{},
+	"braid":    Braid{},
+}
+
+// Named returns the generator with the name and whether there is one
+func Named(name string) (Generator, bool) {
+	generator, ok := generators[name]
+	return generator, ok
+}
+
+// Names returns the names of the generators in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// directions are the offsets of the neighbours of a cell: up, down, left and right
+var directions = []Cell{{Row: -1}, {Row: 1}, {Col: -1}, {Col: 1}}
+
+// grid is a grid of cols by rows cells
+type grid struct {
+	cols int
+	rows int
+}
+
+// contains reports whether the cell is in the grid
+func (g grid) contains(c Cell) bool {
+	return c.Col >= 0 && c.Col < g.cols && c.Row >= 0 && c.Row < g.rows
+}
+
+// index returns the index of the cell when the cells are counted row by row, it is used to keep
+// the state of the cells in slices
+func (g grid) index(c Cell) int {
+	return c.Row*g.cols + c.Col
+}
+
+// cells returns the cells of the grid row by row
+func (g grid) cells() []Cell {
+	cells := make([]Cell, 0, g.cols*g.rows)
+	for row := range g.rows {
+		for col := range g.cols {
+			cells = append(cells, Cell{Col: col, Row: row})
+		}
+	}
+	return cells
+}
+
+// neighbours returns the neighbours of the cell in the grid, in the order of the directions
+func (g grid) neighbours(c Cell) []Cell {
+	var neighbours []Cell
+	for _, d := range directions {
+		if next := (Cell{Col: c.Col + d.Col, Row: c.Row + d.Row}); g.contains(next) {
+			neighbours = append(neighbours, next)
+		}
+	}
+	return neighbours
+}
+
+// DFS carves the maze with a randomized depth-first search, which gives long winding corridors with few branches
+type DFS struct{}
+
+// Passages returns the passages of a maze carved by a depth-first search from the top-left cell
+func (DFS) Passages(cols, rows int, r *rand.Rand) []Passage {
+	g := grid{cols: cols, rows: rows}
+	if !g.contains(Cell{}) {
+		// the start cell is carved even when no cell fits, its shuffle draws the same random numbers
+		// so the numbers drawn after the maze do not change
+		r.Shuffle(len(directions), func(int, int) {})
+		return nil
+	}
+	visited := make([]bool, cols*rows)
+	// a frame holds a cell of the path and the directions that are left to try from it, the path is kept
+	// on a stack so large mazes do not recurse as deep as their longest corridor
+	type frame struct {
+		cell Cell
+		dirs [4]Cell
+		next int
+	}
+	passages := make([]Passage, 0, cols*rows-1)
+	var path []frame
+	visit := func(c Cell) {
+		visited[g.index(c)] = true
+		f := frame{cell: c}
+		copy(f.dirs[:], directions)
+		// shuffle the directions to randomize the path
+		r.Shuffle(len(f.dirs), func(i, j int) { f.dirs[i], f.dirs[j] = f.dirs[j], f.dirs[i] })
+		path = append(path, f)
+	}
+	visit(Cell{})
+	for len(path) > 0 {
+		top := &path[len(path)-1]
+		if top.next == len(top.dirs) {
+			path = path[:len(path)-1]
+			continue
+		}
+		c, d := top.cell, top.dirs[top.next]
+		top.next++
+		if next := (Cell{Col: c.Col + d.Col, Row: c.Row + d.Row}); g.contains(next) && !visited[g.index(next)] {
+			passages = append(passages, Passage{From: c, To: next})
+			visit(next)
+		}
+	}
+	return passages
+}
+
+// Prim carves the maze with a randomized version of Prim's algorithm, the maze grows from the top-left cell
+// by a random passage of its frontier at a time, which gives many short dead ends
+type Prim struct{}
+
+// Passages returns the passages of a maze grown with Prim's algorithm
+func (Prim) Passages(cols, rows int, r *rand.Rand) []Passage {
+	g := grid{cols: cols, rows: rows}
+	if !g.contains(Cell{}) {
+		return nil
+	}
+	visited := make([]bool, cols*rows)
+	visited[0] = true
+	var passages, frontier []Passage
+	grow := func(c Cell) {
+		for _, next := range g.neighbours(c) {
+			if !visited[g.index(next)] {
+				frontier = append(frontier, Passage{From: c, To: next})
+			}
+		}
+	}
+	grow(Cell{})
+	for len(frontier) > 0 {
+		i := r.Intn(len(frontier))
+		p := frontier[i]
+		frontier[i] = frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+		if visited[g.index(p.To)] {
+			continue
+		}
+		visited[g.index(p.To)] = true
+		passages = append(passages, p)
+		grow(p.To)
+	}
+	return passages
+}
+
+// Kruskal carves the maze with a randomized version of Kruskal's algorithm, passages are opened in a random
+// order unless the cells they join are already joined, which gives a maze without a bias in any direction
+type Kruskal struct{}
+
+// Passages returns the passages of a maze joined with Kruskal's algorithm
+func (Kruskal) Passages(cols, rows int, r *rand.Rand) []Passage {
+	g := grid{cols: cols, rows: rows}
+	var candidates []Passage
+	for _, c := range g.cells() {
+		for _, next := range []Cell{{Col: c.Col + 1, Row: c.Row}, {Col: c.Col, Row: c.Row + 1}} {
+			if g.contains(next) {
+				candidates = append(candidates, Passage{From: c, To: next})
+			}
+		}
+	}
+	r.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+
+	// every cell points to a cell of its set, the set is named by the cell that points to itself
+	parent := make([]int, cols*rows)
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			// halving the path keeps the sets flat
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	var passages []Passage
+	for _, p := range candidates {
+		from, to := find(g.index(p.From)), find(g.index(p.To))
+		if from != to {
+			parent[from] = to
+			passages = append(passages, p)
+		}
+	}
+	return passages
+}
+
+// Division carves the maze by recursive division, an open area is split in two by a wall with a single gap
+// and both halves are split again, which gives long straight corridors that reward counts
+type Division struct{}
+
+// Passages returns the passages of a maze divided into smaller and smaller areas
+func (Division) Passages(cols, rows int, r *rand.Rand) []Passage {
+	g := grid{cols: cols, rows: rows}
+	closed := make(map[Passage]bool)
+	divide(0, 0, cols, rows, r, closed)
+
+	var passages []Passage
+	for _, c := range g.cells() {
+		for _, next := range []Cell{{Col: c.Col + 1, Row: c.Row}, {Col: c.Col, Row: c.Row + 1}} {
+			if p := (Passage{From: c, To: next}); g.contains(next) && !closed[p] {
+				passages = append(passages, p)
+			}
+		}
+	}
+	return passages
+}
+
+// divide closes the passages of a wall across the area of w by h cells at col and row, but for a gap,
+// and divides the areas on both sides of it. An area of a single row or column is left open
+func divide(col, row, w, h int, r *rand.Rand, closed map[Passage]bool) {
+	if w < 2 || h < 2 {
+		return
+	}
+	if h > w || h == w && r.Intn(2) == 0 {
+		// the wall runs along the rows, between the row split and the row below it
+		split := row + r.Intn(h-1)
+		gap := col + r.Intn(w)
+		for c := col; c < col+w; c++ {
+			if c != gap {
+				closed[Passage{From: Cell{Col: c, Row: split}, To: Cell{Col: c, Row: split + 1}}] = true
+			}
+		}
+		divide(col, row, w, split-row+1, r, closed)
+		divide(col, split+1, w, row+h-split-1, r, closed)
+		return
+	}
+	// the wall runs along the columns, between the column split and the column right of it
+	split := col + r.Intn(w-1)
+	gap := row + r.Intn(h)
+	for c := row; c < row+h; c++ {
+		if c != gap {
+			closed[Passage{From: Cell{Col: split, Row: c}, To: Cell{Col: split + 1, Row: c}}] = true
+		}
+	}
+	divide(col, row, split-col+1, h, r, closed)
+	divide(split+1, row, col+w-split-1, h, r, closed)
+}
+
+// Wilson carves the maze with Wilson's algorithm, random walks that erase their own loops join the cells one
+// path at a time, which gives every possible maze the same chance
+type Wilson struct{}
+
+// Passages returns the passages of a maze joined by loop-erased random walks
+func (Wilson) Passages(cols, rows int, r *rand.Rand) []Passage {
+	g := grid{cols: cols, rows: rows}
+	cells := g.cells()
+	if len(cells) == 0 {
+		return nil
+	}
+	inMaze := make([]bool, len(cells))
+	inMaze[r.Intn(len(cells))] = true
+	// the walk keeps only the last way it left every cell, which erases the loops it made
+	exits := make([]Cell, len(cells))
+	var passages []Passage
+	for _, start := range cells {
+		for c := start; !inMaze[g.index(c)]; c = exits[g.index(c)] {
+			neighbours := g.neighbours(c)
+			exits[g.index(c)] = neighbours[r.Intn(len(neighbours))]
+		}
+		for c := start; !inMaze[g.index(c)]; c = exits[g.index(c)] {
+			inMaze[g.index(c)] = true
+			passages = append(passages, Passage{From: c, To: exits[g.index(c)]})
+		}
+	}
+	return passages
+}
+
+// Braid carves the maze with the Base generator, or DFS without one, and joins every dead end to another
+// neighbour, preferably another dead end, which gives loops so there is more than one way to a target
+type Braid struct {
+	Base Generator
+}
+
+// Passages returns the passages of the base maze with its dead ends removed
+func (b Braid) Passages(cols, rows int, r *rand.Rand) []Passage {
+	base := b.Base
+	if base == nil {
+		base = DFS{}
+	}
+	g := grid{cols: cols, rows: rows}
+	passages := base.Passages(cols, rows, r)
+	open := make(map[Passage]bool)
+	degree := make([]int, cols*rows)
+	join := func(p Passage) {
+		open[p.key()] = true
+		degree[g.index(p.From)]++
+		degree[g.index(p.To)]++
+	}
+	for _, p := range passages {
+		join(p)
+	}
+
+	for _, c := range g.cells() {
+		if degree[g.index(c)] != 1 {
+			continue
+		}
+		var closed, deadEnds []Cell
+		for _, next := range g.neighbours(c) {
+			if !open[Passage{From: c, To: next}.key()] {
+				closed = append(closed, next)
+				if degree[g.index(next)] == 1 {
+					deadEnds = append(deadEnds, next)
+				}
+			}
+		}
+		if len(deadEnds) > 0 {
+			closed = deadEnds
+		}
+		if len(closed) == 0 {
+			continue
+		}
+		p := Passage{From: c, To: closed[r.Intn(len(closed))]}
+		join(p)
+		passages = append(passages, p)
+	}
+	return passages
+}
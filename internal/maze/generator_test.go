@@ -0,0 +1,111 @@
+package maze
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// perfect lists the generators that carve a maze with exactly one way between every two cells
+var perfect = []string{"dfs", "prim", "kruskal", "division", "wilson"}
+
+// reachable returns the number of cells that can be reached from the top-left cell through the passages
+func reachable(passages []Passage) int {
+	joined := make(map[Cell][]Cell)
+	for _, p := range passages {
+		joined[p.From] = append(joined[p.From], p.To)
+		joined[p.To] = append(joined[p.To], p.From)
+	}
+	visited := map[Cell]bool{{}: true}
+	queue := []Cell{{}}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		for _, next := range joined[c] {
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return len(visited)
+}
+
+func Test_GeneratorsConnect(t *testing.T) {
+	sizes := []struct{ cols, rows int }{{1, 1}, {1, 6}, {6, 1}, {2, 2}, {10, 10}, {17, 9}}
+	for _, name := range Names() {
+		generator, _ := Named(name)
+		for _, size := range sizes {
+			for seed := range int64(5) {
+				passages := generator.Passages(size.cols, size.rows, rand.New(rand.NewSource(seed)))
+				g := grid{cols: size.cols, rows: size.rows}
+				for _, p := range passages {
+					if !g.contains(p.From) || !g.contains(p.To) {
+						t.Fatalf("%s: expected passage %v to be in the %dx%d grid", name, p, size.cols, size.rows)
+					}
+					if d := abs(p.From.Col-p.To.Col) + abs(p.From.Row-p.To.Row); d != 1 {
+						t.Fatalf("%s: expected passage %v to join neighbours", name, p)
+					}
+				}
+				if got, want := reachable(passages), size.cols*size.rows; got != want {
+					t.Errorf("%s: expected all %d cells of %dx%d to be reachable with seed %d, got %d",
+						name, want, size.cols, size.rows, seed, got)
+				}
+			}
+		}
+	}
+}
+
+func Test_PerfectGenerators(t *testing.T) {
+	for _, name := range perfect {
+		generator, _ := Named(name)
+		passages := generator.Passages(12, 8, rand.New(rand.NewSource(3)))
+		// a connected maze without loops has one passage less than it has cells
+		if len(passages) != 12*8-1 {
+			t.Errorf("%s: expected %d passages, got %d", name, 12*8-1, len(passages))
+		}
+	}
+}
+
+func Test_BraidHasNoDeadEnds(t *testing.T) {
+	passages := Braid{}.Passages(12, 8, rand.New(rand.NewSource(3)))
+	degree := make(map[Cell]int)
+	for _, p := range passages {
+		degree[p.From]++
+		degree[p.To]++
+	}
+	for _, c := range (grid{cols: 12, rows: 8}).cells() {
+		if degree[c] < 2 {
+			t.Errorf("expected cell %v to have at least two passages, got %d", c, degree[c])
+		}
+	}
+}
+
+func Test_GeneratorsAreDeterministic(t *testing.T) {
+	for _, name := range Names() {
+		generator, _ := Named(name)
+		first := generator.Passages(10, 10, rand.New(rand.NewSource(42)))
+		second := generator.Passages(10, 10, rand.New(rand.NewSource(42)))
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("%s: expected the same passages for the same seed", name)
+		}
+		other := generator.Passages(10, 10, rand.New(rand.NewSource(43)))
+		if reflect.DeepEqual(first, other) {
+			t.Errorf("%s: expected other passages for another seed", name)
+		}
+	}
+}
+
+func Test_Named(t *testing.T) {
+	if _, ok := Named("dfs"); !ok {
+		t.Error("expected dfs to be a generator")
+	}
+	if _, ok := Named("maze"); ok {
+		t.Error("expected maze not to be a generator")
+	}
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	return max(n, -n)
+}
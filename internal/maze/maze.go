@@ -0,0 +1,121 @@
+package maze
+
+import (
+	"math/rand"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// Maze is a square of walls with corridors carved out of it. Its cells are pathWidth by pathWidth areas
+// with a wall of pathWidth between them, the passages of a Generator open the walls between the cells
+type Maze struct {
+	size      int
+	pathWidth int
+	walls     *bitset
+}
+
+// New returns a Maze of size by size with corridors of pathWidth that are carved by the generator,
+// the random numbers of the generator are drawn from r
+func New(generator Generator, size, pathWidth int, r *rand.Rand) *Maze {
+	m := &Maze{size: size, pathWidth: pathWidth, walls: newBitset(size, size, true)}
+
+	cells := Cells(size, pathWidth)
+	// the top-left cell is the start, it is open even when no cell fits in the maze
+	m.carve(m.position(Cell{}))
+	for _, c := range (grid{cols: cells, rows: cells}).cells() {
+		m.carve(m.position(c))
+	}
+	for _, p := range generator.Passages(cells, cells, r) {
+		from, to := m.position(p.From), m.position(p.To)
+		m.carve(models.Position{X: (from.X + to.X) / 2, Y: (from.Y + to.Y) / 2})
+	}
+	return m
+}
+
+// Cells returns the number of cells in a row or column of a maze of the size with corridors of pathWidth
+func Cells(size, pathWidth int) int {
+	return max((size-1)/(2*pathWidth), 0)
+}
+
+// Size returns the width and height of the maze
+func (m *Maze) Size() int {
+	return m.size
+}
+
+// Start returns the position of the top-left cell of the maze
+func (m *Maze) Start() models.Position {
+	return m.position(Cell{})
+}
+
+// IsWall reports whether there is a wall at the position, there are no walls outside the maze
+func (m *Maze) IsWall(pos models.Position) bool {
+	return m.walls.has(pos.X, pos.Y)
+}
+
+// Walls returns the positions of the walls row by row
+func (m *Maze) Walls() []models.Position {
+	walls := make([]models.Position, 0, m.walls.count())
+	for y := range m.size {
+		for x := range m.size {
+			if m.walls.has(x, y) {
+				walls = append(walls, models.Position{X: x, Y: y})
+			}
+		}
+	}
+	return walls
+}
+
+// Lines returns the maze as lines of text with the walls drawn as wall and the corridors as spaces
+func (m *Maze) Lines(wall rune) []string {
+	lines := make([]string, m.size)
+	for y := range lines {
+		line := make([]rune, m.size)
+		for x := range line {
+			line[x] = ' '
+			if m.walls.has(x, y) {
+				line[x] = wall
+			}
+		}
+		lines[y] = string(line)
+	}
+	return lines
+}
+
+// Farthest returns the open position that takes the most steps up, down, left or right to reach from the
+// position, the first one row by row when there are more
+func (m *Maze) Farthest(from models.Position) models.Position {
+	distance := map[models.Position]int{from: 0}
+	queue := []models.Position{from}
+	farthest := from
+	for len(queue) > 0 {
+		pos := queue[0]
+		queue = queue[1:]
+		d := distance[pos]
+		if d > distance[farthest] || d == distance[farthest] && (pos.Y < farthest.Y || pos.Y == farthest.Y && pos.X < farthest.X) {
+			farthest = pos
+		}
+		for _, dir := range directions {
+			next := models.Position{X: pos.X + dir.Col, Y: pos.Y + dir.Row}
+			_, seen := distance[next]
+			if !seen && m.walls.contains(next.X, next.Y) && !m.IsWall(next) {
+				distance[next] = d + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	return farthest
+}
+
+// position returns the position of the top-left corner of the cell
+func (m *Maze) position(c Cell) models.Position {
+	return models.Position{X: m.pathWidth * (1 + 2*c.Col), Y: m.pathWidth * (1 + 2*c.Row)}
+}
+
+// carve removes the walls of the pathWidth by pathWidth area at the position
+func (m *Maze) carve(pos models.Position) {
+	for dy := range m.pathWidth {
+		for dx := range m.pathWidth {
+			m.walls.remove(pos.X+dx, pos.Y+dy)
+		}
+	}
+}
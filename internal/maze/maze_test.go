@@ -0,0 +1,77 @@
+package maze
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+func Test_New(t *testing.T) {
+	m := New(Division{}, 7, 1, rand.New(rand.NewSource(1)))
+	lines := m.Lines('#')
+	if len(lines) != 7 {
+		t.Fatalf("expected 7 lines, got %d", len(lines))
+	}
+	for y, line := range lines {
+		for x, r := range line {
+			pos := models.Position{X: x, Y: y}
+			if m.IsWall(pos) != (r == '#') {
+				t.Errorf("expected %v to be drawn as a wall when it is one, got %q", pos, r)
+			}
+			// the border and the corners between the cells are always walls
+			if (x == 0 || y == 0 || x == 6 || y == 6 || x%2 == 0 && y%2 == 0) && !m.IsWall(pos) {
+				t.Errorf("expected a wall at %v", pos)
+			}
+			// the cells are always open
+			if x%2 == 1 && y%2 == 1 && m.IsWall(pos) {
+				t.Errorf("expected no wall at %v", pos)
+			}
+		}
+	}
+	if len(m.Walls()) != 49-9-8 {
+		t.Errorf("expected the 9 cells and 8 passages to be carved, got %d walls", len(m.Walls()))
+	}
+	if m.IsWall(models.Position{X: -1, Y: 3}) || m.IsWall(models.Position{X: 3, Y: 7}) {
+		t.Error("expected no walls outside the maze")
+	}
+}
+
+func Test_NewPathWidth(t *testing.T) {
+	m := New(DFS{}, 9, 2, rand.New(rand.NewSource(1)))
+	if got := m.Start(); got != (models.Position{X: 2, Y: 2}) {
+		t.Errorf("expected the start at {2 2}, got %v", got)
+	}
+	// two by two cells at 2 and 6 with the passage between them at 4
+	for _, pos := range []models.Position{{X: 2, Y: 2}, {X: 3, Y: 3}, {X: 6, Y: 6}, {X: 7, Y: 7}} {
+		if m.IsWall(pos) {
+			t.Errorf("expected no wall at %v", pos)
+		}
+	}
+}
+
+func Test_Farthest(t *testing.T) {
+	// a corridor along the top that turns down at the right
+	m := &Maze{size: 5, pathWidth: 1, walls: newBitset(5, 5, true)}
+	for _, pos := range []models.Position{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 2}, {X: 3, Y: 3}} {
+		m.walls.remove(pos.X, pos.Y)
+	}
+	if got, want := m.Farthest(models.Position{X: 1, Y: 1}), (models.Position{X: 3, Y: 3}); got != want {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := m.Farthest(models.Position{X: 3, Y: 2}), (models.Position{X: 1, Y: 1}); got != want {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func Test_NewDeterministic(t *testing.T) {
+	for _, name := range Names() {
+		generator, _ := Named(name)
+		first := New(generator, 21, 1, rand.New(rand.NewSource(7))).Lines('#')
+		second := New(generator, 21, 1, rand.New(rand.NewSource(7))).Lines('#')
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("%s: expected the same maze for the same seed", name)
+		}
+	}
+}
@@ -52,8 +52,41 @@ var DefaultCharacters = Characters{
 	Wall: Character{'#', "#"},
 }
 
-// ToDefaultCharacterStyle maps a rune to a default character style
-func ToDefaultCharacterStyle(r rune) lipgloss.Style {
+// Overlay identifies a highlight that is laid over the character of a cell
+type Overlay uint8
+
+const (
+	// OverlayNone leaves the cell as it is
+	OverlayNone Overlay = iota
+	// OverlaySelection highlights a cell that is selected in visual mode
+	OverlaySelection
+	// OverlayRegion highlights a cell of the region the player has to select
+	OverlayRegion
+	// OverlayGhost highlights the cell of the cursor of the run that is raced against
+	OverlayGhost
+)
+
+// ToDefaultCharacterStyle maps a rune to a default character style, the style of an Overlay
+// other than OverlayNone is laid over it. The cursor is never covered by an Overlay
+func ToDefaultCharacterStyle(r rune, overlay Overlay) lipgloss.Style {
+	characterStyle := toDefaultCharacterStyle(r)
+	if r == DefaultCharacters.Player.Cursor.Rune {
+		return characterStyle
+	}
+	switch overlay {
+	case OverlaySelection:
+		return style.Styles.Adventure.Map.Overlay.Selection.Inherit(characterStyle)
+	case OverlayRegion:
+		return style.Styles.Adventure.Map.Overlay.Region.Inherit(characterStyle)
+	case OverlayGhost:
+		return style.Styles.Adventure.Map.Ghost.Inherit(characterStyle)
+	default:
+		return characterStyle
+	}
+}
+
+// toDefaultCharacterStyle maps a rune to a default character style without an Overlay
+func toDefaultCharacterStyle(r rune) lipgloss.Style {
 	switch r {
 	case DefaultCharacters.Player.Cursor.Rune:
 		return style.Styles.Adventure.Map.Player.Cursor
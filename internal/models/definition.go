@@ -0,0 +1,40 @@
+package models
+
+// LevelDefinition describes a level that is played on a grid of text, it is read from a level file
+// so levels can be written without code. Every target is reached by moving onto its position in order
+type LevelDefinition struct {
+	Number      int
+	Description string
+	Lesson      string // the motions the level teaches, named in the instructions
+	Text        []string
+	Wall        rune // character of the text the cursor cannot move onto, zero when there are no walls
+	Start       Position
+	Allowed     []MotionKind
+	Targets     []TargetDefinition
+	Maze        *MazeDefinition // the maze the text is generated from, nil when the text is written out
+}
+
+// MazeDefinition describes a maze that is generated as the text of a LevelDefinition
+type MazeDefinition struct {
+	Algorithm string // name of the generator that carves the maze, like dfs or prim
+	Size      int
+	Seed      int64
+	PathWidth int
+}
+
+// TargetDefinition describes a target of a LevelDefinition
+type TargetDefinition struct {
+	Position Position
+	Par      int    // keystrokes the target has to be reached in, zero when there is no par
+	Hint     string // explains how the target is reached, it is added to the instructions
+}
+
+// LevelPack is a set of level definitions that are shipped together, it is read from a directory
+// that holds a manifest next to the level files
+type LevelPack struct {
+	Name    string
+	Author  string
+	Version string
+	Order   int // packs are listed by their order, lowest first
+	Levels  []LevelDefinition
+}
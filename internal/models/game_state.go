@@ -17,6 +17,7 @@ type AdventureGameState struct {
 	WindowSize tea.WindowSizeMsg `json:"window_size"`
 	Level      SavedLevel        `json:"level"`
 	Stats      Stats             `json:"stats"`
+	Run        Run               `json:"run"`
 	SaveID     string            `json:"save_id"`
 }
 
@@ -0,0 +1,45 @@
+package models
+
+import "strings"
+
+// HistoryKind identifies a command that goes back and forth through the changes made to the text
+type HistoryKind uint8
+
+const (
+	// HistoryUndo undoes the last change
+	HistoryUndo HistoryKind = iota
+	// HistoryRedo redoes the last undone change
+	HistoryRedo
+	// HistoryRepeat repeats the last change at the cursor
+	HistoryRepeat
+)
+
+// HistoryKinds contains all commands that go through the changes made to the text
+var HistoryKinds = []HistoryKind{HistoryUndo, HistoryRedo, HistoryRepeat}
+
+// History represents a command entered by the player that undoes, redoes or repeats a change,
+// Count holds how many changes are undone or redone, or the count that replaces the one of a repeated change
+type History struct {
+	Kind  HistoryKind
+	Count int
+	Keys  []string
+}
+
+// String returns the keys that were typed for the history command
+func (h History) String() string {
+	return strings.Join(h.Keys, "")
+}
+
+// Keystrokes returns the number of keys that were typed for the history command
+func (h History) Keystrokes() int {
+	return len(h.Keys)
+}
+
+// Change represents the text as it is after a change, kept in the undo tree of a level.
+// Parent is the index of the change it was made on, -1 for the text the level started with,
+// and Cursor is where the change was made
+type Change struct {
+	Parent int      `json:"parent"`
+	Lines  []string `json:"lines"`
+	Cursor Position `json:"cursor"`
+}
@@ -1,6 +1,11 @@
 package models
 
-import "github.com/dasvh/go-learn-vim/internal/style"
+import (
+	"slices"
+	"strings"
+
+	"github.com/dasvh/go-learn-vim/internal/style"
+)
 
 // Section represents a section of information to be displayed on the Vim Information screen
 type Section struct {
@@ -85,7 +90,10 @@ var CheatsheetSection = []Section{
 		Title: "Advanced Motions",
 		Content: `- **w**: Jump to the start of the next word.
 - **e**: Jump to the end of the current/next word.
-- **b**: Jump to the start of the previous word.`,
+- **b**: Jump to the start of the previous word.
+- **)** / **(**: Jump to the start of the next/previous sentence.
+- **}** / **{**: Jump to the empty line after/before the paragraph.
+- **%**: Jump to the bracket matching the one under or after the cursor.`,
 	},
 	{
 		Title: "Combining Motions with Numbers",
@@ -100,6 +108,15 @@ var CheatsheetSection = []Section{
 - **d[motion]**: Delete up to the motion (e.g., **dw** deletes the current word).
 - **y[motion]**: Copy (yank) up to the motion (e.g., **y2w** copies the next two words).
 - **c[motion]**: Change (delete and switch to insert mode) up to the motion (e.g., **c3j** changes the next three lines).`,
+	},
+	{
+		Title: "Text Objects",
+		Content: `After an operator **i** acts on the inside of an object and **a** takes its surroundings along:
+- **iw** / **aw**: The word under the cursor, **aw** includes the space after it.
+- **i"** / **a"**: A quoted string, **i'** and **a'** work the same with single quotes.
+- **i(** / **a(**: The text inside parentheses, **i[**, **i{** and **i<** work the same with other brackets.
+- **ip** / **ap**: A paragraph of lines, **ap** includes the blank lines after it.
+- **it** / **at**: The text between matching tags, like <b> and </b>.`,
 	},
 	{
 		Title: "Essential Commands",
@@ -107,6 +124,27 @@ var CheatsheetSection = []Section{
 - **:q**: Quit Vim.
 - **:wq**: Save and quit.
 - **:q!**: Quit without saving changes.
+- **:{number}**: Jump to a line (e.g., **:12** jumps to line 12).
+- **:help {topic}**: Show the help about a topic (e.g., **:help dw**).
 - **ZZ**: Save and quit (shortcut).`,
 	},
 }
+
+// CheatsheetTopic returns the sections of the cheatsheet about a topic, which are the sections
+// with the topic as a word of their title or that explain it as a command, like **dw**.
+// Without a topic all sections are returned
+func CheatsheetTopic(topic string) []Section {
+	topic = strings.TrimSpace(topic)
+	if topic == "" {
+		return CheatsheetSection
+	}
+	var sections []Section
+	for _, section := range CheatsheetSection {
+		isTitleWord := func(word string) bool { return strings.EqualFold(word, topic) }
+		if slices.ContainsFunc(strings.Fields(section.Title), isTitleWord) ||
+			strings.Contains(section.Content, "**"+topic+"**") {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
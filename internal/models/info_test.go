@@ -0,0 +1,32 @@
+package models
+
+import "testing"
+
+func Test_CheatsheetTopic(t *testing.T) {
+	tests := []struct {
+		topic     string
+		wantTitle string
+		wantCount int
+	}{
+		{topic: "", wantTitle: "Vim Modes", wantCount: len(CheatsheetSection)},
+		{topic: "modes", wantTitle: "Vim Modes", wantCount: 1},
+		{topic: "dw", wantTitle: "Using Motions with Commands", wantCount: 1},
+		{topic: ":wq", wantTitle: "Essential Commands", wantCount: 1},
+		{topic: "w", wantTitle: "Advanced Motions", wantCount: 1},
+		{topic: "ci(", wantCount: 0},
+		{topic: "i(", wantTitle: "Text Objects", wantCount: 1},
+		{topic: "xyz", wantCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			sections := CheatsheetTopic(tt.topic)
+			if len(sections) != tt.wantCount {
+				t.Fatalf("expected %d sections, got %d", tt.wantCount, len(sections))
+			}
+			if tt.wantCount > 0 && sections[0].Title != tt.wantTitle {
+				t.Errorf("expected section %q, got %q", tt.wantTitle, sections[0].Title)
+			}
+		})
+	}
+}
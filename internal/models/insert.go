@@ -0,0 +1,45 @@
+package models
+
+import "strings"
+
+// InsertModeMessage is the message Vim shows while in insert mode
+const InsertModeMessage = "-- INSERT --"
+
+// InsertKind identifies a command that starts insert mode
+type InsertKind uint8
+
+const (
+	// InsertBefore starts inserting in front of the cursor
+	InsertBefore InsertKind = iota
+	// InsertAfter starts inserting after the cursor
+	InsertAfter
+	// InsertLineStart starts inserting in front of the first non-blank character of the line
+	InsertLineStart
+	// InsertLineEnd starts inserting at the end of the line
+	InsertLineEnd
+	// InsertOpenBelow opens a new line below the cursor and starts inserting on it
+	InsertOpenBelow
+	// InsertOpenAbove opens a new line above the cursor and starts inserting on it
+	InsertOpenAbove
+)
+
+// InsertKinds contains all commands that start insert mode
+var InsertKinds = []InsertKind{
+	InsertBefore, InsertAfter, InsertLineStart, InsertLineEnd, InsertOpenBelow, InsertOpenAbove,
+}
+
+// Insert represents a command entered by the player that starts insert mode
+type Insert struct {
+	Kind InsertKind
+	Keys []string
+}
+
+// String returns the keys that were typed for the insert command
+func (i Insert) String() string {
+	return strings.Join(i.Keys, "")
+}
+
+// Keystrokes returns the number of keys that were typed for the insert command
+func (i Insert) Keystrokes() int {
+	return len(i.Keys)
+}
@@ -4,8 +4,9 @@ package models
 type Level interface {
 	Number() int
 	Description() string
-	Init(width, height int)
-	PlayerMove(position Position) PlayerMovement
+	Init(width, height int) error
+	AllowedMotions() []MotionKind
+	PlayerMove(motion Motion) PlayerMovement
 	PlacePlayer(position Position)
 	Render() [][]rune
 	GetStartPosition() Position
@@ -19,14 +20,113 @@ type Level interface {
 	Exit()
 }
 
+// ScrollingLevel represents a Level that can be larger than the visible grid,
+// only the rows of Render inside the Viewport are shown
+type ScrollingLevel interface {
+	Level
+	Viewport() Viewport
+}
+
+// MarkingLevel represents a Level that keeps the marks set by the player
+type MarkingLevel interface {
+	Level
+	Marks() map[string]Position
+}
+
+// EditingLevel represents a Level in which operators change the text,
+// Text returns the text as it is after the changes
+type EditingLevel interface {
+	Level
+	AllowedOperators() []Operator
+	ApplyOperation(operation Operation) PlayerMovement
+	Text() []string
+}
+
+// InsertingLevel represents a Level with text that can be typed into in insert mode,
+// TypeKey receives the keys typed in insert mode by their name, like "a", " " or "enter"
+type InsertingLevel interface {
+	Level
+	AllowedInserts() []InsertKind
+	Inserting() bool
+	StartInsert(insert Insert) PlayerMovement
+	TypeKey(key string) PlayerMovement
+	StopInsert() PlayerMovement
+}
+
+// VisualLevel represents a Level in which text is selected in visual mode before an operator acts on it
+type VisualLevel interface {
+	Level
+	AllowedVisualModes() []VisualMode
+	VisualMode() VisualMode
+	StartVisual(visual Visual) PlayerMovement
+	StopVisual() PlayerMovement
+}
+
+// HistoryLevel represents a Level that keeps the changes made to its text in an undo tree,
+// Changes returns the changes of the tree and the index of the current one
+type HistoryLevel interface {
+	EditingLevel
+	AllowedHistory() []HistoryKind
+	ApplyHistory(history History) PlayerMovement
+	Changes() ([]Change, int)
+}
+
+// MacroLevel represents a Level in which keys are recorded into registers and replayed as macros,
+// while SetReplaying is set the keys a macro replays are not spent again
+type MacroLevel interface {
+	Level
+	AllowedMacros() []MacroKind
+	ApplyMacro(macro Macro) PlayerMovement
+	SetReplaying(replaying bool)
+}
+
+// HighlightingLevel represents a Level that highlights cells of what it renders,
+// Overlays has the same shape as the runes of Render
+type HighlightingLevel interface {
+	Level
+	Overlays() [][]Overlay
+}
+
+// GridLevel represents a Level that does not draw a position in the cell of the same coordinates,
+// Cell returns the cell of Render a position is drawn in and whether it is drawn
+type GridLevel interface {
+	Level
+	Cell(pos Position) (Position, bool)
+}
+
+// ParLevel represents a Level that knows the fewest keystrokes its targets can be reached in,
+// Par returns the sum of them for all targets and whether every target has one
+type ParLevel interface {
+	Level
+	Par() (int, bool)
+}
+
+// HintingLevel represents a Level that can tell the player how to move on, Hint returns the first
+// motion of the fewest keystrokes from the cursor onto the current target and whether there is one
+type HintingLevel interface {
+	Level
+	Hint() (string, bool)
+}
+
+// Viewport represents the rows of a rendered level that are visible
+type Viewport struct {
+	Top    int
+	Height int
+}
+
 // SavedLevel represents a saved level
 type SavedLevel struct {
-	Number         int      `json:"number"`
-	Width          int      `json:"width"`
-	Height         int      `json:"height"`
-	PlayerPosition Position `json:"player_position"`
-	Targets        []Target `json:"targets"`
-	CurrentTarget  int      `json:"current_target"`
-	Completed      bool     `json:"completed"`
-	InProgress     bool     `json:"in_progress"`
+	Number         int                 `json:"number"`
+	Width          int                 `json:"width"`
+	Height         int                 `json:"height"`
+	PlayerPosition Position            `json:"player_position"`
+	Targets        []Target            `json:"targets"`
+	CurrentTarget  int                 `json:"current_target"`
+	Completed      bool                `json:"completed"`
+	InProgress     bool                `json:"in_progress"`
+	Marks          map[string]Position `json:"marks,omitempty"`
+	Lines          []string            `json:"lines,omitempty"`
+	Changes        []Change            `json:"changes,omitempty"`
+	CurrentChange  int                 `json:"current_change,omitempty"`
+	Par            int                 `json:"par,omitempty"`
 }
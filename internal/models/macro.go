@@ -0,0 +1,47 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MacroKind identifies a command that records keys into a register or replays them
+type MacroKind uint8
+
+const (
+	// MacroRecord starts recording keys into a register, or stops recording
+	MacroRecord MacroKind = iota
+	// MacroReplay replays the keys recorded in a register
+	MacroReplay
+)
+
+// MacroKinds contains all commands that record or replay macros
+var MacroKinds = []MacroKind{MacroRecord, MacroReplay}
+
+// LastMacroRegister is the register name of @@, which replays the register that was replayed last
+const LastMacroRegister = '@'
+
+// RecordingMessage returns the message Vim shows while keys are recorded into the register
+func RecordingMessage(register rune) string {
+	return fmt.Sprintf("recording @%c", register)
+}
+
+// Macro represents a command entered by the player that records or replays keys.
+// Register is the name of the register, it is zero for the q that stops recording.
+// Count holds how many times the keys are replayed
+type Macro struct {
+	Kind     MacroKind
+	Register rune
+	Count    int
+	Keys     []string
+}
+
+// String returns the keys that were typed for the macro command
+func (m Macro) String() string {
+	return strings.Join(m.Keys, "")
+}
+
+// Keystrokes returns the number of keys that were typed for the macro command
+func (m Macro) Keystrokes() int {
+	return len(m.Keys)
+}
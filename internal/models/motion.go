@@ -0,0 +1,333 @@
+package models
+
+import (
+	"slices"
+	"strings"
+)
+
+// MotionKind identifies a cursor motion
+type MotionKind uint8
+
+const (
+	// MotionLeft moves the cursor one character to the left
+	MotionLeft MotionKind = iota
+	// MotionRight moves the cursor one character to the right
+	MotionRight
+	// MotionUp moves the cursor one line up
+	MotionUp
+	// MotionDown moves the cursor one line down
+	MotionDown
+	// MotionWordForward moves the cursor to the start of the next word
+	MotionWordForward
+	// MotionWordBackward moves the cursor to the start of the previous word
+	MotionWordBackward
+	// MotionWordEnd moves the cursor to the end of the current or next word
+	MotionWordEnd
+	// MotionWORDForward moves the cursor to the start of the next WORD
+	MotionWORDForward
+	// MotionWORDBackward moves the cursor to the start of the previous WORD
+	MotionWORDBackward
+	// MotionWORDEnd moves the cursor to the end of the current or next WORD
+	MotionWORDEnd
+	// MotionStartOfLine moves the cursor to the first column of the line
+	MotionStartOfLine
+	// MotionFirstNonBlank moves the cursor to the first non-blank character of the line
+	MotionFirstNonBlank
+	// MotionEndOfLine moves the cursor to the last character of the line
+	MotionEndOfLine
+	// MotionFirstLine moves the cursor to the first line, or to the line given by the count
+	MotionFirstLine
+	// MotionLastLine moves the cursor to the last line, or to the line given by the count
+	MotionLastLine
+	// MotionFindForward moves the cursor onto the next occurrence of a character in the line
+	MotionFindForward
+	// MotionFindBackward moves the cursor onto the previous occurrence of a character in the line
+	MotionFindBackward
+	// MotionTillForward moves the cursor in front of the next occurrence of a character in the line
+	MotionTillForward
+	// MotionTillBackward moves the cursor behind the previous occurrence of a character in the line
+	MotionTillBackward
+	// MotionRepeatFind repeats the last f, F, t or T motion
+	MotionRepeatFind
+	// MotionRepeatFindReverse repeats the last f, F, t or T motion in the opposite direction
+	MotionRepeatFindReverse
+	// MotionScreenTop moves the cursor to the top line of the window
+	MotionScreenTop
+	// MotionScreenMiddle moves the cursor to the middle line of the window
+	MotionScreenMiddle
+	// MotionScreenBottom moves the cursor to the bottom line of the window
+	MotionScreenBottom
+	// MotionHalfPageDown scrolls the window and the cursor half a window down
+	MotionHalfPageDown
+	// MotionHalfPageUp scrolls the window and the cursor half a window up
+	MotionHalfPageUp
+	// MotionPageDown scrolls the window a page down
+	MotionPageDown
+	// MotionPageUp scrolls the window a page up
+	MotionPageUp
+	// MotionScrollCenter scrolls the window to put the cursor line in the middle
+	MotionScrollCenter
+	// MotionScrollTop scrolls the window to put the cursor line at the top
+	MotionScrollTop
+	// MotionScrollBottom scrolls the window to put the cursor line at the bottom
+	MotionScrollBottom
+	// MotionSearchForward moves the cursor to the next match of a pattern
+	MotionSearchForward
+	// MotionSearchBackward moves the cursor to the previous match of a pattern
+	MotionSearchBackward
+	// MotionSearchNext repeats the last search in the same direction
+	MotionSearchNext
+	// MotionSearchPrevious repeats the last search in the opposite direction
+	MotionSearchPrevious
+	// MotionSetMark sets a mark at the cursor position
+	MotionSetMark
+	// MotionJumpMarkLine moves the cursor to the first non-blank character of the line of a mark
+	MotionJumpMarkLine
+	// MotionJumpMark moves the cursor to the position of a mark
+	MotionJumpMark
+	// MotionJumpOlder moves the cursor to an older position in the jump list
+	MotionJumpOlder
+	// MotionJumpNewer moves the cursor to a newer position in the jump list
+	MotionJumpNewer
+	// MotionSentenceForward moves the cursor to the start of the next sentence
+	MotionSentenceForward
+	// MotionSentenceBackward moves the cursor to the start of the sentence, or of the previous one
+	MotionSentenceBackward
+	// MotionParagraphForward moves the cursor to the empty line after the paragraph
+	MotionParagraphForward
+	// MotionParagraphBackward moves the cursor to the empty line before the paragraph
+	MotionParagraphBackward
+	// MotionMatchPair moves the cursor to the bracket matching the first bracket under or after it in the line,
+	// unlike in Vim a count does not move the cursor to a percentage of the text
+	MotionMatchPair
+)
+
+// BasicMotions contains the single character hjkl motions
+var BasicMotions = []MotionKind{MotionLeft, MotionDown, MotionUp, MotionRight}
+
+// WordMotions contains the word and WORD motions
+var WordMotions = []MotionKind{
+	MotionWordForward, MotionWordBackward, MotionWordEnd,
+	MotionWORDForward, MotionWORDBackward, MotionWORDEnd,
+}
+
+// LineMotions contains the motions that move along a line or through the lines
+var LineMotions = []MotionKind{
+	MotionStartOfLine, MotionFirstNonBlank, MotionEndOfLine,
+	MotionFirstLine, MotionLastLine,
+}
+
+// FindMotions contains the character find motions and the motions that repeat them
+var FindMotions = []MotionKind{
+	MotionFindForward, MotionFindBackward, MotionTillForward, MotionTillBackward,
+	MotionRepeatFind, MotionRepeatFindReverse,
+}
+
+// ScreenMotions contains the motions that move relative to the window and the motions that scroll it
+var ScreenMotions = []MotionKind{
+	MotionScreenTop, MotionScreenMiddle, MotionScreenBottom,
+	MotionHalfPageDown, MotionHalfPageUp, MotionPageDown, MotionPageUp,
+	MotionScrollCenter, MotionScrollTop, MotionScrollBottom,
+}
+
+// SearchMotions contains the search motions and the motions that repeat them
+var SearchMotions = []MotionKind{
+	MotionSearchForward, MotionSearchBackward, MotionSearchNext, MotionSearchPrevious,
+}
+
+// MarkMotions contains the motions that set and jump to marks and the jump list motions
+var MarkMotions = []MotionKind{
+	MotionSetMark, MotionJumpMarkLine, MotionJumpMark, MotionJumpOlder, MotionJumpNewer,
+}
+
+// ProseMotions contains the motions that move over sentences and paragraphs
+var ProseMotions = []MotionKind{
+	MotionSentenceForward, MotionSentenceBackward, MotionParagraphForward, MotionParagraphBackward,
+}
+
+// motionNames maps the keys of every motion and the name of every group of motions to the motions
+var motionNames = map[string][]MotionKind{
+	"h": {MotionLeft}, "j": {MotionDown}, "k": {MotionUp}, "l": {MotionRight},
+	"w": {MotionWordForward}, "b": {MotionWordBackward}, "e": {MotionWordEnd},
+	"W": {MotionWORDForward}, "B": {MotionWORDBackward}, "E": {MotionWORDEnd},
+	"0": {MotionStartOfLine}, "^": {MotionFirstNonBlank}, "$": {MotionEndOfLine},
+	"gg": {MotionFirstLine}, "G": {MotionLastLine},
+	"f": {MotionFindForward}, "F": {MotionFindBackward}, "t": {MotionTillForward}, "T": {MotionTillBackward},
+	";": {MotionRepeatFind}, ",": {MotionRepeatFindReverse},
+	"H": {MotionScreenTop}, "M": {MotionScreenMiddle}, "L": {MotionScreenBottom},
+	"ctrl+d": {MotionHalfPageDown}, "ctrl+u": {MotionHalfPageUp}, "ctrl+f": {MotionPageDown}, "ctrl+b": {MotionPageUp},
+	"zz": {MotionScrollCenter}, "zt": {MotionScrollTop}, "zb": {MotionScrollBottom},
+	"/": {MotionSearchForward}, "?": {MotionSearchBackward}, "n": {MotionSearchNext}, "N": {MotionSearchPrevious},
+	"m": {MotionSetMark}, "'": {MotionJumpMarkLine}, "`": {MotionJumpMark},
+	"ctrl+o": {MotionJumpOlder}, "ctrl+i": {MotionJumpNewer},
+	")": {MotionSentenceForward}, "(": {MotionSentenceBackward},
+	"}": {MotionParagraphForward}, "{": {MotionParagraphBackward}, "%": {MotionMatchPair},
+	"basic": BasicMotions, "word": WordMotions, "line": LineMotions, "find": FindMotions,
+	"screen": ScreenMotions, "search": SearchMotions, "mark": MarkMotions, "prose": ProseMotions,
+}
+
+// ParseMotions returns the motions with the given name, which is either the keys of a motion like w or gg
+// or the name of a group of motions like word, and whether there are motions with that name
+func ParseMotions(name string) ([]MotionKind, bool) {
+	kinds, ok := motionNames[name]
+	return kinds, ok
+}
+
+// motionGroups lists the names of the groups of motions in the order MotionNames names them
+var motionGroups = []string{"basic", "word", "line", "find", "screen", "search", "mark", "prose"}
+
+// MotionNames returns the names that ParseMotions turns back into the motions, the motions of a whole group
+// are named by the group and the other motions by their keys
+func MotionNames(kinds []MotionKind) []string {
+	var names []string
+	named := make(map[MotionKind]bool)
+	for _, group := range motionGroups {
+		if !containsAll(kinds, motionNames[group]) {
+			continue
+		}
+		names = append(names, group)
+		for _, kind := range motionNames[group] {
+			named[kind] = true
+		}
+	}
+	for _, kind := range kinds {
+		for name, motions := range motionNames {
+			if !named[kind] && len(motions) == 1 && motions[0] == kind {
+				names = append(names, name)
+				named[kind] = true
+			}
+		}
+	}
+	return names
+}
+
+// containsAll reports whether every motion of want is in kinds
+func containsAll(kinds, want []MotionKind) bool {
+	for _, kind := range want {
+		if !slices.Contains(kinds, kind) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFind reports whether the motion moves to a character in the line
+func (k MotionKind) IsFind() bool {
+	switch k {
+	case MotionFindForward, MotionFindBackward, MotionTillForward, MotionTillBackward:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsMark reports whether the motion sets or jumps to a mark
+func (k MotionKind) IsMark() bool {
+	return k == MotionSetMark || k == MotionJumpMarkLine || k == MotionJumpMark
+}
+
+// NeedsChar reports whether the motion is followed by a character, like the character
+// it moves to or the name of a mark
+func (k MotionKind) NeedsChar() bool {
+	return k.IsFind() || k.IsMark()
+}
+
+// IsJump reports whether the motion is a jump, the position before a jump is kept in the jump list
+func (k MotionKind) IsJump() bool {
+	switch k {
+	case MotionFirstLine, MotionLastLine,
+		MotionScreenTop, MotionScreenMiddle, MotionScreenBottom,
+		MotionSearchForward, MotionSearchBackward, MotionSearchNext, MotionSearchPrevious,
+		MotionJumpMarkLine, MotionJumpMark,
+		MotionSentenceForward, MotionSentenceBackward, MotionParagraphForward, MotionParagraphBackward,
+		MotionMatchPair:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsLinewise reports whether an operator followed by the motion acts on whole lines
+func (k MotionKind) IsLinewise() bool {
+	switch k {
+	case MotionUp, MotionDown, MotionFirstLine, MotionLastLine,
+		MotionScreenTop, MotionScreenMiddle, MotionScreenBottom, MotionJumpMarkLine:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsInclusive reports whether an operator followed by the motion includes the character
+// the motion ends on, for the other motions that character is left out
+func (k MotionKind) IsInclusive() bool {
+	switch k {
+	case MotionWordEnd, MotionWORDEnd, MotionEndOfLine, MotionFindForward, MotionTillForward, MotionMatchPair:
+		return true
+	default:
+		return false
+	}
+}
+
+// NeedsPattern reports whether the motion is followed by a pattern typed on the command line
+func (k MotionKind) NeedsPattern() bool {
+	return k == MotionSearchForward || k == MotionSearchBackward
+}
+
+// Keys returns the keys that are typed for the motion, without a count or the character or pattern
+// that follows them, like g and g for gg
+func (k MotionKind) Keys() []string {
+	for name, kinds := range motionNames {
+		if len(kinds) != 1 || kinds[0] != k || slices.Contains(motionGroups, name) {
+			continue
+		}
+		if strings.HasPrefix(name, "ctrl+") {
+			return []string{name}
+		}
+		return strings.Split(name, "")
+	}
+	return nil
+}
+
+// Motion represents a cursor motion entered by the player,
+// a Count of zero means that no count was typed.
+// Char holds the character of a find motion or the name of a mark, Repeated is set when the
+// find motion was repeated with ; or ,
+// Pattern holds the pattern of a search motion, once it is typed its keys are part of the Keys
+type Motion struct {
+	Kind     MotionKind
+	Count    int
+	Char     rune
+	Repeated bool
+	Pattern  string
+	Keys     []string
+}
+
+// String returns the keys that were typed for the motion
+func (m Motion) String() string {
+	return strings.Join(m.Keys, "")
+}
+
+// Keystrokes returns the number of keys that were typed for the motion
+func (m Motion) Keystrokes() int {
+	return len(m.Keys)
+}
+
+// ExEnter is the key that runs the ex command typed on the command line, written like in Vim
+const ExEnter = "<CR>"
+
+// ExJumpKeys returns the keys typed for the ex command :{n} that jumps to a line,
+// the colon, every character of the text typed after it and the enter that runs it
+func ExJumpKeys(text string) []string {
+	return append([]string{":"}, CommandLineKeys(text)...)
+}
+
+// CommandLineKeys returns the keys typed on the command line after its prompt, every character
+// of the text and the enter that runs it, like the pattern of a search motion
+func CommandLineKeys(text string) []string {
+	var keys []string
+	for _, r := range text {
+		keys = append(keys, string(r))
+	}
+	return append(keys, ExEnter)
+}
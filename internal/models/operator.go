@@ -0,0 +1,135 @@
+package models
+
+import "strings"
+
+// Operator identifies a command that acts on the text a motion moves over or a text object covers
+type Operator uint8
+
+const (
+	// OperatorDelete deletes the text
+	OperatorDelete Operator = iota
+	// OperatorChange deletes the text to replace it
+	OperatorChange
+	// OperatorYank copies the text without changing it
+	OperatorYank
+)
+
+// Operators contains all operators
+var Operators = []Operator{OperatorDelete, OperatorChange, OperatorYank}
+
+// String returns the key of the operator
+func (o Operator) String() string {
+	switch o {
+	case OperatorDelete:
+		return "d"
+	case OperatorChange:
+		return "c"
+	case OperatorYank:
+		return "y"
+	default:
+		return "?"
+	}
+}
+
+// Verb returns the word used for the operator in instructions
+func (o Operator) Verb() string {
+	switch o {
+	case OperatorDelete:
+		return "Delete"
+	case OperatorChange:
+		return "Change"
+	case OperatorYank:
+		return "Yank"
+	default:
+		return "Operate on"
+	}
+}
+
+// TextObject identifies a text object that can follow an operator, like iw or aw
+type TextObject uint8
+
+const (
+	// TextObjectNone means the operator is followed by a motion
+	TextObjectNone TextObject = iota
+	// TextObjectInnerWord covers the word under the cursor
+	TextObjectInnerWord
+	// TextObjectAWord covers the word under the cursor and the blanks around it
+	TextObjectAWord
+	// TextObjectInnerWORD covers the WORD under the cursor
+	TextObjectInnerWORD
+	// TextObjectAWORD covers the WORD under the cursor and the blanks around it
+	TextObjectAWORD
+	// TextObjectInnerDoubleQuote covers the text between the double quotes around the cursor
+	TextObjectInnerDoubleQuote
+	// TextObjectADoubleQuote covers the double quoted string around the cursor and the blanks after it
+	TextObjectADoubleQuote
+	// TextObjectInnerSingleQuote covers the text between the single quotes around the cursor
+	TextObjectInnerSingleQuote
+	// TextObjectASingleQuote covers the single quoted string around the cursor and the blanks after it
+	TextObjectASingleQuote
+	// TextObjectInnerBacktick covers the text between the backticks around the cursor
+	TextObjectInnerBacktick
+	// TextObjectABacktick covers the backtick quoted string around the cursor and the blanks after it
+	TextObjectABacktick
+	// TextObjectInnerParen covers the text inside the parentheses around the cursor
+	TextObjectInnerParen
+	// TextObjectAParen covers the parentheses around the cursor and the text inside them
+	TextObjectAParen
+	// TextObjectInnerBracket covers the text inside the square brackets around the cursor
+	TextObjectInnerBracket
+	// TextObjectABracket covers the square brackets around the cursor and the text inside them
+	TextObjectABracket
+	// TextObjectInnerBrace covers the text inside the curly braces around the cursor
+	TextObjectInnerBrace
+	// TextObjectABrace covers the curly braces around the cursor and the text inside them
+	TextObjectABrace
+	// TextObjectInnerAngle covers the text inside the angle brackets around the cursor
+	TextObjectInnerAngle
+	// TextObjectAAngle covers the angle brackets around the cursor and the text inside them
+	TextObjectAAngle
+	// TextObjectInnerParagraph covers the lines of the paragraph under the cursor
+	TextObjectInnerParagraph
+	// TextObjectAParagraph covers the lines of the paragraph under the cursor and the blank lines after it
+	TextObjectAParagraph
+	// TextObjectInnerTag covers the text between the tags around the cursor, like <b> and </b>
+	TextObjectInnerTag
+	// TextObjectATag covers the tags around the cursor and the text between them
+	TextObjectATag
+)
+
+// IsInner reports whether the text object leaves out the white space or delimiters around the object,
+// like iw and i( do, while aw and a( include them
+func (t TextObject) IsInner() bool {
+	switch t {
+	case TextObjectInnerWord, TextObjectInnerWORD, TextObjectInnerDoubleQuote, TextObjectInnerSingleQuote,
+		TextObjectInnerBacktick, TextObjectInnerParen, TextObjectInnerBracket, TextObjectInnerBrace,
+		TextObjectInnerAngle, TextObjectInnerParagraph, TextObjectInnerTag:
+		return true
+	default:
+		return false
+	}
+}
+
+// Operation represents an Operator entered by the player together with what it acts on,
+// which is the Motion, the TextObject or, when Linewise is set, whole lines like with dd.
+// When Visual is set the operator was typed in visual mode and acts on the selection.
+// Count holds the count of the whole operation, for a motion it is already part of the Motion
+type Operation struct {
+	Operator Operator
+	Count    int
+	Motion   Motion
+	Object   TextObject
+	Linewise bool
+	Visual   bool
+	Keys     []string
+}
+
+// String returns the keys that were typed for the operation
+func (o Operation) String() string {
+	return strings.Join(o.Keys, "")
+}
+
+// Keystrokes returns the number of keys that were typed for the operation
+func (o Operation) Keystrokes() int {
+	return len(o.Keys)
+}
@@ -18,10 +18,12 @@ type PlayerMovement struct {
 	InstructionMessage string
 }
 
-// HighScore represents a player's high score entry
+// HighScore represents a player's high score entry, Run is the recorded run of the game the score is for
 type HighScore struct {
 	PlayerName string    `json:"player_name"`
 	Level      int       `json:"level"`
 	Score      int       `json:"score"`
 	Timestamp  time.Time `json:"timestamp"`
+	SaveID     string    `json:"save_id"`
+	Run        Run       `json:"-"`
 }
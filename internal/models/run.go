@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// RunStep is a move of a level run, Keys holds the keys that were typed since the move before it
+// and Position is where the move left the cursor. Elapsed is the time since the run started
+type RunStep struct {
+	Elapsed  time.Duration `json:"elapsed"`
+	Keys     []string      `json:"keys"`
+	Position Position      `json:"position"`
+}
+
+// Run represents the timestamped motion sequence of a level run, the cursor moves from Start
+// through the positions of the Steps. Width and Height are the size of the grid it was played on
+type Run struct {
+	Width  int       `json:"width"`
+	Height int       `json:"height"`
+	Start  Position  `json:"start"`
+	Steps  []RunStep `json:"steps"`
+}
+
+// Record adds a step at the elapsed time that moved the cursor to pos with the keys
+func (r *Run) Record(elapsed time.Duration, keys []string, pos Position) {
+	r.Steps = append(r.Steps, RunStep{Elapsed: elapsed, Keys: keys, Position: pos})
+}
+
+// PositionAt returns the position of the cursor at the elapsed time of the run
+func (r Run) PositionAt(elapsed time.Duration) Position {
+	pos := r.Start
+	for _, step := range r.Steps {
+		if step.Elapsed > elapsed {
+			break
+		}
+		pos = step.Position
+	}
+	return pos
+}
+
+// Next returns the elapsed time of the first step after the elapsed time and whether there is one
+func (r Run) Next(elapsed time.Duration) (time.Duration, bool) {
+	for _, step := range r.Steps {
+		if step.Elapsed > elapsed {
+			return step.Elapsed, true
+		}
+	}
+	return 0, false
+}
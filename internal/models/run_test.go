@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRun_PositionAt(t *testing.T) {
+	run := Run{Start: Position{X: 1, Y: 1}}
+	run.Record(time.Second, []string{"4", "l"}, Position{X: 5, Y: 1})
+	run.Record(3*time.Second, []string{"j"}, Position{X: 5, Y: 2})
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    Position
+	}{
+		{name: "before the first step", elapsed: 0, want: Position{X: 1, Y: 1}},
+		{name: "at the first step", elapsed: time.Second, want: Position{X: 5, Y: 1}},
+		{name: "between the steps", elapsed: 2 * time.Second, want: Position{X: 5, Y: 1}},
+		{name: "after the last step", elapsed: time.Minute, want: Position{X: 5, Y: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := run.PositionAt(tt.elapsed); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRun_Next(t *testing.T) {
+	run := Run{}
+	run.Record(time.Second, []string{"l"}, Position{X: 1})
+	run.Record(3*time.Second, []string{"l"}, Position{X: 2})
+
+	if next, ok := run.Next(time.Second); !ok || next != 3*time.Second {
+		t.Errorf("expected the next step at %v, got %v (%v)", 3*time.Second, next, ok)
+	}
+	if next, ok := run.Next(3 * time.Second); ok {
+		t.Errorf("expected no step after the last one, got %v", next)
+	}
+}
@@ -28,6 +28,20 @@ type UpdateLoadButtonMsg struct {
 	CanLoadGame bool
 }
 
+// OpenHelpMsg represents a message to open a help screen with sections of the cheatsheet,
+// leaving the help screen returns to the Back screen
+type OpenHelpMsg struct {
+	Sections []Section
+	Back     Screen
+}
+
+// PlaytestDoneMsg represents a message that the level played from the level editor was left,
+// Completed is set when all of its targets were reached
+type PlaytestDoneMsg struct {
+	Completed  bool
+	Keystrokes int
+}
+
 const (
 	// MainMenuScreen represents the main menu screen
 	MainMenuScreen Screen = iota
@@ -51,6 +65,10 @@ const (
 	StatsScreen
 	// ScoresScreen represents the scores screen
 	ScoresScreen
+	// HelpScreen represents the screen that shows the help opened with :help
+	HelpScreen
+	// LevelEditorScreen represents the screen where levels are drawn and written to level files
+	LevelEditorScreen
 )
 
 // ChangeScreen returns a command to change the current screen to the specified screen
@@ -3,11 +3,19 @@ package models
 // StatsFormat is the format string for displaying stats
 const StatsFormat = "Keystrokes: %d Time: %d s"
 
+// StatsParFormat is the format string for displaying stats of a level with a par,
+// the keystrokes are followed by the par
+const StatsParFormat = "Keystrokes: %d/%d par Time: %d s"
+
 // Stats represent the statistics of a game
 type Stats struct {
-	KeyPresses      map[string]int `json:"key_presses"`
-	TotalKeystrokes int            `json:"total_keystrokes"`
-	TimeElapsed     int            `json:"time_elapsed"`
+	KeyPresses        map[string]int `json:"key_presses"`
+	TotalKeystrokes   int            `json:"total_keystrokes"`
+	CommandKeystrokes int            `json:"command_keystrokes"`
+	InsertKeystrokes  int            `json:"insert_keystrokes"`
+	RepeatKeystrokes  int            `json:"repeat_keystrokes"`
+	Hints             int            `json:"hints"`
+	TimeElapsed       int            `json:"time_elapsed"`
 }
 
 // NewStats creates a new Stats instance
@@ -27,6 +35,68 @@ func (s *Stats) RegisterKey(key string, allowed bool) {
 	}
 }
 
+// RegisterMotion registers a Motion under the keys it was typed with,
+// every key of the Motion including its count adds to the total keystrokes
+func (s *Stats) RegisterMotion(motion Motion) {
+	s.TotalKeystrokes += motion.Keystrokes()
+	s.KeyPresses[motion.String()]++
+}
+
+// RegisterOperation registers an Operation under the keys it was typed with,
+// every key of the Operation including its counts adds to the total keystrokes
+func (s *Stats) RegisterOperation(operation Operation) {
+	s.TotalKeystrokes += operation.Keystrokes()
+	s.KeyPresses[operation.String()]++
+}
+
+// RegisterInsert registers an Insert under the keys it was typed with,
+// the keys that start insert mode are normal mode keys and add to the total keystrokes
+func (s *Stats) RegisterInsert(insert Insert) {
+	s.TotalKeystrokes += insert.Keystrokes()
+	s.KeyPresses[insert.String()]++
+}
+
+// RegisterVisual registers a Visual under the keys it was typed with,
+// every key of the Visual adds to the total keystrokes
+func (s *Stats) RegisterVisual(visual Visual) {
+	s.TotalKeystrokes += visual.Keystrokes()
+	s.KeyPresses[visual.String()]++
+}
+
+// RegisterHistory registers a History under the keys it was typed with, every key of the History
+// adds to the total keystrokes. The dots that repeat a change are counted apart as well, they score as cheap keys
+func (s *Stats) RegisterHistory(history History) {
+	s.TotalKeystrokes += history.Keystrokes()
+	s.KeyPresses[history.String()]++
+	if history.Kind == HistoryRepeat {
+		s.RepeatKeystrokes++
+	}
+}
+
+// RegisterMacro registers a Macro under the keys it was typed with, every key of the Macro adds
+// to the total keystrokes. The keys a macro replays were counted when they were recorded
+func (s *Stats) RegisterMacro(macro Macro) {
+	s.TotalKeystrokes += macro.Keystrokes()
+	s.KeyPresses[macro.String()]++
+}
+
+// RegisterInsertKey counts a key typed in insert mode, including the key that leaves it,
+// these keys are kept apart from the motion keys
+func (s *Stats) RegisterInsertKey() {
+	s.InsertKeystrokes++
+}
+
+// RegisterCommandKey counts a key typed on the command line,
+// these keys are kept apart from the motion keys
+func (s *Stats) RegisterCommandKey() {
+	s.CommandKeystrokes++
+}
+
+// RegisterHint counts a hint that was shown, hints are not keystrokes but lower the score
+func (s *Stats) RegisterHint() {
+	s.Hints++
+}
+
 // IncrementTime increments the time counter
 func (s *Stats) IncrementTime() {
 	s.TimeElapsed++
@@ -36,29 +106,45 @@ func (s *Stats) IncrementTime() {
 func (s *Stats) Reset() {
 	s.KeyPresses = make(map[string]int)
 	s.TotalKeystrokes = 0
+	s.CommandKeystrokes = 0
+	s.InsertKeystrokes = 0
+	s.RepeatKeystrokes = 0
+	s.Hints = 0
 }
 
 // LifetimeStats represents aggregated statistics for all games
 type LifetimeStats struct {
-	TotalKeystrokes int            `json:"total_keystrokes"`
-	TotalPlaytime   int            `json:"total_playtime"`
-	TotalGames      int            `json:"total_games"`
-	KeyPresses      map[string]int `json:"key_presses"`
+	TotalKeystrokes   int            `json:"total_keystrokes"`
+	CommandKeystrokes int            `json:"command_keystrokes"`
+	InsertKeystrokes  int            `json:"insert_keystrokes"`
+	RepeatKeystrokes  int            `json:"repeat_keystrokes"`
+	Hints             int            `json:"hints"`
+	TotalPlaytime     int            `json:"total_playtime"`
+	TotalGames        int            `json:"total_games"`
+	KeyPresses        map[string]int `json:"key_presses"`
 }
 
 // NewLifetimeStats initializes an empty LifetimeStats
 func NewLifetimeStats() *LifetimeStats {
 	return &LifetimeStats{
-		TotalKeystrokes: 0,
-		TotalPlaytime:   0,
-		TotalGames:      0,
-		KeyPresses:      make(map[string]int),
+		TotalKeystrokes:   0,
+		CommandKeystrokes: 0,
+		InsertKeystrokes:  0,
+		RepeatKeystrokes:  0,
+		Hints:             0,
+		TotalPlaytime:     0,
+		TotalGames:        0,
+		KeyPresses:        make(map[string]int),
 	}
 }
 
 // Merge aggregates stats from another Stats instance
 func (ls *LifetimeStats) Merge(stats Stats) {
 	ls.TotalKeystrokes += stats.TotalKeystrokes
+	ls.CommandKeystrokes += stats.CommandKeystrokes
+	ls.InsertKeystrokes += stats.InsertKeystrokes
+	ls.RepeatKeystrokes += stats.RepeatKeystrokes
+	ls.Hints += stats.Hints
 	ls.TotalPlaytime += stats.TimeElapsed
 
 	for key, count := range stats.KeyPresses {
@@ -76,6 +76,137 @@ func TestStats_RegisterKey(t *testing.T) {
 	}
 }
 
+func TestStats_RegisterMotion(t *testing.T) {
+	tests := []struct {
+		name           string
+		motions        []Motion
+		wantKeystrokes int
+		wantKeyPresses map[string]int
+	}{
+		{
+			name:           "single key motion",
+			motions:        []Motion{{Kind: MotionDown, Keys: []string{"j"}}},
+			wantKeystrokes: 1,
+			wantKeyPresses: map[string]int{"j": 1},
+		},
+		{
+			name: "motion with count",
+			motions: []Motion{
+				{Kind: MotionDown, Count: 5, Keys: []string{"5", "j"}},
+				{Kind: MotionDown, Count: 5, Keys: []string{"5", "j"}},
+			},
+			wantKeystrokes: 4,
+			wantKeyPresses: map[string]int{"5j": 2},
+		},
+		{
+			name: "count and multi key motion",
+			motions: []Motion{
+				{Kind: MotionFirstLine, Count: 12, Keys: []string{"1", "2", "g", "g"}},
+				{Kind: MotionDown, Keys: []string{"j"}},
+			},
+			wantKeystrokes: 5,
+			wantKeyPresses: map[string]int{"12gg": 1, "j": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := NewStats()
+			for _, motion := range tt.motions {
+				stats.RegisterMotion(motion)
+			}
+
+			if stats.TotalKeystrokes != tt.wantKeystrokes {
+				t.Errorf("expected TotalKeystrokes to be %d, got %d", tt.wantKeystrokes, stats.TotalKeystrokes)
+			}
+
+			if !reflect.DeepEqual(stats.KeyPresses, tt.wantKeyPresses) {
+				t.Errorf("expected KeyPresses to be %v, got %v", tt.wantKeyPresses, stats.KeyPresses)
+			}
+		})
+	}
+}
+
+func TestStats_RegisterOperation(t *testing.T) {
+	stats := NewStats()
+	stats.RegisterOperation(Operation{
+		Operator: OperatorDelete,
+		Motion:   Motion{Kind: MotionWordForward, Count: 6, Keys: []string{"2", "d", "3", "w"}},
+		Keys:     []string{"2", "d", "3", "w"},
+	})
+	stats.RegisterOperation(Operation{Operator: OperatorDelete, Linewise: true, Keys: []string{"d", "d"}})
+
+	if stats.TotalKeystrokes != 6 {
+		t.Errorf("expected TotalKeystrokes to be 6, got %d", stats.TotalKeystrokes)
+	}
+	want := map[string]int{"2d3w": 1, "dd": 1}
+	if !reflect.DeepEqual(stats.KeyPresses, want) {
+		t.Errorf("expected KeyPresses to be %v, got %v", want, stats.KeyPresses)
+	}
+}
+
+func TestStats_RegisterInsert(t *testing.T) {
+	stats := NewStats()
+	stats.RegisterInsert(Insert{Kind: InsertLineEnd, Keys: []string{"A"}})
+	for range 4 {
+		stats.RegisterInsertKey()
+	}
+
+	if stats.TotalKeystrokes != 1 {
+		t.Errorf("expected TotalKeystrokes to be 1, got %d", stats.TotalKeystrokes)
+	}
+	if stats.InsertKeystrokes != 4 {
+		t.Errorf("expected InsertKeystrokes to be 4, got %d", stats.InsertKeystrokes)
+	}
+	if want := map[string]int{"A": 1}; !reflect.DeepEqual(stats.KeyPresses, want) {
+		t.Errorf("expected KeyPresses to be %v, got %v", want, stats.KeyPresses)
+	}
+}
+
+func TestStats_RegisterVisual(t *testing.T) {
+	stats := NewStats()
+	stats.RegisterVisual(Visual{Mode: VisualBlock, Keys: []string{"ctrl+v"}})
+	stats.RegisterVisual(Visual{Mode: VisualLine, Keys: []string{"V"}})
+
+	if stats.TotalKeystrokes != 2 {
+		t.Errorf("expected TotalKeystrokes to be 2, got %d", stats.TotalKeystrokes)
+	}
+	if want := map[string]int{"ctrl+v": 1, "V": 1}; !reflect.DeepEqual(stats.KeyPresses, want) {
+		t.Errorf("expected KeyPresses to be %v, got %v", want, stats.KeyPresses)
+	}
+}
+
+func TestStats_RegisterHistory(t *testing.T) {
+	stats := NewStats()
+	stats.RegisterHistory(History{Kind: HistoryUndo, Count: 2, Keys: []string{"2", "u"}})
+	stats.RegisterHistory(History{Kind: HistoryRepeat, Keys: []string{"."}})
+	stats.RegisterHistory(History{Kind: HistoryRepeat, Keys: []string{"."}})
+
+	if stats.TotalKeystrokes != 4 {
+		t.Errorf("expected TotalKeystrokes to be 4, got %d", stats.TotalKeystrokes)
+	}
+	if stats.RepeatKeystrokes != 2 {
+		t.Errorf("expected RepeatKeystrokes to be 2, got %d", stats.RepeatKeystrokes)
+	}
+	if want := map[string]int{"2u": 1, ".": 2}; !reflect.DeepEqual(stats.KeyPresses, want) {
+		t.Errorf("expected KeyPresses to be %v, got %v", want, stats.KeyPresses)
+	}
+}
+
+func TestStats_RegisterMacro(t *testing.T) {
+	stats := NewStats()
+	stats.RegisterMacro(Macro{Kind: MacroRecord, Register: 'a', Keys: []string{"q", "a"}})
+	stats.RegisterMacro(Macro{Kind: MacroRecord, Keys: []string{"q"}})
+	stats.RegisterMacro(Macro{Kind: MacroReplay, Register: 'a', Count: 19, Keys: []string{"1", "9", "@", "a"}})
+
+	if stats.TotalKeystrokes != 7 {
+		t.Errorf("expected TotalKeystrokes to be 7, got %d", stats.TotalKeystrokes)
+	}
+	if want := map[string]int{"qa": 1, "q": 1, "19@a": 1}; !reflect.DeepEqual(stats.KeyPresses, want) {
+		t.Errorf("expected KeyPresses to be %v, got %v", want, stats.KeyPresses)
+	}
+}
+
 func TestStats_IncrementTime(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -118,6 +249,24 @@ func TestStats_IncrementTime(t *testing.T) {
 	}
 }
 
+func TestStats_RegisterHint(t *testing.T) {
+	stats := NewStats()
+	stats.RegisterHint()
+	stats.RegisterHint()
+
+	if stats.Hints != 2 {
+		t.Errorf("expected Hints to be 2, got %d", stats.Hints)
+	}
+	if stats.TotalKeystrokes != 0 {
+		t.Errorf("expected TotalKeystrokes to be 0, got %d", stats.TotalKeystrokes)
+	}
+
+	stats.Reset()
+	if stats.Hints != 0 {
+		t.Errorf("expected Hints to be 0 after a reset, got %d", stats.Hints)
+	}
+}
+
 func TestStats_Reset(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -199,12 +348,25 @@ func TestLifetimeStats_Merge(t *testing.T) {
 	stats2 := NewStats()
 	stats2.RegisterKey("h", true)
 	stats2.RegisterKey("k", true)
+	stats2.RegisterCommandKey()
+	stats2.RegisterCommandKey()
+	stats2.RegisterInsertKey()
+	stats2.RegisterHint()
 	stats2.IncrementTime()
 	lifetime.Merge(*stats2)
 
 	if lifetime.TotalKeystrokes != 5 {
 		t.Errorf("expected TotalKeystrokes to be 5, got %d", lifetime.TotalKeystrokes)
 	}
+	if lifetime.CommandKeystrokes != 2 {
+		t.Errorf("expected CommandKeystrokes to be 2, got %d", lifetime.CommandKeystrokes)
+	}
+	if lifetime.InsertKeystrokes != 1 {
+		t.Errorf("expected InsertKeystrokes to be 1, got %d", lifetime.InsertKeystrokes)
+	}
+	if lifetime.Hints != 1 {
+		t.Errorf("expected Hints to be 1, got %d", lifetime.Hints)
+	}
 	if lifetime.TotalPlaytime != 3 {
 		t.Errorf("expected TotalPlaytime to be 3, got %d", lifetime.TotalPlaytime)
 	}
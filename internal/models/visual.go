@@ -0,0 +1,50 @@
+package models
+
+import "strings"
+
+// VisualMode identifies the way text is selected in visual mode
+type VisualMode uint8
+
+const (
+	// VisualNone means the level is not in visual mode
+	VisualNone VisualMode = iota
+	// VisualChar selects the characters from where visual mode started up to the cursor
+	VisualChar
+	// VisualLine selects whole lines
+	VisualLine
+	// VisualBlock selects a rectangle of columns
+	VisualBlock
+)
+
+// VisualModes contains all modes that select text
+var VisualModes = []VisualMode{VisualChar, VisualLine, VisualBlock}
+
+// Message returns the message Vim shows while the mode is active
+func (m VisualMode) Message() string {
+	switch m {
+	case VisualChar:
+		return "-- VISUAL --"
+	case VisualLine:
+		return "-- VISUAL LINE --"
+	case VisualBlock:
+		return "-- VISUAL BLOCK --"
+	default:
+		return ""
+	}
+}
+
+// Visual represents a command entered by the player that starts or leaves visual mode
+type Visual struct {
+	Mode VisualMode
+	Keys []string
+}
+
+// String returns the keys that were typed for the visual command
+func (v Visual) String() string {
+	return strings.Join(v.Keys, "")
+}
+
+// Keystrokes returns the number of keys that were typed for the visual command
+func (v Visual) Keystrokes() int {
+	return len(v.Keys)
+}
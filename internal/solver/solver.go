@@ -0,0 +1,263 @@
+package solver
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// maxCount is the largest count typed in front of a motion, a motion is only repeated until
+// a larger count no longer moves the cursor any further
+const maxCount = 99
+
+// Solution is the shortest way to move the cursor from one position to another
+type Solution struct {
+	Motions    []models.Motion
+	Keystrokes int
+}
+
+// Hint returns the keys of the first motion of the solution, it is empty when the cursor
+// is already on the target
+func (s Solution) Hint() string {
+	if len(s.Motions) == 0 {
+		return ""
+	}
+	return s.Motions[0].String()
+}
+
+// Supported reports whether the solver moves the cursor with the motion. Motions that depend on the window,
+// on an earlier motion or on a pattern, like H, ctrl+d, ;, n and the marks, are left out
+func Supported(kind models.MotionKind) bool {
+	switch kind {
+	case models.MotionLeft, models.MotionRight, models.MotionUp, models.MotionDown,
+		models.MotionWordForward, models.MotionWordBackward, models.MotionWordEnd,
+		models.MotionWORDForward, models.MotionWORDBackward, models.MotionWORDEnd,
+		models.MotionStartOfLine, models.MotionFirstNonBlank, models.MotionEndOfLine,
+		models.MotionFirstLine, models.MotionLastLine,
+		models.MotionFindForward, models.MotionFindBackward, models.MotionTillForward, models.MotionTillBackward,
+		models.MotionSentenceForward, models.MotionSentenceBackward,
+		models.MotionParagraphForward, models.MotionParagraphBackward, models.MotionMatchPair:
+		return true
+	default:
+		return false
+	}
+}
+
+// visit holds the motion a position was reached with from an earlier position, the keys of the motion
+// are only typed out for the motions of the Solution so the search does not build them for every motion
+type visit struct {
+	motion models.Motion
+	ex     bool // the line is jumped to with the ex command :{n}
+	from   models.Position
+}
+
+// search holds the buffer that is searched and the motions that move the cursor over it,
+// the positions are kept in slices by their index in the buffer
+type search struct {
+	buf      *vim.Buffer
+	width    int
+	allowed  []models.MotionKind
+	lines    bool                      // the lines are jumped to by their number, with gg, G or :{n}
+	ex       bool                      // :{n} jumps to a line, it is allowed along with G
+	keys     map[models.MotionKind]int // the number of keys of the allowed motions without a count or character
+	cost     []int
+	previous []visit
+	// a bucket holds the positions that are reached with as many keystrokes as its index
+	buckets [][]models.Position
+}
+
+// index returns the index of the position in the slices of the search
+func (s *search) index(pos models.Position) int {
+	return pos.Y*s.width + pos.X
+}
+
+// Solve returns the Solution with the fewest keystrokes that moves the cursor from start onto the target with
+// the allowed motions, and whether the target can be reached with them. Every position of the buffer is a node
+// and every motion that moves the cursor is an edge that costs its keystrokes, the search visits the positions
+// in the order of the keystrokes it takes to reach them so the first time the target is visited is the shortest
+func Solve(buf *vim.Buffer, start, target models.Position, allowed []models.MotionKind) (Solution, bool) {
+	s := &search{
+		buf:     buf,
+		width:   max(buf.Width(), 1),
+		allowed: allowed,
+		lines:   slices.Contains(allowed, models.MotionFirstLine) || slices.Contains(allowed, models.MotionLastLine),
+		ex:      slices.Contains(allowed, models.MotionLastLine),
+		keys:    make(map[models.MotionKind]int),
+		buckets: [][]models.Position{{start}},
+	}
+	for _, kind := range allowed {
+		s.keys[kind] = len(kind.Keys())
+	}
+	nodes := s.width * buf.LineCount()
+	if s.index(start) < 0 || s.index(start) >= nodes {
+		return Solution{}, false
+	}
+	s.cost = make([]int, nodes)
+	for i := range s.cost {
+		s.cost[i] = -1
+	}
+	s.cost[s.index(start)] = 0
+	s.previous = make([]visit, nodes)
+
+	for keystrokes := 0; keystrokes < len(s.buckets); keystrokes++ {
+		for _, pos := range s.buckets[keystrokes] {
+			if s.cost[s.index(pos)] < keystrokes {
+				// the position was reached with fewer keystrokes after it was put in the bucket
+				continue
+			}
+			if pos == target {
+				return s.solution(start, target, keystrokes), true
+			}
+			s.expand(pos, keystrokes)
+		}
+	}
+	return Solution{}, false
+}
+
+// reach records that the motion moves the cursor from pos to a position with keystrokes in total,
+// unless the position was already reached with as few
+func (s *search) reach(pos, to models.Position, motion models.Motion, ex bool, keystrokes int) {
+	if known := s.cost[s.index(to)]; known >= 0 && known <= keystrokes {
+		return
+	}
+	s.cost[s.index(to)] = keystrokes
+	s.previous[s.index(to)] = visit{motion: motion, ex: ex, from: pos}
+	for len(s.buckets) <= keystrokes {
+		s.buckets = append(s.buckets, nil)
+	}
+	s.buckets[keystrokes] = append(s.buckets[keystrokes], to)
+}
+
+// solution follows the visits back from the target to the start and types out the keys of their motions
+func (s *search) solution(start, target models.Position, keystrokes int) Solution {
+	var motions []models.Motion
+	for pos := target; pos != start; pos = s.previous[s.index(pos)].from {
+		v := s.previous[s.index(pos)]
+		motion := newMotion(v.motion.Kind, v.motion.Count, v.motion.Char)
+		if v.ex {
+			motion.Keys = models.ExJumpKeys(strconv.Itoa(v.motion.Count))
+		}
+		motions = append(motions, motion)
+	}
+	slices.Reverse(motions)
+	return Solution{Motions: motions, Keystrokes: keystrokes}
+}
+
+// expand reaches the positions the motions move the cursor to from pos, which took keystrokes to reach
+func (s *search) expand(pos models.Position, keystrokes int) {
+	for _, kind := range s.allowed {
+		switch {
+		case !Supported(kind):
+			continue
+		case kind == models.MotionFirstLine || kind == models.MotionLastLine:
+			// the lines are jumped to once for both motions
+			continue
+		case kind.IsFind():
+			var chars []rune
+			for _, r := range s.buf.Line(pos.Y) {
+				if !slices.Contains(chars, r) {
+					chars = append(chars, r)
+					s.repeat(pos, keystrokes, kind, r)
+				}
+			}
+		case kind == models.MotionStartOfLine || kind == models.MotionFirstNonBlank || kind == models.MotionMatchPair:
+			motion := models.Motion{Kind: kind}
+			if to, moved := vim.Resolve(s.buf, pos, motion); moved {
+				s.reach(pos, to, motion, false, keystrokes+s.keys[kind])
+			}
+		default:
+			s.repeat(pos, keystrokes, kind, 0)
+		}
+	}
+	if s.lines {
+		for n := 1; n <= s.buf.LineCount(); n++ {
+			motion, ex, keys := s.lineJump(n)
+			if to, moved := vim.Resolve(s.buf, pos, motion); moved {
+				s.reach(pos, to, motion, ex, keystrokes+keys)
+			}
+		}
+	}
+}
+
+// lineJump returns the motion with the fewest keys that jumps to line n, whether it is the ex command :{n}
+// and its keys. Every line is reached by its number with gg, G and :{n} alike, the count does not repeat
+// these motions. Like in the levels :{n} costs the colon, the digits and enter, so {n}G is never more keys
+func (s *search) lineJump(n int) (models.Motion, bool, int) {
+	best, ex, keys := models.Motion{}, false, -1
+	consider := func(motion models.Motion, isEx bool, cost int) {
+		if keys < 0 || cost < keys {
+			best, ex, keys = motion, isEx, cost
+		}
+	}
+	if slices.Contains(s.allowed, models.MotionLastLine) {
+		if n == s.buf.LineCount() {
+			consider(models.Motion{Kind: models.MotionLastLine}, false, s.keys[models.MotionLastLine])
+		}
+		consider(models.Motion{Kind: models.MotionLastLine, Count: n}, false, digits(n)+s.keys[models.MotionLastLine])
+	}
+	if slices.Contains(s.allowed, models.MotionFirstLine) {
+		if n == 1 {
+			consider(models.Motion{Kind: models.MotionFirstLine}, false, s.keys[models.MotionFirstLine])
+		}
+		consider(models.Motion{Kind: models.MotionFirstLine, Count: n}, false, digits(n)+s.keys[models.MotionFirstLine])
+	}
+	if s.ex {
+		consider(models.Motion{Kind: models.MotionLastLine, Count: n}, true, exKeys(n))
+	}
+	return best, ex, keys
+}
+
+// exKeys returns the number of keys typed for the ex command :{n} that jumps to line n
+func exKeys(n int) int {
+	return len(models.ExJumpKeys(strconv.Itoa(n)))
+}
+
+// repeat reaches the positions the motion of the kind moves the cursor to from pos without a count
+// and with every count that moves the cursor further than the count before it
+func (s *search) repeat(pos models.Position, keystrokes int, kind models.MotionKind, char rune) {
+	keys := s.keys[kind]
+	if char != 0 {
+		keys++
+	}
+	last := pos
+	vim.Counts(s.buf, pos, models.Motion{Kind: kind, Char: char}, maxCount, func(count int, to models.Position, moved bool) bool {
+		if !moved || to == last {
+			// a wall can keep the cursor from the end of one line but not of the next
+			return kind == models.MotionEndOfLine && pos.Y+count < s.buf.LineCount()
+		}
+		motion := models.Motion{Kind: kind, Count: count, Char: char}
+		if count == 1 {
+			// a count of one moves the cursor as far as no count
+			motion.Count = 0
+		}
+		s.reach(pos, to, motion, false, keystrokes+keys+digits(motion.Count))
+		last = to
+		return true
+	})
+}
+
+// digits returns the number of keys typed for the count, none for a count of zero
+func digits(count int) int {
+	n := 0
+	for ; count > 0; count /= 10 {
+		n++
+	}
+	return n
+}
+
+// newMotion returns the motion of the kind with the keys that are typed for it
+func newMotion(kind models.MotionKind, count int, char rune) models.Motion {
+	var keys []string
+	if count > 0 {
+		for _, digit := range strconv.Itoa(count) {
+			keys = append(keys, string(digit))
+		}
+	}
+	keys = append(keys, kind.Keys()...)
+	if char != 0 {
+		keys = append(keys, string(char))
+	}
+	return models.Motion{Kind: kind, Count: count, Char: char, Keys: keys}
+}
@@ -0,0 +1,196 @@
+package solver
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+	"github.com/dasvh/go-learn-vim/internal/vim"
+)
+
+// maze is the text of a level with walls, it is the maze of the embedded walls level
+var maze = []string{
+	"###############",
+	"#     #       #",
+	"# ### # ##### #",
+	"#   #   #     #",
+	"###############",
+}
+
+// wallBuffer returns a Buffer of the lines where # is a wall
+func wallBuffer(lines []string) *vim.Buffer {
+	buf := vim.NewBuffer(lines)
+	buf.SetWalls(func(pos models.Position) bool {
+		line := buf.Line(pos.Y)
+		return pos.X >= 0 && pos.X < len(line) && line[pos.X] == '#'
+	})
+	return buf
+}
+
+func Test_Solve(t *testing.T) {
+	numbered := make([]string, 20)
+	for y := range numbered {
+		numbered[y] = fmt.Sprintf("line %d", y+1)
+	}
+	basic := []models.MotionKind{models.MotionLeft, models.MotionDown, models.MotionUp, models.MotionRight}
+
+	tests := []struct {
+		name           string
+		buf            *vim.Buffer
+		start          models.Position
+		target         models.Position
+		allowed        []models.MotionKind
+		wantOK         bool
+		wantKeystrokes int
+		wantHint       string
+	}{
+		{
+			name:    "already on the target",
+			buf:     vim.NewBuffer([]string{"abc"}),
+			start:   models.Position{X: 1},
+			target:  models.Position{X: 1},
+			allowed: basic,
+			wantOK:  true,
+		},
+		{
+			name:           "count in front of a motion",
+			buf:            vim.NewBuffer([]string{"abcdefghij"}),
+			target:         models.Position{X: 9},
+			allowed:        basic,
+			wantOK:         true,
+			wantKeystrokes: 2,
+			wantHint:       "9l",
+		},
+		{
+			name:           "end of line stops at a wall",
+			buf:            wallBuffer(maze),
+			start:          models.Position{X: 1, Y: 1},
+			target:         models.Position{X: 5, Y: 1},
+			allowed:        append([]models.MotionKind{models.MotionStartOfLine, models.MotionEndOfLine}, basic...),
+			wantOK:         true,
+			wantKeystrokes: 1,
+			wantHint:       "$",
+		},
+		{
+			name:           "around the walls",
+			buf:            wallBuffer(maze),
+			start:          models.Position{X: 7, Y: 3},
+			target:         models.Position{X: 13, Y: 1},
+			allowed:        append([]models.MotionKind{models.MotionStartOfLine, models.MotionEndOfLine}, basic...),
+			wantOK:         true,
+			wantKeystrokes: 3,
+			wantHint:       "2k",
+		},
+		{
+			name:    "walled in",
+			buf:     wallBuffer(maze),
+			start:   models.Position{X: 1, Y: 1},
+			target:  models.Position{X: 13, Y: 3},
+			allowed: []models.MotionKind{models.MotionRight, models.MotionLeft},
+			wantOK:  false,
+		},
+		{
+			name:           "find a character",
+			buf:            vim.NewBuffer([]string{"a b c d x y"}),
+			target:         models.Position{X: 8},
+			allowed:        append([]models.MotionKind{models.MotionFindForward}, basic...),
+			wantOK:         true,
+			wantKeystrokes: 2,
+			wantHint:       "fx",
+		},
+		{
+			name:           "multi-digit line jump",
+			buf:            vim.NewBuffer(numbered),
+			target:         models.Position{Y: 14},
+			allowed:        []models.MotionKind{models.MotionLastLine},
+			wantOK:         true,
+			wantKeystrokes: 3,
+			wantHint:       "15G",
+		},
+		{
+			name:           "gg with a count",
+			buf:            vim.NewBuffer(numbered),
+			target:         models.Position{Y: 14},
+			allowed:        []models.MotionKind{models.MotionFirstLine},
+			wantOK:         true,
+			wantKeystrokes: 4,
+			wantHint:       "15gg",
+		},
+		{
+			name:    "unsupported motions are not used",
+			buf:     vim.NewBuffer(numbered),
+			target:  models.Position{Y: 14},
+			allowed: []models.MotionKind{models.MotionSearchForward, models.MotionScreenBottom},
+			wantOK:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Solve(tt.buf, tt.start, tt.target, tt.allowed)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok %v, got %v", tt.wantOK, ok)
+			}
+			if got.Keystrokes != tt.wantKeystrokes {
+				t.Errorf("expected %d keystrokes, got %d", tt.wantKeystrokes, got.Keystrokes)
+			}
+			if hint := got.Hint(); hint != tt.wantHint {
+				t.Errorf("expected hint %q, got %q", tt.wantHint, hint)
+			}
+
+			// the motions of the solution move the cursor onto the target with the keystrokes
+			pos, keystrokes := tt.start, 0
+			for _, motion := range got.Motions {
+				pos, _ = vim.Resolve(tt.buf, pos, motion)
+				keystrokes += motion.Keystrokes()
+			}
+			if ok && (pos != tt.target || keystrokes != got.Keystrokes) {
+				t.Errorf("expected the motions to reach %v in %d keystrokes, got %v in %d", tt.target, got.Keystrokes, pos, keystrokes)
+			}
+		})
+	}
+}
+
+func Test_LineJump(t *testing.T) {
+	numbered := make([]string, 120)
+	for y := range numbered {
+		numbered[y] = fmt.Sprintf("line %d", y+1)
+	}
+
+	tests := []struct {
+		name     string
+		allowed  []models.MotionKind
+		line     int
+		wantHint string
+		wantKeys int
+	}{
+		{name: "last line", allowed: []models.MotionKind{models.MotionLastLine}, line: 120, wantHint: "G", wantKeys: 1},
+		{name: "G with a count", allowed: []models.MotionKind{models.MotionLastLine}, line: 15, wantHint: "15G", wantKeys: 3},
+		{name: "first line", allowed: []models.MotionKind{models.MotionFirstLine}, line: 1, wantHint: "gg", wantKeys: 2},
+		{name: "gg with a count", allowed: []models.MotionKind{models.MotionFirstLine}, line: 105, wantHint: "105gg", wantKeys: 5},
+		{name: "G is fewer keys than gg", allowed: []models.MotionKind{models.MotionFirstLine, models.MotionLastLine}, line: 15, wantHint: "15G", wantKeys: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &search{buf: vim.NewBuffer(numbered), allowed: tt.allowed, ex: slices.Contains(tt.allowed, models.MotionLastLine), keys: make(map[models.MotionKind]int)}
+			for _, kind := range tt.allowed {
+				s.keys[kind] = len(kind.Keys())
+			}
+			motion, ex, keys := s.lineJump(tt.line)
+			if hint := newMotion(motion.Kind, motion.Count, 0).String(); ex || hint != tt.wantHint || keys != tt.wantKeys {
+				t.Errorf("expected %s in %d keys, got %s in %d (ex %v)", tt.wantHint, tt.wantKeys, hint, keys, ex)
+			}
+		})
+	}
+}
+
+func Test_ExKeys(t *testing.T) {
+	// the colon, the digits and enter
+	for line, want := range map[int]int{7: 3, 15: 4, 120: 5} {
+		if got := exKeys(line); got != want {
+			t.Errorf("expected :%d to cost %d keys, got %d", line, want, got)
+		}
+	}
+}
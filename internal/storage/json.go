@@ -162,13 +162,18 @@ func (repo *JSONRepository) PlayerLifetimeStats(playerID string) (*models.Lifeti
 	return lifetimeStats, nil
 }
 
-// ComputeHighScores computes high scores for the repository
+// ComputeHighScores computes high scores for the repository, a dot that repeats a change
+// costs less than any other keystroke, every hint costs more than a keystroke
+// and completing a level within its par earns a bonus
 func (repo *JSONRepository) ComputeHighScores() ([]models.HighScore, error) {
 	const (
-		BaseScore       = 25000
-		TimeWeight      = 177
-		KeystrokeWeight = 17
-		MinScore        = 5000
+		BaseScore             = 25000
+		TimeWeight            = 177
+		KeystrokeWeight       = 17
+		RepeatKeystrokeWeight = 5
+		HintWeight            = 250
+		MinScore              = 5000
+		ParBonus              = 2500
 	)
 	var highScores []models.HighScore
 
@@ -177,13 +182,20 @@ func (repo *JSONRepository) ComputeHighScores() ([]models.HighScore, error) {
 			gameStats := save.GameState.(models.AdventureGameState).Stats
 			if gameStats.TimeElapsed > 0 && gameStats.TotalKeystrokes > 0 {
 				timePenalty := gameStats.TimeElapsed * TimeWeight
-				keystrokePenalty := gameStats.TotalKeystrokes * KeystrokeWeight
-				score := max(BaseScore-timePenalty-keystrokePenalty, MinScore)
+				repeats := min(gameStats.RepeatKeystrokes, gameStats.TotalKeystrokes)
+				keystrokePenalty := (gameStats.TotalKeystrokes-repeats)*KeystrokeWeight + repeats*RepeatKeystrokeWeight
+				hintPenalty := gameStats.Hints * HintWeight
+				score := max(BaseScore-timePenalty-keystrokePenalty-hintPenalty, MinScore)
+				if par := save.GameState.(models.AdventureGameState).Level.Par; par > 0 && gameStats.TotalKeystrokes <= par {
+					score += ParBonus
+				}
 				highScores = append(highScores, models.HighScore{
 					PlayerName: save.Player.Name,
 					Level:      save.GameState.(models.AdventureGameState).Level.Number,
 					Score:      score,
 					Timestamp:  save.Timestamp,
+					SaveID:     save.ID,
+					Run:        save.GameState.(models.AdventureGameState).Run,
 				})
 			}
 		}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/dasvh/go-learn-vim/internal/models"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -89,6 +90,100 @@ func Test_JSONRepository_ComputeHighScores(t *testing.T) {
 	}
 }
 
+func Test_JSONRepository_ComputeHighScoresWeights(t *testing.T) {
+	// a completed game of 30 seconds and 70 keystrokes without any weights scores this
+	const baseScore = 25000 - (30 * 177) - (70 * 17)
+
+	tests := []struct {
+		name   string
+		adjust func(state *models.AdventureGameState)
+		want   int
+	}{
+		{
+			name:   "repeats cost 5 instead of 17",
+			adjust: func(state *models.AdventureGameState) { state.Stats.RepeatKeystrokes = 20 },
+			want:   baseScore + (20 * 17) - (20 * 5),
+		},
+		{
+			name:   "every hint costs 250",
+			adjust: func(state *models.AdventureGameState) { state.Stats.Hints = 3 },
+			want:   baseScore - (3 * 250),
+		},
+		{
+			name:   "within par",
+			adjust: func(state *models.AdventureGameState) { state.Level.Par = 70 },
+			want:   baseScore + 2500,
+		},
+		{
+			name:   "over par",
+			adjust: func(state *models.AdventureGameState) { state.Level.Par = 69 },
+			want:   baseScore,
+		},
+		{
+			name:   "without par",
+			adjust: func(state *models.AdventureGameState) {},
+			want:   baseScore,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestJSONRepository(t)
+			game := createTestGameSaveWithID(models.Player{ID: "p1", Name: "Player 1"}, "g1", 1, 30, 70, true)
+			state := game.GameState.(models.AdventureGameState)
+			tt.adjust(&state)
+			game.GameState = state
+			repo.SaveGame(game)
+
+			scores, err := repo.ComputeHighScores()
+			if err != nil {
+				t.Fatalf("Failed to compute high scores: %v", err)
+			}
+			if len(scores) != 1 || scores[0].Score != tt.want {
+				t.Errorf("Expected a single score of %d, got %v", tt.want, scores)
+			}
+		})
+	}
+}
+
+func Test_JSONRepository_ComputeHighScoresRun(t *testing.T) {
+	repo := newTestJSONRepository(t)
+	game := createTestGameSaveWithID(models.Player{ID: "p1", Name: "Player 1"}, "g1", 1, 30, 70, true)
+	state := game.GameState.(models.AdventureGameState)
+	state.Run = models.Run{Width: 40, Height: 20, Steps: []models.RunStep{{Elapsed: time.Second, Keys: []string{"l"}, Position: models.Position{X: 1}}}}
+	game.GameState = state
+	repo.SaveGame(game)
+
+	scores, err := repo.ComputeHighScores()
+	if err != nil {
+		t.Fatalf("Failed to compute high scores: %v", err)
+	}
+	if len(scores) != 1 || !reflect.DeepEqual(scores[0].Run, state.Run) {
+		t.Errorf("Expected the score to hold the run %v, got %v", state.Run, scores)
+	}
+}
+
+// newTestJSONRepository returns a JSONRepository without players and saves in a temporary file
+func newTestJSONRepository(t *testing.T) *JSONRepository {
+	t.Helper()
+	tempFile, err := os.CreateTemp("", "test_repo_*.json")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	t.Cleanup(func() { os.Remove(tempFile.Name()) })
+
+	if _, err := tempFile.Write([]byte(`{"players":[],"saves":[]}`)); err != nil {
+		t.Fatalf("Failed to write initial JSON: %v", err)
+	}
+	tempFile.Close()
+
+	repo, err := NewJSONRepository(tempFile.Name())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	return repo
+}
+
 func createTestGameSaveWithID(player models.Player, id string, level int,
 	timeElapsed int, keystrokes int, completed bool) models.GameSave {
 	stats := models.Stats{
@@ -0,0 +1,371 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/dasvh/go-learn-vim/internal/maze"
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// LevelFileExt is the extension of level files
+const LevelFileExt = ".toml"
+
+const (
+	// mazeWall is the wall of a maze level that has no wall of its own
+	mazeWall = '#'
+	// mazeMinSize and mazeMaxSize limit the size of a maze level
+	mazeMinSize = 3
+	mazeMaxSize = 30
+)
+
+// ParseLevelFile parses a level file into a models.LevelDefinition. A level file is written in TOML:
+//
+//	number = 100
+//	description = "Walk around the pillar"
+//	lesson = "hjkl"
+//	allowed = ["basic", "w"]
+//	wall = "#"
+//	start = [1, 1]
+//	text = '''
+//	#######
+//	#  #  #
+//	#######
+//	'''
+//
+//	[[targets]]
+//	position = [5, 1]
+//	par = 6
+//	hint = "the cursor cannot move onto #"
+//
+// The allowed motions are named by their keys or by the name of their group, wall and start are optional
+// and so are the par and hint of a target. Positions are columns and lines counted from zero.
+//
+// Instead of a text a level can have a maze that is generated from a seed, it is carved by one of the
+// algorithms of maze.Names with corridors of path_width, which is optional:
+//
+//	[maze]
+//	algorithm = "division"
+//	size = 21
+//	seed = 7
+//	path_width = 1
+//
+// A maze level without a start starts in its top-left corner, and without targets it gets a single target
+// on the position that is the farthest from the start
+func ParseLevelFile(data []byte) (models.LevelDefinition, error) {
+	var doc levelDocument
+	if err := decodeTOML(data, &doc); err != nil {
+		return models.LevelDefinition{}, err
+	}
+	r := &levelReader{}
+
+	def := models.LevelDefinition{
+		Number:      required(r, "number", doc.Number),
+		Description: required(r, "description", doc.Description),
+		Lesson:      required(r, "lesson", doc.Lesson),
+	}
+	if doc.Maze != nil && doc.Text != nil {
+		r.fail("text", "a level has either a text or a maze")
+	} else if doc.Maze != nil {
+		r.table = "maze."
+		def.Maze = &models.MazeDefinition{
+			Algorithm: required(r, "algorithm", doc.Maze.Algorithm),
+			Size:      required(r, "size", doc.Maze.Size),
+			Seed:      doc.Maze.Seed,
+			PathWidth: doc.Maze.PathWidth,
+		}
+		r.table = ""
+	} else {
+		text := strings.TrimSuffix(required(r, "text", doc.Text), "\n")
+		def.Text = strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	}
+	if wall := []rune(doc.Wall); len(wall) > 1 {
+		r.fail("wall", "wall has to be a single character")
+	} else if len(wall) == 1 {
+		def.Wall = wall[0]
+	}
+	if doc.Start != nil {
+		def.Start = r.position("start", doc.Start)
+	}
+	if doc.Allowed == nil {
+		r.fail("allowed", "allowed is missing")
+	}
+	for _, name := range doc.Allowed {
+		kinds, ok := models.ParseMotions(name)
+		if !ok {
+			r.fail("allowed", "unknown motion %q in allowed", name)
+		}
+		for _, kind := range kinds {
+			if !slices.Contains(def.Allowed, kind) {
+				def.Allowed = append(def.Allowed, kind)
+			}
+		}
+	}
+	for i, target := range doc.Targets {
+		r.table = TargetKey(i, "") + "."
+		def.Targets = append(def.Targets, models.TargetDefinition{
+			Position: r.position("position", target.Position),
+			Par:      target.Par,
+			Hint:     target.Hint,
+		})
+	}
+	if r.err != nil {
+		return models.LevelDefinition{}, r.err
+	}
+	if def.Maze != nil {
+		if err := generateMaze(&def, doc.Start != nil); err != nil {
+			return models.LevelDefinition{}, err
+		}
+	}
+	return def, ValidateLevel(def)
+}
+
+// levelDocument is a level file as it is decoded from TOML, the keys that are required
+// are pointers and the start is a slice to tell whether they are there
+type levelDocument struct {
+	Number      *int             `toml:"number"`
+	Description *string          `toml:"description"`
+	Lesson      *string          `toml:"lesson"`
+	Allowed     []string         `toml:"allowed"`
+	Wall        string           `toml:"wall"`
+	Start       []int            `toml:"start"`
+	Text        *string          `toml:"text"`
+	Maze        *mazeDocument    `toml:"maze"`
+	Targets     []targetDocument `toml:"targets"`
+}
+
+// mazeDocument is the maze table of a level file
+type mazeDocument struct {
+	Algorithm *string `toml:"algorithm"`
+	Size      *int    `toml:"size"`
+	Seed      int64   `toml:"seed"`
+	PathWidth int     `toml:"path_width"`
+}
+
+// targetDocument is a table of the targets of a level file
+type targetDocument struct {
+	Position []int  `toml:"position"`
+	Par      int    `toml:"par"`
+	Hint     string `toml:"hint"`
+}
+
+// generateMaze generates the text of the level from its maze, the walls are drawn as # unless the level
+// has its own wall. Without a start the level starts in the top-left corner of the maze, and a level
+// without targets gets one on the open position that is the farthest from the start
+func generateMaze(def *models.LevelDefinition, hasStart bool) error {
+	generator, ok := maze.Named(def.Maze.Algorithm)
+	if !ok {
+		return keyError("maze.algorithm", "unknown maze algorithm %q, it is one of %s",
+			def.Maze.Algorithm, strings.Join(maze.Names(), ", "))
+	}
+	if def.Maze.PathWidth == 0 {
+		def.Maze.PathWidth = 1
+	}
+	switch {
+	case def.Maze.Size < mazeMinSize || def.Maze.Size > mazeMaxSize:
+		return keyError("maze.size", "size of the maze has to be between %d and %d", mazeMinSize, mazeMaxSize)
+	case def.Maze.PathWidth < 0 || maze.Cells(def.Maze.Size, def.Maze.PathWidth) == 0:
+		return keyError("maze.path_width", "path_width of the maze leaves no room for a corridor")
+	}
+
+	if def.Wall == 0 {
+		def.Wall = mazeWall
+	}
+	carved := maze.New(generator, def.Maze.Size, def.Maze.PathWidth, rand.New(rand.NewSource(def.Maze.Seed)))
+	def.Text = carved.Lines(def.Wall)
+	if !hasStart {
+		def.Start = carved.Start()
+	}
+	if len(def.Targets) == 0 {
+		def.Targets = []models.TargetDefinition{{Position: carved.Farthest(def.Start)}}
+	}
+	return nil
+}
+
+// FormatLevelFile writes the models.LevelDefinition in the format ParseLevelFile reads
+func FormatLevelFile(def models.LevelDefinition) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "number = %d\n", def.Number)
+	fmt.Fprintf(&sb, "description = %s\n", tomlString(def.Description))
+	fmt.Fprintf(&sb, "lesson = %s\n", tomlString(def.Lesson))
+	names := models.MotionNames(def.Allowed)
+	for i, name := range names {
+		names[i] = tomlString(name)
+	}
+	fmt.Fprintf(&sb, "allowed = [%s]\n", strings.Join(names, ", "))
+	if def.Wall != 0 {
+		fmt.Fprintf(&sb, "wall = %s\n", tomlString(string(def.Wall)))
+	}
+	fmt.Fprintf(&sb, "start = [%d, %d]\n", def.Start.X, def.Start.Y)
+	text := strings.Join(def.Text, "\n") + "\n"
+	switch {
+	case def.Maze != nil:
+		// the text is generated from the maze again
+		fmt.Fprintf(&sb, "\n[maze]\nalgorithm = %s\nsize = %d\nseed = %d\npath_width = %d\n",
+			tomlString(def.Maze.Algorithm), def.Maze.Size, def.Maze.Seed, def.Maze.PathWidth)
+	case strings.Contains(text, "'''"):
+		fmt.Fprintf(&sb, "text = %s\n", tomlString(text))
+	default:
+		// a literal string keeps the text as it is shown in the level
+		fmt.Fprintf(&sb, "text = '''\n%s'''\n", text)
+	}
+	for _, target := range def.Targets {
+		fmt.Fprintf(&sb, "\n[[targets]]\nposition = [%d, %d]\n", target.Position.X, target.Position.Y)
+		if target.Par > 0 {
+			fmt.Fprintf(&sb, "par = %d\n", target.Par)
+		}
+		if target.Hint != "" {
+			fmt.Fprintf(&sb, "hint = %s\n", tomlString(target.Hint))
+		}
+	}
+	return []byte(sb.String())
+}
+
+// tomlString quotes the text as a basic string
+func tomlString(text string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\t", "\\t")
+	return "\"" + replacer.Replace(text) + "\""
+}
+
+// loadLevelFile reads and parses the named level file of the file system
+func loadLevelFile(fsys fs.FS, name string) (models.LevelDefinition, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return models.LevelDefinition{}, fmt.Errorf("failed to read level file: %w", err)
+	}
+	def, err := ParseLevelFile(data)
+	if err != nil {
+		return models.LevelDefinition{}, fmt.Errorf("%s: %w", path.Base(name), err)
+	}
+	return def, nil
+}
+
+// ValidateLevel checks that the level can be played, every target and the start have to be
+// on a character of the text that is not a wall
+func ValidateLevel(def models.LevelDefinition) error {
+	onText := func(pos models.Position) bool {
+		return pos.Y >= 0 && pos.Y < len(def.Text) && pos.X >= 0 && pos.X < len([]rune(def.Text[pos.Y])) &&
+			[]rune(def.Text[pos.Y])[pos.X] != def.Wall
+	}
+	switch {
+	case def.Number < 0:
+		return keyError("number", "number has to be zero or more")
+	case len(def.Allowed) == 0:
+		return keyError("allowed", "allowed has to name at least one motion")
+	case len(def.Targets) == 0:
+		return keyError("targets", "a level needs at least one target")
+	case !onText(def.Start):
+		return keyError("start", "start %v is not on the text", def.Start)
+	}
+	for i, target := range def.Targets {
+		if !onText(target.Position) {
+			return keyError(TargetKey(i, "position"), "target %d at %v is not on the text", i+1, target.Position)
+		}
+		if target.Par < 0 {
+			return keyError(TargetKey(i, "par"), "par of target %d has to be zero or more", i+1)
+		}
+	}
+	return nil
+}
+
+// LevelError is an error in a level file or a pack manifest. An error in the TOML is on a line,
+// an error in a value names the key of the value instead, which ErrorLine finds the line of
+type LevelError struct {
+	Line    int    // line of the error in the TOML, zero for an error in a value
+	Key     string // key of the value that is wrong, keys of targets are named by TargetKey
+	Message string
+}
+
+// Error returns the message, prefixed by the line when the error is on one
+func (e *LevelError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+	return e.Message
+}
+
+// keyError returns a *LevelError about the value of the key
+func keyError(key, format string, args ...any) error {
+	return &LevelError{Key: key, Message: fmt.Sprintf(format, args...)}
+}
+
+// TargetKey returns the key of a value of the target with the index, like targets.2.par for the par of
+// the second target, or the key of the target itself when key is empty
+func TargetKey(index int, key string) string {
+	name := fmt.Sprintf("targets.%d", index+1)
+	if key == "" {
+		return name
+	}
+	return name + "." + key
+}
+
+// KeyLine returns the line of the key in the TOML data, a key that is missing from a table is looked up
+// as the table itself so a missing par points at its target. It returns zero when the key is not there
+func KeyLine(data []byte, key string) int {
+	lines, err := keyLines(data)
+	if err != nil {
+		return 0
+	}
+	for key != "" {
+		if line, ok := lines[key]; ok {
+			return line
+		}
+		i := strings.LastIndexByte(key, '.')
+		if i < 0 {
+			break
+		}
+		key = key[:i]
+	}
+	return 0
+}
+
+// ErrorLine returns the line of the TOML data the error returned by ParseLevelFile or ParsePackManifest
+// is on, or zero when it is not on a line
+func ErrorLine(data []byte, err error) int {
+	var levelErr *LevelError
+	if !errors.As(err, &levelErr) {
+		return 0
+	}
+	if levelErr.Line > 0 {
+		return levelErr.Line
+	}
+	return KeyLine(data, levelErr.Key)
+}
+
+// levelReader checks the values of a decoded level file, the first error is kept
+// so the values can be checked one after another and the error returned once
+type levelReader struct {
+	err   error
+	table string // prefix of the keys of the table that is read, it is empty for the top-level keys
+}
+
+// fail keeps an error about the value of the key unless there already is an error
+func (r *levelReader) fail(key, format string, args ...any) {
+	if r.err == nil {
+		r.err = keyError(r.table+key, format, args...)
+	}
+}
+
+// required returns the value of the key, or the zero value and an error when the key is missing
+func required[T any](r *levelReader, key string, value *T) T {
+	if value == nil {
+		r.fail(key, "%s is missing", key)
+		var zero T
+		return zero
+	}
+	return *value
+}
+
+// position returns the position of the key from its values, it is written as [column, line]
+func (r *levelReader) position(key string, values []int) models.Position {
+	if len(values) != 2 {
+		r.fail(key, "%s has to be a [column, line] pair", key)
+		return models.Position{}
+	}
+	return models.Position{X: values[0], Y: values[1]}
+}
@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// levelFile is a level file with every key, its text has walls
+const levelFile = `number = 100
+description = "Around the wall"
+lesson = "hjkl and w"
+allowed = ["basic", "w", "j"]
+wall = "#"
+start = [1, 0]
+text = '''
+a b#c
+d e f
+'''
+
+[[targets]]
+position = [4, 0]
+par = 6
+hint = "go around"
+
+[[targets]]
+position = [0, 1]
+`
+
+func Test_ParseLevelFile(t *testing.T) {
+	want := models.LevelDefinition{
+		Number:      100,
+		Description: "Around the wall",
+		Lesson:      "hjkl and w",
+		Text:        []string{"a b#c", "d e f"},
+		Wall:        '#',
+		Start:       models.Position{X: 1, Y: 0},
+		Allowed: []models.MotionKind{
+			models.MotionLeft, models.MotionDown, models.MotionUp, models.MotionRight, models.MotionWordForward,
+		},
+		Targets: []models.TargetDefinition{
+			{Position: models.Position{X: 4, Y: 0}, Par: 6, Hint: "go around"},
+			{Position: models.Position{X: 0, Y: 1}},
+		},
+	}
+
+	got, err := ParseLevelFile([]byte(levelFile))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func Test_ParseLevelFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		replace  [2]string
+		wantErr  string
+		wantLine int
+	}{
+		{name: "invalid TOML", replace: [2]string{"number = 100", "number ="}, wantErr: "line 1: incomplete number", wantLine: 1},
+		{name: "missing key", replace: [2]string{`lesson = "hjkl and w"`, ""}, wantErr: "lesson is missing", wantLine: 0},
+		{name: "wrong type", replace: [2]string{"number = 100", `number = "100"`}, wantErr: "line 1: number has the wrong type, found a TOML string", wantLine: 1},
+		{name: "unknown motion", replace: [2]string{`"basic"`, `"x"`}, wantErr: `unknown motion "x" in allowed`, wantLine: 4},
+		{name: "long wall", replace: [2]string{`wall = "#"`, `wall = "##"`}, wantErr: "wall has to be a single character", wantLine: 5},
+		{name: "start on a wall", replace: [2]string{"start = [1, 0]", "start = [3, 0]"}, wantErr: "start {3 0} is not on the text", wantLine: 6},
+		{name: "target off the text", replace: [2]string{"position = [0, 1]", "position = [0, 2]"}, wantErr: "target 2 at {0 2} is not on the text", wantLine: 18},
+		{name: "invalid position", replace: [2]string{"position = [0, 1]", "position = [0]"}, wantErr: "position has to be a [column, line] pair", wantLine: 18},
+		{name: "negative par", replace: [2]string{"par = 6", "par = -1"}, wantErr: "par of target 1 has to be zero or more", wantLine: 14},
+		{name: "no targets", replace: [2]string{levelFile[strings.Index(levelFile, "[[targets]]"):], ""}, wantErr: "a level needs at least one target", wantLine: 0},
+		{name: "targets as a table", replace: [2]string{"[[targets]]", "[targets]"}, wantErr: "line 17: targets is defined twice", wantLine: 17},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte(strings.Replace(levelFile, tt.replace[0], tt.replace[1], 1))
+			_, err := ParseLevelFile(data)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+			if line := ErrorLine(data, err); line != tt.wantLine {
+				t.Errorf("expected the error on line %d, got %d", tt.wantLine, line)
+			}
+		})
+	}
+}
+
+// mazeFile is a level file with a maze instead of a text
+const mazeFile = `number = 101
+description = "Divided"
+lesson = "counts"
+allowed = ["basic"]
+
+[maze]
+algorithm = "division"
+size = 11
+seed = 7
+`
+
+func Test_ParseLevelFileMaze(t *testing.T) {
+	def, err := ParseLevelFile([]byte(mazeFile))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(def.Text) != 11 || len([]rune(def.Text[0])) != 11 || def.Text[0] != strings.Repeat("#", 11) {
+		t.Errorf("expected an 11x11 maze surrounded by walls, got %q", def.Text)
+	}
+	if def.Wall != '#' || def.Start != (models.Position{X: 1, Y: 1}) {
+		t.Errorf("expected the wall # and the start {1 1}, got %q and %v", def.Wall, def.Start)
+	}
+	if len(def.Targets) != 1 || def.Targets[0].Position == def.Start {
+		t.Errorf("expected a single target away from the start, got %v", def.Targets)
+	}
+	want := &models.MazeDefinition{Algorithm: "division", Size: 11, Seed: 7, PathWidth: 1}
+	if !reflect.DeepEqual(def.Maze, want) {
+		t.Errorf("expected maze %+v, got %+v", want, def.Maze)
+	}
+
+	again, err := ParseLevelFile([]byte(mazeFile))
+	if err != nil || !reflect.DeepEqual(again, def) {
+		t.Errorf("expected the same level for the same seed, got %v", err)
+	}
+	formatted, err := ParseLevelFile(FormatLevelFile(def))
+	if err != nil || !reflect.DeepEqual(formatted, def) {
+		t.Errorf("expected the formatted level to be the same, got %+v and %v", formatted, err)
+	}
+}
+
+func Test_ParseLevelFileMazeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		replace  [2]string
+		wantErr  string
+		wantLine int
+	}{
+		{name: "text and maze", replace: [2]string{"[maze]", "text = 'a'\n[maze]"}, wantErr: "a level has either a text or a maze", wantLine: 6},
+		{name: "unknown algorithm", replace: [2]string{`"division"`, `"spiral"`},
+			wantErr: `unknown maze algorithm "spiral", it is one of braid, dfs, division, kruskal, prim, wilson`, wantLine: 7},
+		{name: "missing size", replace: [2]string{"size = 11", ""}, wantErr: "size is missing", wantLine: 6},
+		{name: "too large", replace: [2]string{"size = 11", "size = 300"}, wantErr: "size of the maze has to be between 3 and 30", wantLine: 8},
+		{name: "too wide", replace: [2]string{"seed = 7", "path_width = 6"}, wantErr: "path_width of the maze leaves no room for a corridor", wantLine: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte(strings.Replace(mazeFile, tt.replace[0], tt.replace[1], 1))
+			_, err := ParseLevelFile(data)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+			if line := ErrorLine(data, err); line != tt.wantLine {
+				t.Errorf("expected the error on line %d, got %d", tt.wantLine, line)
+			}
+		})
+	}
+}
+
+func Test_KeyLine(t *testing.T) {
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{key: "number", want: 1},
+		{key: "text", want: 7},
+		{key: TargetKey(0, ""), want: 12},
+		{key: TargetKey(0, "hint"), want: 15},
+		{key: TargetKey(1, "position"), want: 18},
+		{key: TargetKey(1, "par"), want: 17},
+		{key: "order", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := KeyLine([]byte(levelFile), tt.key); got != tt.want {
+				t.Errorf("expected line %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_FormatLevelFile(t *testing.T) {
+	want, err := ParseLevelFile([]byte(levelFile))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	tests := []struct {
+		name string
+		edit func(def *models.LevelDefinition)
+	}{
+		{name: "level file", edit: func(*models.LevelDefinition) {}},
+		{name: "quotes and no walls", edit: func(def *models.LevelDefinition) {
+			def.Description = `say "hi" \ bye`
+			def.Wall = 0
+			def.Text = []string{"''' ab", "\tcd"}
+			def.Start = models.Position{}
+			def.Targets = []models.TargetDefinition{{Position: models.Position{X: 1}}}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := want
+			tt.edit(&def)
+			got, err := ParseLevelFile(FormatLevelFile(def))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(got, def) {
+				t.Errorf("expected %+v, got %+v", def, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// PackManifestFile is the name of the manifest of a level pack
+const PackManifestFile = "pack" + LevelFileExt
+
+// LevelPacksDir returns the directory level packs of the user are loaded from, which is
+// go-learn-vim/levels in $XDG_DATA_HOME, or in ~/.local/share when it is not set
+func LevelPacksDir() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find the data directory: %w", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "go-learn-vim", "levels"), nil
+}
+
+// ParsePackManifest parses the manifest of a level pack into the pack, without its levels, and the names
+// of the level files it lists. A manifest is written in TOML:
+//
+//	name = "Mazes"
+//	author = "go-learn-vim"
+//	version = "1.0.0"
+//	order = 1
+//	levels = ["walls.toml"]
+//
+// Author, version and order are optional. Levels names the level files of the pack in the order they are played,
+// without it every level file next to the manifest is part of the pack, sorted by file name
+func ParsePackManifest(data []byte) (models.LevelPack, []string, error) {
+	var doc manifestDocument
+	if err := decodeTOML(data, &doc); err != nil {
+		return models.LevelPack{}, nil, err
+	}
+	r := &levelReader{}
+
+	pack := models.LevelPack{
+		Name:    required(r, "name", doc.Name),
+		Author:  doc.Author,
+		Version: doc.Version,
+		Order:   doc.Order,
+	}
+	if r.err != nil {
+		return models.LevelPack{}, nil, r.err
+	}
+	return pack, doc.Levels, nil
+}
+
+// manifestDocument is a pack manifest as it is decoded from TOML, levels is nil when the key is not there
+type manifestDocument struct {
+	Name    *string  `toml:"name"`
+	Author  string   `toml:"author"`
+	Version string   `toml:"version"`
+	Order   int      `toml:"order"`
+	Levels  []string `toml:"levels"`
+}
+
+// LoadLevelPack reads the manifest and the level files of the pack in the directory of the file system
+func LoadLevelPack(fsys fs.FS, dir string) (models.LevelPack, error) {
+	pack, files, err := ReadPackManifest(fsys, dir)
+	if err != nil {
+		return models.LevelPack{}, err
+	}
+	for _, file := range files {
+		def, err := loadLevelFile(fsys, path.Join(dir, file))
+		if err != nil {
+			return models.LevelPack{}, err
+		}
+		pack.Levels = append(pack.Levels, def)
+	}
+	return pack, nil
+}
+
+// ReadPackManifest reads the manifest of the pack in the directory of the file system and returns the pack,
+// without its levels, and the names of its level files in the order they are played
+func ReadPackManifest(fsys fs.FS, dir string) (models.LevelPack, []string, error) {
+	data, err := fs.ReadFile(fsys, path.Join(dir, PackManifestFile))
+	if err != nil {
+		return models.LevelPack{}, nil, fmt.Errorf("failed to read pack manifest: %w", err)
+	}
+	pack, files, err := ParsePackManifest(data)
+	if err != nil {
+		return models.LevelPack{}, nil, fmt.Errorf("%s: %w", PackManifestFile, err)
+	}
+
+	if files == nil {
+		// fs.Glob returns the names sorted
+		paths, err := fs.Glob(fsys, path.Join(dir, "*"+LevelFileExt))
+		if err != nil {
+			return models.LevelPack{}, nil, fmt.Errorf("failed to list level files: %w", err)
+		}
+		for _, p := range paths {
+			if path.Base(p) != PackManifestFile {
+				files = append(files, path.Base(p))
+			}
+		}
+	}
+	if len(files) == 0 {
+		return models.LevelPack{}, nil, fmt.Errorf("a pack needs at least one level")
+	}
+	for _, file := range files {
+		if strings.ContainsRune(file, '/') || file == PackManifestFile {
+			return models.LevelPack{}, nil, fmt.Errorf("%s is not a level file of the pack", file)
+		}
+	}
+	return pack, files, nil
+}
+
+// LoadLevelPacks reads every pack of the file system, each directory at its root holds a pack.
+// Packs that fail to load are left out and their errors are returned along with the packs that loaded,
+// which are sorted by their order and name. A file system without a root directory holds no packs
+func LoadLevelPacks(fsys fs.FS) ([]models.LevelPack, []error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to list level packs: %w", err)}
+	}
+
+	var packs []models.LevelPack
+	var errs []error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pack, err := LoadLevelPack(fsys, entry.Name())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("pack %s: %w", entry.Name(), err))
+			continue
+		}
+		packs = append(packs, pack)
+	}
+	slices.SortStableFunc(packs, func(a, b models.LevelPack) int {
+		return cmp.Or(cmp.Compare(a.Order, b.Order), cmp.Compare(a.Name, b.Name))
+	})
+	return packs, errs
+}
+
+// WriteLevelFile writes the level into a file of the pack in the directory and returns its path. The file is
+// named after the number of the level and replaces an earlier file of that level, the directory and a manifest
+// with the name of the pack are created when there is no pack in it yet
+func WriteLevelFile(dir, packName string, def models.LevelDefinition) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create pack directory: %w", err)
+	}
+	manifest := filepath.Join(dir, PackManifestFile)
+	if _, err := os.Stat(manifest); errors.Is(err, fs.ErrNotExist) {
+		data := fmt.Sprintf("name = %s\n", tomlString(packName))
+		if err := os.WriteFile(manifest, []byte(data), 0o644); err != nil {
+			return "", fmt.Errorf("failed to write pack manifest: %w", err)
+		}
+	}
+	file := filepath.Join(dir, fmt.Sprintf("level-%d%s", def.Number, LevelFileExt))
+	if err := os.WriteFile(file, FormatLevelFile(def), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write level file: %w", err)
+	}
+	return file, nil
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/dasvh/go-learn-vim/levels"
+)
+
+// packManifest is a pack manifest with every key
+const packManifest = `name = "Walls"
+author = "someone"
+version = "1.2.0"
+order = 3
+levels = ["b.toml", "a.toml"]
+`
+
+func Test_ParsePackManifest(t *testing.T) {
+	pack, files, err := ParsePackManifest([]byte(packManifest))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pack.Name != "Walls" || pack.Author != "someone" || pack.Version != "1.2.0" || pack.Order != 3 {
+		t.Errorf("expected the pack of the manifest, got %+v", pack)
+	}
+	if len(files) != 2 || files[0] != "b.toml" || files[1] != "a.toml" {
+		t.Errorf("expected the level files b.toml and a.toml, got %v", files)
+	}
+
+	if _, _, err := ParsePackManifest([]byte(`author = "someone"`)); err == nil || err.Error() != "name is missing" {
+		t.Errorf("expected error %q, got %v", "name is missing", err)
+	}
+	if _, _, err := ParsePackManifest([]byte(`name = "Walls"` + "\nlevels = [1]")); err == nil {
+		t.Errorf("expected an error for levels that are not strings")
+	}
+}
+
+func Test_LoadLevelPack(t *testing.T) {
+	second := strings.Replace(levelFile, "number = 100", "number = 101", 1)
+	tests := []struct {
+		name        string
+		manifest    string
+		wantNumbers []int
+		wantErr     string
+	}{
+		{name: "levels in the order of the manifest", manifest: packManifest, wantNumbers: []int{101, 100}},
+		{name: "levels sorted by file name", manifest: `name = "Walls"`, wantNumbers: []int{100, 101}},
+		{name: "missing level file", manifest: `name = "Walls"` + "\nlevels = [\"c.toml\"]", wantErr: "failed to read level file"},
+		{name: "manifest as a level", manifest: `name = "Walls"` + "\nlevels = [\"pack.toml\"]", wantErr: "pack.toml is not a level file of the pack"},
+		{name: "no levels", manifest: `name = "Walls"` + "\nlevels = []", wantErr: "a pack needs at least one level"},
+		{name: "invalid manifest", manifest: `name = 1`, wantErr: "pack.toml: line 1: name has the wrong type, found a TOML integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"walls/pack.toml": {Data: []byte(tt.manifest)},
+				"walls/a.toml":    {Data: []byte(levelFile)},
+				"walls/b.toml":    {Data: []byte(second)},
+				"walls/notes.txt": {Data: []byte("not a level")},
+			}
+			pack, err := LoadLevelPack(fsys, "walls")
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Errorf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			var numbers []int
+			for _, def := range pack.Levels {
+				numbers = append(numbers, def.Number)
+			}
+			if len(numbers) != len(tt.wantNumbers) || numbers[0] != tt.wantNumbers[0] || numbers[1] != tt.wantNumbers[1] {
+				t.Errorf("expected levels %v, got %v", tt.wantNumbers, numbers)
+			}
+		})
+	}
+}
+
+func Test_LoadLevelPacks(t *testing.T) {
+	fsys := fstest.MapFS{
+		"b/pack.toml":      {Data: []byte(`name = "First"` + "\norder = 1")},
+		"b/level.toml":     {Data: []byte(levelFile)},
+		"a/pack.toml":      {Data: []byte(`name = "Second"` + "\norder = 2")},
+		"a/level.toml":     {Data: []byte(levelFile)},
+		"broken/pack.toml": {Data: []byte(`name = "Broken"`)},
+		"broken/c.toml":    {Data: []byte("number = 1")},
+		"readme.txt":       {Data: []byte("not a pack")},
+	}
+
+	packs, errs := LoadLevelPacks(fsys)
+	if len(packs) != 2 || packs[0].Name != "First" || packs[1].Name != "Second" {
+		t.Errorf("expected the packs First and Second in order, got %+v", packs)
+	}
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "pack broken: c.toml: ") {
+		t.Errorf("expected an error naming the broken pack and its level file, got %v", errs)
+	}
+
+	if packs, errs := LoadLevelPacks(fstest.MapFS{}); len(packs) != 0 || len(errs) != 0 {
+		t.Errorf("expected no packs from an empty file system, got %v, %v", packs, errs)
+	}
+	if packs, errs := LoadLevelPacks(os.DirFS(filepath.Join(t.TempDir(), "missing"))); len(packs) != 0 || len(errs) != 0 {
+		t.Errorf("expected no packs from a missing directory, got %v, %v", packs, errs)
+	}
+}
+
+func Test_LoadLevelPacksEmbedded(t *testing.T) {
+	packs, errs := LoadLevelPacks(levels.Packs)
+	if len(errs) != 0 {
+		t.Fatalf("expected the embedded packs to load, got %v", errs)
+	}
+	if len(packs) == 0 {
+		t.Errorf("expected at least one embedded pack")
+	}
+}
+
+func Test_LevelPacksDir(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/data")
+	dir, err := LevelPacksDir()
+	if err != nil || dir != "/data/go-learn-vim/levels" {
+		t.Errorf("expected /data/go-learn-vim/levels, got %q, %v", dir, err)
+	}
+
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("HOME", "/home/someone")
+	dir, err = LevelPacksDir()
+	if err != nil || dir != "/home/someone/.local/share/go-learn-vim/levels" {
+		t.Errorf("expected /home/someone/.local/share/go-learn-vim/levels, got %q, %v", dir, err)
+	}
+}
+
+func Test_WriteLevelFile(t *testing.T) {
+	def, err := ParseLevelFile([]byte(levelFile))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	dir := filepath.Join(t.TempDir(), "custom")
+
+	path, err := WriteLevelFile(dir, "Custom", def)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if filepath.Base(path) != "level-100.toml" {
+		t.Errorf("expected level-100.toml, got %s", path)
+	}
+	pack, err := LoadLevelPack(os.DirFS(filepath.Dir(dir)), "custom")
+	if err != nil {
+		t.Fatalf("expected the written pack to load, got %v", err)
+	}
+	if pack.Name != "Custom" || len(pack.Levels) != 1 || pack.Levels[0].Number != 100 {
+		t.Errorf("expected the pack Custom with level 100, got %+v", pack)
+	}
+
+	// the manifest of an existing pack is kept
+	if err := os.WriteFile(filepath.Join(dir, PackManifestFile), []byte(`name = "Drills"`), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	if _, err := WriteLevelFile(dir, "Custom", def); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pack, _ := LoadLevelPack(os.DirFS(filepath.Dir(dir)), "custom"); pack.Name != "Drills" || len(pack.Levels) != 1 {
+		t.Errorf("expected the pack Drills with one level, got %+v", pack)
+	}
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// decodeTOML decodes the TOML data into v, whose fields are tagged with the keys they are read from.
+// Errors in the TOML and values that do not fit their field are returned as a *LevelError on the line they are on
+func decodeTOML(data []byte, v any) error {
+	// the decoder does not report keys and tables that are defined twice on their line, keyLines does
+	var levelErr *LevelError
+	if _, err := keyLines(data); errors.As(err, &levelErr) {
+		return err
+	}
+	err := toml.Unmarshal(data, v)
+	var decodeErr *toml.DecodeError
+	if errors.As(err, &decodeErr) {
+		line, _ := decodeErr.Position()
+		return &LevelError{Line: line, Message: decodeMessage(data, decodeErr, line)}
+	}
+	if err != nil {
+		return &LevelError{Message: strings.TrimPrefix(err.Error(), "toml: ")}
+	}
+	return nil
+}
+
+// decodeMessage returns the message of the error of the decoder on the line. The decoder names the Go type
+// a value does not fit, the message names the key of the value and its TOML type instead
+func decodeMessage(data []byte, err *toml.DecodeError, line int) string {
+	message := strings.TrimPrefix(err.Error(), "toml: ")
+	var found string
+	for _, prefix := range []string{"cannot decode TOML ", "cannot assign "} {
+		if rest, ok := strings.CutPrefix(message, prefix); ok {
+			found, _, _ = strings.Cut(rest, " ")
+		}
+	}
+	lines, linesErr := keyLines(data)
+	key := lastKeyBefore(lines, line)
+	if found == "" || linesErr != nil || key == "" {
+		return message
+	}
+	return fmt.Sprintf("%s has the wrong type, found a TOML %s", key, found)
+}
+
+// lastKeyBefore returns the key that is on the line or, for a value spanning lines, the closest line before it
+func lastKeyBefore(lines map[string]int, line int) string {
+	var last string
+	for key, l := range lines {
+		best, ok := lines[last]
+		if l <= line && (!ok || l > best || l == best && len(key) > len(last)) {
+			last = key
+		}
+	}
+	return last
+}
+
+// keyLines returns the line every key and header of the TOML data is on. Keys in tables are prefixed by
+// the name of the table, tables in arrays are named by their number counted from one, so the par of the
+// second target is targets.2.par. A key or table that is defined twice is returned as a *LevelError,
+// an error in the TOML is returned as the parser reports it
+func keyLines(data []byte) (map[string]int, error) {
+	lines := make(map[string]int)
+	arrays := make(map[string]int) // the number of tables in every array of tables
+	prefix := ""
+	p := unstable.Parser{}
+	p.Reset(data)
+	for p.NextExpression() {
+		expr := p.Expression()
+		if expr.Kind != unstable.KeyValue && expr.Kind != unstable.Table && expr.Kind != unstable.ArrayTable {
+			continue
+		}
+		var parts []string
+		line := 0
+		for it := expr.Key(); it.Next(); {
+			if line == 0 {
+				line = p.Shape(it.Node().Raw).Start.Line
+			}
+			parts = append(parts, string(it.Node().Data))
+		}
+		name := strings.Join(parts, ".")
+
+		switch expr.Kind {
+		case unstable.KeyValue:
+			name = prefix + name
+		case unstable.Table:
+			if arrays[name] > 0 {
+				return nil, &LevelError{Line: line, Message: fmt.Sprintf("%s is an array of tables", name)}
+			}
+			prefix = name + "."
+		case unstable.ArrayTable:
+			if _, ok := lines[name]; !ok {
+				arrays[name]++
+				name += "." + strconv.Itoa(arrays[name])
+			}
+			prefix = name + "."
+		}
+		if _, ok := lines[name]; ok {
+			return nil, &LevelError{Line: line, Message: fmt.Sprintf("%s is defined twice", name)}
+		}
+		lines[name] = line
+	}
+	if err := p.Error(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
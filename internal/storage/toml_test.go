@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_KeyLines(t *testing.T) {
+	text := `number = 1
+text = '''
+one
+two'''
+
+[[targets]]
+par = 2
+
+[[targets]]
+hint = "after a string spanning lines"
+par = 3
+
+[maze]
+size.width = 4
+`
+	want := map[string]int{
+		"number":          1,
+		"text":            2,
+		"targets.1":       6,
+		"targets.1.par":   7,
+		"targets.2":       9,
+		"targets.2.hint":  10,
+		"targets.2.par":   11,
+		"maze":            13,
+		"maze.size.width": 14,
+	}
+
+	lines, err := keyLines([]byte(text))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("expected %v, got %v", want, lines)
+	}
+}
+
+func Test_DecodeTOMLErrors(t *testing.T) {
+	type document struct {
+		A     int      `toml:"a"`
+		Names []string `toml:"names"`
+		Items []struct {
+			N int `toml:"n"`
+		} `toml:"items"`
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		wantErr string
+	}{
+		{name: "key defined twice", text: "a = 1\na = 2", wantErr: "line 2: a is defined twice"},
+		{name: "table defined twice", text: "[t]\n[t]", wantErr: "line 2: t is defined twice"},
+		{name: "table and array of tables", text: "[items]\n[[items]]", wantErr: "line 2: items is defined twice"},
+		{name: "array of tables and table", text: "[[items]]\n[items]", wantErr: "line 2: items is an array of tables"},
+		{name: "key and table", text: "a = 1\n[a]", wantErr: "line 2: a is defined twice"},
+		{name: "wrong type", text: "\na = 'one'", wantErr: "line 2: a has the wrong type, found a TOML string"},
+		{name: "wrong type in an array spanning lines", text: "names = [\n  'one',\n  2,\n]", wantErr: "line 3: names has the wrong type, found a TOML integer"},
+		{name: "wrong type in an array of tables", text: "[[items]]\nn = 1\n[[items]]\nn = true", wantErr: "line 4: items.2.n has the wrong type, found a TOML boolean"},
+		{name: "unterminated string", text: "a = 1\nnames = ['one", wantErr: "line 2: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc document
+			err := decodeTOML([]byte(tt.text), &doc)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -59,6 +59,12 @@ var adventureTheme = struct {
 	TargetReachedBg lipgloss.Color
 	WallFg          lipgloss.Color
 	WallBg          lipgloss.Color
+	SelectionFg     lipgloss.Color
+	SelectionBg     lipgloss.Color
+	RegionFg        lipgloss.Color
+	RegionBg        lipgloss.Color
+	GhostFg         lipgloss.Color
+	GhostBg         lipgloss.Color
 }{
 	HeaderBg:        colours.DarkBlue,
 	FieldBg:         colours.DarkBlue,
@@ -75,6 +81,12 @@ var adventureTheme = struct {
 	TargetReachedBg: colours.Pink,
 	WallFg:          colours.LightPink,
 	WallBg:          colours.DarkPink,
+	SelectionFg:     colours.DarkBlue,
+	SelectionBg:     colours.LightGreen,
+	RegionFg:        colours.White,
+	RegionBg:        colours.DarkPink,
+	GhostFg:         colours.DarkBlue,
+	GhostBg:         colours.GoBlue,
 }
 
 // Target defines the styling for the target in the adventure game
@@ -84,6 +96,13 @@ type Target struct {
 	Reached  lipgloss.Style
 }
 
+// Overlay defines the styling of highlighted cells in the adventure game,
+// it is laid over the style of the character in the cell
+type Overlay struct {
+	Selection lipgloss.Style
+	Region    lipgloss.Style
+}
+
 // Player defines the styling for the player in the adventure game
 type Player struct {
 	Cursor lipgloss.Style
@@ -240,12 +259,22 @@ var Styles = struct {
 		Instructions struct {
 			Style lipgloss.Style
 		}
+		CommandLine struct {
+			Style lipgloss.Style
+		}
+		Registers struct {
+			Border lipgloss.Style
+			Title  lipgloss.Style
+			Text   lipgloss.Style
+		}
 		Map struct {
 			Border     lipgloss.Style
 			Background lipgloss.Style
 			Player     Player
 			Target     Target
 			Wall       lipgloss.Style
+			Overlay    Overlay
+			Ghost      lipgloss.Style
 		}
 	}
 }{
@@ -296,12 +325,22 @@ var Styles = struct {
 		Instructions struct {
 			Style lipgloss.Style
 		}
+		CommandLine struct {
+			Style lipgloss.Style
+		}
+		Registers struct {
+			Border lipgloss.Style
+			Title  lipgloss.Style
+			Text   lipgloss.Style
+		}
 		Map struct {
 			Border     lipgloss.Style
 			Background lipgloss.Style
 			Player     Player
 			Target     Target
 			Wall       lipgloss.Style
+			Overlay    Overlay
+			Ghost      lipgloss.Style
 		}
 	}{
 		Header: struct {
@@ -333,12 +372,36 @@ var Styles = struct {
 				Foreground(colours.Green).
 				Bold(true),
 		},
+		CommandLine: struct {
+			Style lipgloss.Style
+		}{
+			Style: lipgloss.NewStyle().
+				Foreground(colours.White),
+		},
+		Registers: struct {
+			Border lipgloss.Style
+			Title  lipgloss.Style
+			Text   lipgloss.Style
+		}{
+			Border: lipgloss.NewStyle().
+				BorderStyle(lipgloss.RoundedBorder()).
+				PaddingLeft(1).
+				BorderForeground(adventureTheme.HeaderBorderFg).
+				BorderBackground(adventureTheme.HeaderBorderBg),
+			Title: lipgloss.NewStyle().
+				Foreground(colours.Pink).
+				Bold(true),
+			Text: lipgloss.NewStyle().
+				Foreground(colours.White),
+		},
 		Map: struct {
 			Border     lipgloss.Style
 			Background lipgloss.Style
 			Player     Player
 			Target     Target
 			Wall       lipgloss.Style
+			Overlay    Overlay
+			Ghost      lipgloss.Style
 		}{
 			Border: lipgloss.NewStyle().
 				BorderStyle(lipgloss.RoundedBorder()).
@@ -374,6 +437,18 @@ var Styles = struct {
 			Wall: lipgloss.NewStyle().
 				Foreground(adventureTheme.WallFg).
 				Background(adventureTheme.WallBg),
+			Overlay: Overlay{
+				Selection: lipgloss.NewStyle().
+					Foreground(adventureTheme.SelectionFg).
+					Background(adventureTheme.SelectionBg),
+				Region: lipgloss.NewStyle().
+					Foreground(adventureTheme.RegionFg).
+					Background(adventureTheme.RegionBg),
+			},
+			Ghost: lipgloss.NewStyle().
+				Foreground(adventureTheme.GhostFg).
+				Background(adventureTheme.GhostBg).
+				Bold(true),
 		},
 	},
 }
@@ -6,8 +6,9 @@ import (
 )
 
 type MockGameRepository struct {
-	PlayersData   []models.Player
-	GameSavesData []models.GameSave
+	PlayersData    []models.Player
+	GameSavesData  []models.GameSave
+	HighScoresData []models.HighScore
 }
 
 func NewMockGameRepository() *MockGameRepository {
@@ -103,7 +104,7 @@ func (m *MockGameRepository) PlayerLifetimeStats(playerID string) (*models.Lifet
 	return &models.LifetimeStats{}, nil
 }
 
-// ComputeHighScores computes the high scores
+// ComputeHighScores returns the high scores of the mock repository, best first
 func (m *MockGameRepository) ComputeHighScores() ([]models.HighScore, error) {
-	return []models.HighScore{}, nil
+	return append([]models.HighScore{}, m.HighScoresData...), nil
 }
@@ -13,27 +13,37 @@ import (
 const (
 	MinLevelWidth  = 10
 	MinPlayerWidth = 10 + models.PlayerNameMaxLength
-	MinModeWidth   = 16
-	MinStatsWidth  = 30
+	MinModeWidth   = 24
+	MinStatsWidth  = 36
+	RegistersWidth = 32
 )
 
-// GameMap represents the game map of the adventure mode
+// GameMap represents the game map of the adventure mode, Overlays holds the highlight
+// of every cell of the Field and is nil when nothing is highlighted
 type GameMap struct {
 	Field      [][]rune
+	Overlays   [][]models.Overlay
 	Border     lipgloss.Style
 	Background lipgloss.Style
 }
 
 // AdventureView represents the adventure mode view
 type AdventureView struct {
-	Size    tea.WindowSizeMsg
-	Level   components.TextDisplay
-	Player  components.TextDisplay
-	Mode    components.TextDisplay
-	Stats   components.TextDisplay
-	Info    components.TextDisplay
-	GameMap GameMap
-	Help    []key.Binding
+	Size        tea.WindowSizeMsg
+	Level       components.TextDisplay
+	Player      components.TextDisplay
+	Mode        components.TextDisplay
+	Stats       components.TextDisplay
+	Info        components.TextDisplay
+	GameMap     GameMap
+	Help        []key.Binding
+	Command     components.TextDisplay
+	registers   []string
+	par         int
+	panel       bool
+	mode        string
+	pending     string
+	modeMessage string
 }
 
 // InitializeAdventureView creates a new instance of AdventureView
@@ -54,6 +64,9 @@ func InitializeAdventureView() AdventureView {
 		Info: components.TextDisplay{
 			Style: style.Styles.Adventure.Instructions.Style,
 		},
+		Command: components.TextDisplay{
+			Style: style.Styles.Adventure.CommandLine.Style,
+		},
 		GameMap: GameMap{
 			Field:      make([][]rune, 0),
 			Border:     style.Styles.Adventure.Map.Border,
@@ -70,6 +83,9 @@ func (av *AdventureView) UpdateGridDimensions() (int, int) {
 
 	gridHeight := av.Size.Height - (topHeight + mapBorderHeight)
 	gridWidth := av.Size.Width - style.GetComponentWidth(av.GameMap.Border)
+	if av.panel {
+		gridWidth -= RegistersWidth
+	}
 
 	return gridWidth, gridHeight
 }
@@ -85,8 +101,19 @@ func (av *AdventureView) RenderScreen() string {
 
 	topBar := renderTopBar(sections, widths, positions, style.Styles.Adventure.Header.Border)
 	levelInstructions := av.Info.Render()
-	gameMap := renderGameMap(av.GameMap, av.Size.Width, gameMapBorderWidth)
+	mapWidth := av.Size.Width
+	if av.panel {
+		mapWidth -= RegistersWidth
+	}
+	gameMap := renderGameMap(av.GameMap, mapWidth, gameMapBorderWidth)
+	if av.panel {
+		gameMap = lipgloss.JoinHorizontal(lipgloss.Top, gameMap, renderRegisters(av.registers, lipgloss.Height(gameMap)))
+	}
+	// like in Vim an open command line takes the place of the controls at the bottom
 	controlsBar := help.New().ShortHelpView(av.Help)
+	if av.Command.Text != "" {
+		controlsBar = av.Command.Style.Width(av.Size.Width).Render(av.Command.Text)
+	}
 
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -109,14 +136,68 @@ func (av *AdventureView) SetPlayer(player string) {
 
 // SetMode sets the mode text
 func (av *AdventureView) SetMode(mode string) {
-	av.Mode.SetText("Mode: %s", mode)
+	av.mode = mode
+	av.renderMode()
+}
+
+// SetPending sets the keys of a motion that is still being typed, like a count,
+// they are shown next to the mode the same way Vim shows them with 'showcmd'
+func (av *AdventureView) SetPending(keys string) {
+	av.pending = keys
+	av.renderMode()
+}
+
+// renderMode sets the mode text followed by the pending keys
+func (av *AdventureView) renderMode() {
+	if av.modeMessage != "" {
+		av.Mode.SetText("%s", av.modeMessage)
+		return
+	}
+	if av.pending == "" {
+		av.Mode.SetText("Mode: %s", av.mode)
+		return
+	}
+	av.Mode.SetText("Mode: %s  %s", av.mode, av.pending)
+}
+
+// SetModeMessage sets the message of the Vim mode the level is in, like -- INSERT --,
+// it is shown instead of the mode like Vim does. An empty message shows the mode again
+func (av *AdventureView) SetModeMessage(message string) {
+	av.modeMessage = message
+	av.renderMode()
+}
+
+// SetCommandLine sets the text of the command line, an empty text closes it
+func (av *AdventureView) SetCommandLine(text string) {
+	av.Command.SetText("%s", text)
+}
+
+// ShowRegisters sets whether the registers with the recorded macros are shown next to the game map,
+// the grid dimensions have to be updated afterward
+func (av *AdventureView) ShowRegisters(show bool) {
+	av.panel = show
 }
 
-// SetStats sets the stats text with keystrokes and time
+// SetRegisters sets the lines of the registers panel, one for every register that holds a macro
+func (av *AdventureView) SetRegisters(registers []string) {
+	av.registers = registers
+}
+
+// SetStats sets the stats text with keystrokes and time, the keystrokes are shown versus the par when there is one
 func (av *AdventureView) SetStats(keystrokes int, time int) {
+	if av.par > 0 {
+		av.Stats.SetText(models.StatsParFormat, keystrokes, av.par, time)
+		return
+	}
 	av.Stats.SetText(models.StatsFormat, keystrokes, time)
 }
 
+// SetPar sets the par the keystrokes are shown versus, zero when the level has none,
+// the stats have to be set afterward
+func (av *AdventureView) SetPar(par int) {
+	av.par = par
+}
+
 // SetInfo sets the info text
 func (av *AdventureView) SetInfo(info string) {
 	av.Info.SetText("%s", info)
@@ -127,10 +208,10 @@ func renderGameMap(gameMap GameMap, totalWidth int, bordersSpace int) string {
 	var lines []string
 
 	// convert grid content to strings with proper spacing
-	for _, row := range gameMap.Field {
+	for y, row := range gameMap.Field {
 		var lineRunes []string
-		for _, r := range row {
-			characterStyle := models.ToDefaultCharacterStyle(r)
+		for x, r := range row {
+			characterStyle := models.ToDefaultCharacterStyle(r, overlayAt(gameMap.Overlays, x, y))
 			cell := characterStyle.Render(string(r))
 			lineRunes = append(lineRunes, cell)
 		}
@@ -141,6 +222,38 @@ func renderGameMap(gameMap GameMap, totalWidth int, bordersSpace int) string {
 	return gameMap.Border.Width(totalWidth - bordersSpace).Render(content)
 }
 
+// renderRegisters renders the panel with the registers next to the game map with the given height,
+// lines that are too long for the panel are cut off
+func renderRegisters(registers []string, height int) string {
+	border := style.Styles.Adventure.Registers.Border
+	width := RegistersWidth - border.GetHorizontalFrameSize()
+
+	lines := []string{style.Styles.Adventure.Registers.Title.Render("Registers")}
+	if len(registers) == 0 {
+		lines = append(lines, style.Styles.Adventure.Registers.Text.Render("q{a-z} records a macro"))
+	}
+	for _, register := range registers {
+		if runes := []rune(register); len(runes) > width {
+			register = string(runes[:width-1]) + "…"
+		}
+		lines = append(lines, style.Styles.Adventure.Registers.Text.Render(register))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return border.
+		Width(RegistersWidth - border.GetHorizontalBorderSize()).
+		Height(height - border.GetVerticalBorderSize()).
+		Render(content)
+}
+
+// overlayAt returns the models.Overlay of a cell, cells outside the overlays are not highlighted
+func overlayAt(overlays [][]models.Overlay, x, y int) models.Overlay {
+	if y < len(overlays) && x < len(overlays[y]) {
+		return overlays[y][x]
+	}
+	return models.OverlayNone
+}
+
 // renderTopBar renders the top bar
 func renderTopBar(sections []components.TextDisplay, widths []int, positions []lipgloss.Position, border lipgloss.Style) string {
 	if len(sections) != len(widths) || len(sections) != len(positions) {
@@ -0,0 +1,16 @@
+package views
+
+import "github.com/dasvh/go-learn-vim/internal/models"
+
+// Frame returns the rows of a rendered level, or of its overlays, that are inside the models.Viewport,
+// rows missing below the level are left empty
+func Frame[T any](field [][]T, viewport models.Viewport) [][]T {
+	framed := make([][]T, viewport.Height)
+	for y := range framed {
+		row := viewport.Top + y
+		if row >= 0 && row < len(field) {
+			framed[y] = field[row]
+		}
+	}
+	return framed
+}
@@ -0,0 +1,141 @@
+package vim
+
+import (
+	"strings"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// Buffer holds the lines of text the cursor moves over
+type Buffer struct {
+	lines [][]rune
+	walls func(models.Position) bool
+}
+
+// NewBuffer creates a Buffer from the given lines of text
+func NewBuffer(lines []string) *Buffer {
+	b := &Buffer{lines: make([][]rune, len(lines))}
+	for y, line := range lines {
+		b.lines[y] = []rune(line)
+	}
+	// like in Vim a buffer always has at least one line
+	if len(b.lines) == 0 {
+		b.lines = [][]rune{{}}
+	}
+	return b
+}
+
+// NewBlankBuffer creates a Buffer of blank lines with the given dimensions
+func NewBlankBuffer(width, height int) *Buffer {
+	lines := make([]string, height)
+	for y := range lines {
+		lines[y] = strings.Repeat(" ", width)
+	}
+	return NewBuffer(lines)
+}
+
+// SetWalls sets the function that reports the positions the cursor cannot enter
+func (b *Buffer) SetWalls(isWall func(models.Position) bool) {
+	b.walls = isWall
+}
+
+// IsWall reports whether the cursor cannot enter the given position
+func (b *Buffer) IsWall(pos models.Position) bool {
+	return b.walls != nil && b.walls(pos)
+}
+
+// LineCount returns the number of lines in the Buffer
+func (b *Buffer) LineCount() int {
+	return len(b.lines)
+}
+
+// Line returns the runes of the line at index y
+func (b *Buffer) Line(y int) []rune {
+	if y < 0 || y >= len(b.lines) {
+		return nil
+	}
+	return b.lines[y]
+}
+
+// Width returns the length of the longest line in the Buffer
+func (b *Buffer) Width() int {
+	width := 0
+	for _, line := range b.lines {
+		width = max(width, len(line))
+	}
+	return width
+}
+
+// Lines returns the content of the Buffer as strings
+func (b *Buffer) Lines() []string {
+	lines := make([]string, len(b.lines))
+	for y, line := range b.lines {
+		lines[y] = string(line)
+	}
+	return lines
+}
+
+// Contains reports whether the cursor can be placed on the given position,
+// the start of an empty line counts as a valid position
+func (b *Buffer) Contains(pos models.Position) bool {
+	if pos.Y < 0 || pos.Y >= len(b.lines) || pos.X < 0 {
+		return false
+	}
+	return pos.X < len(b.lines[pos.Y]) || pos.X == 0
+}
+
+// Clamp returns the position closest to pos the cursor can be placed on
+func (b *Buffer) Clamp(pos models.Position) models.Position {
+	pos.Y = min(max(pos.Y, 0), len(b.lines)-1)
+	pos.X = min(max(pos.X, 0), max(len(b.lines[pos.Y])-1, 0))
+	return pos
+}
+
+// stopAtWall returns the position the cursor reaches when it moves from start to end,
+// a move along a line or a column stops in front of the first wall on the way
+func (b *Buffer) stopAtWall(start, end models.Position) models.Position {
+	if b.walls == nil {
+		return end
+	}
+	if start.X != end.X && start.Y != end.Y {
+		if b.IsWall(end) {
+			return start
+		}
+		return end
+	}
+
+	step := models.Position{X: sign(end.X - start.X), Y: sign(end.Y - start.Y)}
+	pos := start
+	for pos != end {
+		next := models.Position{X: pos.X + step.X, Y: pos.Y + step.Y}
+		if b.IsWall(next) {
+			break
+		}
+		pos = next
+	}
+	return pos
+}
+
+// between reports whether pos is on the line or column from start to end
+func between(start, pos, end models.Position) bool {
+	switch {
+	case start.Y == pos.Y && pos.Y == end.Y:
+		return min(start.X, end.X) <= pos.X && pos.X <= max(start.X, end.X)
+	case start.X == pos.X && pos.X == end.X:
+		return min(start.Y, end.Y) <= pos.Y && pos.Y <= max(start.Y, end.Y)
+	default:
+		return false
+	}
+}
+
+// sign returns -1, 0 or 1 depending on the sign of n
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
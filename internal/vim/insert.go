@@ -0,0 +1,90 @@
+package vim
+
+import "github.com/dasvh/go-learn-vim/internal/models"
+
+// Insert returns the position where typing starts for the models.InsertKind,
+// o and O first open a new line for it. In insert mode the cursor can be on the
+// end-of-line position after the last character
+func Insert(buf *Buffer, pos models.Position, kind models.InsertKind) models.Position {
+	pos = buf.Clamp(pos)
+	line := buf.Line(pos.Y)
+
+	switch kind {
+	case models.InsertAfter:
+		return models.Position{X: min(pos.X+1, len(line)), Y: pos.Y}
+	case models.InsertLineStart:
+		// unlike ^ the cursor goes to the end of a line that only has blanks
+		x := 0
+		for x < len(line) && charClass(line[x], false) == classBlank {
+			x++
+		}
+		return models.Position{X: x, Y: pos.Y}
+	case models.InsertLineEnd:
+		return models.Position{X: len(line), Y: pos.Y}
+	case models.InsertOpenBelow:
+		buf.replace(pos.Y+1, pos.Y, [][]rune{{}})
+		return models.Position{Y: pos.Y + 1}
+	case models.InsertOpenAbove:
+		buf.replace(pos.Y, pos.Y-1, [][]rune{{}})
+		return models.Position{Y: pos.Y}
+	default:
+		return pos
+	}
+}
+
+// InsertRune types r in front of pos and returns the position after it
+func (b *Buffer) InsertRune(pos models.Position, r rune) models.Position {
+	pos = b.insertClamp(pos)
+	line := b.lines[pos.Y]
+	typed := append(append(append([]rune{}, line[:pos.X]...), r), line[pos.X:]...)
+	b.lines[pos.Y] = typed
+	return models.Position{X: pos.X + 1, Y: pos.Y}
+}
+
+// SplitLine breaks the line at pos like enter does in insert mode,
+// it returns the start of the new line
+func (b *Buffer) SplitLine(pos models.Position) models.Position {
+	pos = b.insertClamp(pos)
+	line := b.lines[pos.Y]
+	head := append([]rune{}, line[:pos.X]...)
+	tail := append([]rune{}, line[pos.X:]...)
+	b.replace(pos.Y, pos.Y, [][]rune{head, tail})
+	return models.Position{Y: pos.Y + 1}
+}
+
+// Backspace deletes the character in front of pos, at the start of a line it joins the line
+// with the line above like with 'backspace' set to eol. It reports false when there is nothing to delete
+func (b *Buffer) Backspace(pos models.Position) (models.Position, bool) {
+	pos = b.insertClamp(pos)
+	switch {
+	case pos.X > 0:
+		line := b.lines[pos.Y]
+		b.lines[pos.Y] = append(append([]rune{}, line[:pos.X-1]...), line[pos.X:]...)
+		return models.Position{X: pos.X - 1, Y: pos.Y}, true
+	case pos.Y > 0:
+		above := b.lines[pos.Y-1]
+		joined := append(append([]rune{}, above...), b.lines[pos.Y]...)
+		b.replace(pos.Y-1, pos.Y, [][]rune{joined})
+		return models.Position{X: len(above), Y: pos.Y - 1}, true
+	default:
+		return pos, false
+	}
+}
+
+// insertClamp returns the position closest to pos the cursor can be placed on in insert mode
+func (b *Buffer) insertClamp(pos models.Position) models.Position {
+	pos.Y = min(max(pos.Y, 0), len(b.lines)-1)
+	pos.X = min(max(pos.X, 0), len(b.lines[pos.Y]))
+	return pos
+}
+
+// FirstDifference returns the index of the first line that differs between the lines and the goal,
+// a line that is missing on either side counts as different. It returns -1 when they are the same
+func FirstDifference(lines, goal []string) int {
+	for y := range max(len(lines), len(goal)) {
+		if y >= len(lines) || y >= len(goal) || lines[y] != goal[y] {
+			return y
+		}
+	}
+	return -1
+}
@@ -0,0 +1,82 @@
+package vim
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+func Test_Insert(t *testing.T) {
+	text := []string{"  one two", "three"}
+	tests := []struct {
+		name      string
+		start     models.Position
+		kind      models.InsertKind
+		wantPos   models.Position
+		wantLines []string
+	}{
+		{name: "i", start: models.Position{X: 3, Y: 0}, kind: models.InsertBefore, wantPos: models.Position{X: 3, Y: 0}, wantLines: text},
+		{name: "a", start: models.Position{X: 3, Y: 0}, kind: models.InsertAfter, wantPos: models.Position{X: 4, Y: 0}, wantLines: text},
+		{name: "a on the last character", start: models.Position{X: 4, Y: 1}, kind: models.InsertAfter, wantPos: models.Position{X: 5, Y: 1}, wantLines: text},
+		{name: "I", start: models.Position{X: 6, Y: 0}, kind: models.InsertLineStart, wantPos: models.Position{X: 2, Y: 0}, wantLines: text},
+		{name: "A", start: models.Position{X: 0, Y: 0}, kind: models.InsertLineEnd, wantPos: models.Position{X: 9, Y: 0}, wantLines: text},
+		{name: "o", start: models.Position{X: 3, Y: 0}, kind: models.InsertOpenBelow, wantPos: models.Position{X: 0, Y: 1}, wantLines: []string{"  one two", "", "three"}},
+		{name: "o on the last line", start: models.Position{X: 3, Y: 1}, kind: models.InsertOpenBelow, wantPos: models.Position{X: 0, Y: 2}, wantLines: []string{"  one two", "three", ""}},
+		{name: "O", start: models.Position{X: 3, Y: 0}, kind: models.InsertOpenAbove, wantPos: models.Position{X: 0, Y: 0}, wantLines: []string{"", "  one two", "three"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := NewBuffer(text)
+			if pos := Insert(buf, tt.start, tt.kind); pos != tt.wantPos {
+				t.Errorf("expected insert at %v, got %v", tt.wantPos, pos)
+			}
+			if !slices.Equal(buf.Lines(), tt.wantLines) {
+				t.Errorf("expected lines %q, got %q", tt.wantLines, buf.Lines())
+			}
+		})
+	}
+}
+
+func Test_Typing(t *testing.T) {
+	buf := NewBuffer([]string{"ac", "end"})
+	pos := buf.InsertRune(models.Position{X: 1, Y: 0}, 'b')
+	pos = buf.SplitLine(pos)
+	pos = buf.InsertRune(pos, 'x')
+	if want := []string{"ab", "xc", "end"}; !slices.Equal(buf.Lines(), want) {
+		t.Fatalf("expected lines %q, got %q", want, buf.Lines())
+	}
+
+	pos, _ = buf.Backspace(pos)
+	pos, _ = buf.Backspace(pos)
+	if want := []string{"abc", "end"}; !slices.Equal(buf.Lines(), want) || pos != (models.Position{X: 2, Y: 0}) {
+		t.Fatalf("expected lines %q with the cursor at 2, got %q at %v", want, buf.Lines(), pos)
+	}
+
+	if _, ok := buf.Backspace(models.Position{X: 0, Y: 0}); ok {
+		t.Errorf("expected backspace at the start of the buffer to do nothing")
+	}
+}
+
+func Test_FirstDifference(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		goal  []string
+		want  int
+	}{
+		{name: "same", lines: []string{"a", "b"}, goal: []string{"a", "b"}, want: -1},
+		{name: "changed line", lines: []string{"a", "b"}, goal: []string{"a", "c"}, want: 1},
+		{name: "missing line", lines: []string{"a"}, goal: []string{"a", "b"}, want: 1},
+		{name: "extra line", lines: []string{"a", "b", ""}, goal: []string{"a", "b"}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FirstDifference(tt.lines, tt.goal); got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package vim
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// maxJumps is the number of positions the JumpList remembers, like in Vim
+const maxJumps = 100
+
+// IsMarkName reports whether r can be used as the name of a mark
+func IsMarkName(r rune) bool {
+	return r >= 'a' && r <= 'z'
+}
+
+// ResolveMark returns the position a jump to the mark at pos lands on,
+// a linewise jump lands on the first non-blank character of the line of the mark
+func ResolveMark(buf *Buffer, pos models.Position, linewise bool) models.Position {
+	c := &cursor{buf: buf, pos: buf.Clamp(pos)}
+	if linewise {
+		c.firstNonBlank()
+	}
+	c.adjust()
+	return c.pos
+}
+
+// JumpList remembers the positions the cursor jumped away from,
+// Index points at the entry the cursor is on or past the end when it is on none
+type JumpList struct {
+	Entries []models.Position
+	Index   int
+}
+
+// Push adds the position the cursor jumps away from, like in Vim an older entry
+// on the same line is removed and the Index moves past the end
+func (jl *JumpList) Push(pos models.Position) {
+	jl.Entries = slices.DeleteFunc(jl.Entries, func(entry models.Position) bool {
+		return entry.Y == pos.Y
+	})
+	jl.Entries = append(jl.Entries, pos)
+	if len(jl.Entries) > maxJumps {
+		jl.Entries = jl.Entries[len(jl.Entries)-maxJumps:]
+	}
+	jl.Index = len(jl.Entries)
+}
+
+// Older returns the position count entries back in the JumpList, when the cursor is not on an
+// entry yet its position is added first so Newer can return to it
+func (jl *JumpList) Older(current models.Position, count int) (models.Position, bool) {
+	if jl.Index >= len(jl.Entries) {
+		jl.Push(current)
+		jl.Index = len(jl.Entries) - 1
+	}
+	target := jl.Index - count
+	if target < 0 {
+		return current, false
+	}
+	jl.Index = target
+	return jl.Entries[target], true
+}
+
+// Newer returns the position count entries forward in the JumpList
+func (jl *JumpList) Newer(current models.Position, count int) (models.Position, bool) {
+	target := jl.Index + count
+	if target >= len(jl.Entries) {
+		return current, false
+	}
+	jl.Index = target
+	return jl.Entries[target], true
+}
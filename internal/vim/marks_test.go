@@ -0,0 +1,74 @@
+package vim
+
+import (
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+func Test_JumpList(t *testing.T) {
+	var jl JumpList
+	jl.Push(models.Position{X: 1, Y: 1})
+	jl.Push(models.Position{X: 2, Y: 5})
+	jl.Push(models.Position{X: 3, Y: 9})
+	current := models.Position{X: 0, Y: 20}
+
+	steps := []struct {
+		name    string
+		older   bool
+		count   int
+		wantPos models.Position
+		wantOK  bool
+	}{
+		{name: "ctrl+o to last jump", older: true, count: 1, wantPos: models.Position{X: 3, Y: 9}, wantOK: true},
+		{name: "2 ctrl+o", older: true, count: 2, wantPos: models.Position{X: 1, Y: 1}, wantOK: true},
+		{name: "ctrl+o past oldest", older: true, count: 1, wantPos: models.Position{X: 1, Y: 1}, wantOK: false},
+		{name: "ctrl+i", older: false, count: 1, wantPos: models.Position{X: 2, Y: 5}, wantOK: true},
+		{name: "2 ctrl+i back to start", older: false, count: 2, wantPos: models.Position{X: 0, Y: 20}, wantOK: true},
+		{name: "ctrl+i past newest", older: false, count: 1, wantPos: models.Position{X: 0, Y: 20}, wantOK: false},
+	}
+
+	for _, step := range steps {
+		var got models.Position
+		var ok bool
+		if step.older {
+			got, ok = jl.Older(current, step.count)
+		} else {
+			got, ok = jl.Newer(current, step.count)
+		}
+		if ok {
+			current = got
+		}
+		if got != step.wantPos || ok != step.wantOK {
+			t.Errorf("%s: expected %+v %v, got %+v %v", step.name, step.wantPos, step.wantOK, got, ok)
+		}
+	}
+}
+
+func Test_JumpListPushRemovesSameLine(t *testing.T) {
+	var jl JumpList
+	jl.Push(models.Position{X: 1, Y: 1})
+	jl.Push(models.Position{X: 2, Y: 5})
+	jl.Push(models.Position{X: 7, Y: 1})
+
+	want := []models.Position{{X: 2, Y: 5}, {X: 7, Y: 1}}
+	if len(jl.Entries) != len(want) || jl.Entries[0] != want[0] || jl.Entries[1] != want[1] {
+		t.Errorf("expected entries %+v, got %+v", want, jl.Entries)
+	}
+	if jl.Index != 2 {
+		t.Errorf("expected index 2, got %d", jl.Index)
+	}
+}
+
+func Test_ResolveMark(t *testing.T) {
+	mark := models.Position{X: 9, Y: 2}
+	if got := ResolveMark(wordBuffer, mark, false); got != mark {
+		t.Errorf("expected %+v, got %+v", mark, got)
+	}
+	if got := ResolveMark(wordBuffer, mark, true); got != (models.Position{X: 2, Y: 2}) {
+		t.Errorf("expected first non-blank of the line, got %+v", got)
+	}
+	if got := ResolveMark(wordBuffer, models.Position{X: 40, Y: 3}, false); got != (models.Position{X: 3, Y: 3}) {
+		t.Errorf("expected mark past the end of the line to be clamped, got %+v", got)
+	}
+}
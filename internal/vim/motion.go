@@ -0,0 +1,429 @@
+package vim
+
+import (
+	"unicode"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// character classes used by the word motions
+const (
+	classBlank = iota
+	classPunctuation
+	classWord
+)
+
+// results of moving a cursor by a single character
+const (
+	stepEnd  = -1 // there is no character left to move to
+	stepChar = 0  // moved within the line
+	stepLine = 1  // moved onto another line
+	stepEOL  = 2  // moved onto the end of the line
+)
+
+// Resolve returns the position reached by applying the models.Motion from pos
+// and whether the cursor was moved at all, walls of the Buffer stop the cursor
+func Resolve(buf *Buffer, pos models.Position, motion models.Motion) (models.Position, bool) {
+	start := buf.Clamp(pos)
+	end, ok := motionEnd(buf, start, motion)
+	if !ok {
+		return start, false
+	}
+	end = buf.stopAtWall(start, end)
+	return end, end != pos
+}
+
+// motionEnd returns the position the models.Motion moves the cursor to from start as if there were no walls,
+// and whether the motion could be applied at all
+func motionEnd(buf *Buffer, start models.Position, motion models.Motion) (models.Position, bool) {
+	c := &cursor{buf: buf, pos: start}
+
+	count := max(motion.Count, 1)
+	if step := c.step(motion.Kind); step != nil {
+		c.repeat(count, step)
+	}
+	switch motion.Kind {
+	case models.MotionUp:
+		c.vertical(-count)
+	case models.MotionDown:
+		c.vertical(count)
+	case models.MotionMatchPair:
+		if !c.matchPair() {
+			return start, false
+		}
+	case models.MotionStartOfLine:
+		c.pos.X = 0
+	case models.MotionFirstNonBlank:
+		c.firstNonBlank()
+	case models.MotionEndOfLine:
+		// a count moves the cursor count - 1 lines down first
+		if c.pos.Y+count-1 >= buf.LineCount() {
+			return start, false
+		}
+		c.pos.Y += count - 1
+		c.pos.X = len(c.line()) - 1
+	case models.MotionFindForward, models.MotionFindBackward, models.MotionTillForward, models.MotionTillBackward:
+		// like in Vim the cursor does not move when there are fewer than count occurrences
+		if !c.find(motion.Kind, motion.Char, count, motion.Repeated) {
+			return start, false
+		}
+	case models.MotionSearchForward, models.MotionSearchBackward:
+		if !c.search(motion.Pattern, motion.Kind == models.MotionSearchForward, count) {
+			return start, false
+		}
+	case models.MotionFirstLine:
+		c.toLine(count)
+	case models.MotionLastLine:
+		if motion.Count > 0 {
+			c.toLine(motion.Count)
+		} else {
+			c.toLine(buf.LineCount())
+		}
+	}
+
+	c.adjust()
+	return c.pos, true
+}
+
+// Counts calls yield with the position the models.Motion reaches from pos with every count from 1 up to
+// maxCount and whether the cursor moved, the same positions Resolve returns for those counts. A motion that
+// repeats a single step continues from where the count before it stopped, and its counts end once the cursor
+// no longer moves. Counts stops when yield returns false
+func Counts(buf *Buffer, pos models.Position, motion models.Motion, maxCount int, yield func(count int, to models.Position, moved bool) bool) {
+	start := buf.Clamp(pos)
+	c := &cursor{buf: buf, pos: start}
+	step := c.step(motion.Kind)
+	to := start
+	for count := 1; count <= maxCount; count++ {
+		var end models.Position
+		ok := true
+		if step == nil {
+			motion.Count = count
+			end, ok = motionEnd(buf, start, motion)
+		} else {
+			before := c.pos
+			step()
+			if count > 1 && c.pos == before {
+				return
+			}
+			end = buf.Clamp(c.pos)
+		}
+		if !ok {
+			to = start
+			if !yield(count, start, false) {
+				return
+			}
+			continue
+		}
+		// the way from start to the position the count before reached is open,
+		// the walls only have to be looked for from there on when it is on the way
+		from := start
+		if between(start, to, end) {
+			from = to
+		}
+		to = buf.stopAtWall(from, end)
+		if !yield(count, to, to != pos) {
+			return
+		}
+	}
+}
+
+// RepeatFind returns the find motion that ; or , repeats based on the last find motion,
+// the count and keys are taken from the repeating motion
+func RepeatFind(last, motion models.Motion) models.Motion {
+	kind := last.Kind
+	if motion.Kind == models.MotionRepeatFindReverse {
+		switch kind {
+		case models.MotionFindForward:
+			kind = models.MotionFindBackward
+		case models.MotionFindBackward:
+			kind = models.MotionFindForward
+		case models.MotionTillForward:
+			kind = models.MotionTillBackward
+		case models.MotionTillBackward:
+			kind = models.MotionTillForward
+		}
+	}
+	return models.Motion{Kind: kind, Count: motion.Count, Char: last.Char, Repeated: true, Keys: motion.Keys}
+}
+
+// cursor walks over the characters of a Buffer the same way Vim does,
+// which includes the end-of-line position after the last character of a line
+type cursor struct {
+	buf *Buffer
+	pos models.Position
+}
+
+// line returns the line the cursor is on
+func (c *cursor) line() []rune {
+	return c.buf.lines[c.pos.Y]
+}
+
+// onEmptyLine reports whether the cursor is on an empty line
+func (c *cursor) onEmptyLine() bool {
+	return c.pos.X == 0 && len(c.line()) == 0
+}
+
+// adjust moves the cursor off the end-of-line position onto the last character
+func (c *cursor) adjust() {
+	c.pos = c.buf.Clamp(c.pos)
+}
+
+// class returns the character class under the cursor, with bigWord set
+// every non-blank character belongs to the same class
+func (c *cursor) class(bigWord bool) int {
+	line := c.line()
+	if c.pos.X >= len(line) {
+		return classBlank
+	}
+
+	return charClass(line[c.pos.X], bigWord)
+}
+
+// charClass returns the character class of r, with bigWord set
+// every non-blank character belongs to the same class
+func charClass(r rune, bigWord bool) int {
+	switch {
+	case r == ' ' || r == '\t':
+		return classBlank
+	case bigWord:
+		return classPunctuation
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+		return classWord
+	default:
+		return classPunctuation
+	}
+}
+
+// inc moves the cursor forward by one character
+func (c *cursor) inc() int {
+	line := c.line()
+	if c.pos.X < len(line) {
+		c.pos.X++
+		if c.pos.X < len(line) {
+			return stepChar
+		}
+		return stepEOL
+	}
+	if c.pos.Y < c.buf.LineCount()-1 {
+		c.pos.Y++
+		c.pos.X = 0
+		return stepLine
+	}
+	return stepEnd
+}
+
+// dec moves the cursor back by one character
+func (c *cursor) dec() int {
+	if c.pos.X > 0 {
+		c.pos.X--
+		return stepChar
+	}
+	if c.pos.Y > 0 {
+		c.pos.Y--
+		c.pos.X = len(c.line())
+		return stepLine
+	}
+	return stepEnd
+}
+
+// skipClass moves the cursor while it is on characters of the given class
+// and reports whether it ran into the start or end of the Buffer
+func (c *cursor) skipClass(class int, bigWord, forward bool) bool {
+	for c.class(bigWord) == class {
+		step := c.dec
+		if forward {
+			step = c.inc
+		}
+		if step() == stepEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// left moves the cursor one character to the left within the line
+func (c *cursor) left() {
+	if c.pos.X > 0 {
+		c.pos.X--
+	}
+}
+
+// right moves the cursor one character to the right within the line
+func (c *cursor) right() {
+	if c.pos.X < len(c.line())-1 {
+		c.pos.X++
+	}
+}
+
+// vertical moves the cursor by delta lines, keeping the column where possible,
+// like in Vim the cursor stops on the first or last line when delta goes past it
+func (c *cursor) vertical(delta int) {
+	y := min(max(c.pos.Y+delta, 0), c.buf.LineCount()-1)
+	c.pos = c.buf.Clamp(models.Position{X: c.pos.X, Y: y})
+}
+
+// step returns the single step a count repeats for the motions that move the cursor one step at a time,
+// like a character or a word, it returns nil for the other motions
+func (c *cursor) step(kind models.MotionKind) func() {
+	switch kind {
+	case models.MotionLeft:
+		return c.left
+	case models.MotionRight:
+		return c.right
+	case models.MotionWordForward:
+		return func() { c.wordForward(false) }
+	case models.MotionWORDForward:
+		return func() { c.wordForward(true) }
+	case models.MotionWordBackward:
+		return func() { c.wordBackward(false) }
+	case models.MotionWORDBackward:
+		return func() { c.wordBackward(true) }
+	case models.MotionWordEnd:
+		return func() { c.wordEnd(false) }
+	case models.MotionWORDEnd:
+		return func() { c.wordEnd(true) }
+	case models.MotionSentenceForward:
+		return func() { c.sentence(true) }
+	case models.MotionSentenceBackward:
+		return func() { c.sentence(false) }
+	case models.MotionParagraphForward:
+		return func() { c.paragraph(true) }
+	case models.MotionParagraphBackward:
+		return func() { c.paragraph(false) }
+	default:
+		return nil
+	}
+}
+
+// repeat applies a single step motion count times, it stops early once the cursor no longer moves
+func (c *cursor) repeat(count int, step func()) {
+	for range count {
+		before := c.pos
+		step()
+		if c.pos == before {
+			return
+		}
+	}
+}
+
+// firstNonBlank moves the cursor to the first non-blank character of the line,
+// on a blank line it ends up on the last character like in Vim
+func (c *cursor) firstNonBlank() {
+	line := c.line()
+	for x, r := range line {
+		if r != ' ' && r != '\t' {
+			c.pos.X = x
+			return
+		}
+	}
+	c.pos.X = len(line) - 1
+}
+
+// toLine moves the cursor to the line with the given number, counted from one,
+// the column is kept where possible like with 'nostartofline'
+func (c *cursor) toLine(number int) {
+	y := min(max(number, 1), c.buf.LineCount()) - 1
+	c.pos = c.buf.Clamp(models.Position{X: c.pos.X, Y: y})
+}
+
+// find moves the cursor onto the count'th occurrence of char in the line, or next to it for t and T,
+// and reports whether there were enough occurrences
+func (c *cursor) find(kind models.MotionKind, char rune, count int, repeated bool) bool {
+	line := c.line()
+	dir := 1
+	if kind == models.MotionFindBackward || kind == models.MotionTillBackward {
+		dir = -1
+	}
+	till := kind == models.MotionTillForward || kind == models.MotionTillBackward
+
+	x := c.pos.X
+	// repeating t or T skips the character next to the cursor, otherwise ; would not move
+	if till && repeated && count == 1 {
+		x += dir
+	}
+	for count > 0 {
+		x += dir
+		if x < 0 || x >= len(line) {
+			return false
+		}
+		if line[x] == char {
+			count--
+		}
+	}
+	if till {
+		x -= dir
+	}
+	c.pos.X = x
+	return true
+}
+
+// wordForward moves the cursor to the start of the next word, stopping on empty lines
+func (c *cursor) wordForward(bigWord bool) {
+	startClass := c.class(bigWord)
+	lastLine := c.pos.Y == c.buf.LineCount()-1
+
+	// the cursor always moves at least one character, unless it is on the last character
+	step := c.inc()
+	if step == stepEnd || (step >= stepLine && lastLine) {
+		return
+	}
+
+	// go one character past the end of the current word
+	if startClass != classBlank && c.skipClass(startClass, bigWord, true) {
+		return
+	}
+
+	// go to the next non-blank character
+	for c.class(bigWord) == classBlank && !c.onEmptyLine() {
+		if c.inc() == stepEnd {
+			return
+		}
+	}
+}
+
+// wordBackward moves the cursor to the start of the previous word, stopping on empty lines
+func (c *cursor) wordBackward(bigWord bool) {
+	if c.dec() == stepEnd {
+		return
+	}
+
+	// skip the blanks before the word
+	for c.class(bigWord) == classBlank {
+		if c.onEmptyLine() {
+			return
+		}
+		if c.dec() == stepEnd {
+			return
+		}
+	}
+
+	// move to the start of the word, the cursor overshoots by one character
+	if c.skipClass(c.class(bigWord), bigWord, false) {
+		return
+	}
+	c.inc()
+}
+
+// wordEnd moves the cursor to the end of the current or next word
+func (c *cursor) wordEnd(bigWord bool) {
+	startClass := c.class(bigWord)
+	if c.inc() == stepEnd {
+		return
+	}
+
+	if class := c.class(bigWord); class != startClass || startClass == classBlank {
+		// the cursor was at the end of a word, skip the blanks before the next one
+		for c.class(bigWord) == classBlank {
+			if c.inc() == stepEnd {
+				return
+			}
+		}
+	}
+
+	// move to the end of the word, the cursor overshoots by one character
+	if c.skipClass(c.class(bigWord), bigWord, true) {
+		return
+	}
+	c.dec()
+}
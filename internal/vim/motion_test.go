@@ -0,0 +1,517 @@
+package vim
+
+import (
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+var wordBuffer = NewBuffer([]string{
+	"foo.bar baz",
+	"",
+	"  key=value   end",
+	"last",
+})
+
+func Test_Resolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     models.Position
+		kind      models.MotionKind
+		wantPos   models.Position
+		wantMoved bool
+	}{
+		{
+			name:      "h at start of line",
+			start:     models.Position{X: 0, Y: 0},
+			kind:      models.MotionLeft,
+			wantPos:   models.Position{X: 0, Y: 0},
+			wantMoved: false,
+		},
+		{
+			name:      "l at end of line",
+			start:     models.Position{X: 10, Y: 0},
+			kind:      models.MotionRight,
+			wantPos:   models.Position{X: 10, Y: 0},
+			wantMoved: false,
+		},
+		{
+			name:      "j onto empty line",
+			start:     models.Position{X: 5, Y: 0},
+			kind:      models.MotionDown,
+			wantPos:   models.Position{X: 0, Y: 1},
+			wantMoved: true,
+		},
+		{
+			name:      "k on first line",
+			start:     models.Position{X: 5, Y: 0},
+			kind:      models.MotionUp,
+			wantPos:   models.Position{X: 5, Y: 0},
+			wantMoved: false,
+		},
+		{
+			name:      "w stops at punctuation",
+			start:     models.Position{X: 0, Y: 0},
+			kind:      models.MotionWordForward,
+			wantPos:   models.Position{X: 3, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "W skips punctuation",
+			start:     models.Position{X: 0, Y: 0},
+			kind:      models.MotionWORDForward,
+			wantPos:   models.Position{X: 8, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "w stops on empty line",
+			start:     models.Position{X: 8, Y: 0},
+			kind:      models.MotionWordForward,
+			wantPos:   models.Position{X: 0, Y: 1},
+			wantMoved: true,
+		},
+		{
+			name:      "w skips leading blanks",
+			start:     models.Position{X: 0, Y: 1},
+			kind:      models.MotionWordForward,
+			wantPos:   models.Position{X: 2, Y: 2},
+			wantMoved: true,
+		},
+		{
+			name:      "w on last word moves to last character",
+			start:     models.Position{X: 0, Y: 3},
+			kind:      models.MotionWordForward,
+			wantPos:   models.Position{X: 3, Y: 3},
+			wantMoved: true,
+		},
+		{
+			name:      "w on last character fails",
+			start:     models.Position{X: 3, Y: 3},
+			kind:      models.MotionWordForward,
+			wantPos:   models.Position{X: 3, Y: 3},
+			wantMoved: false,
+		},
+		{
+			name:      "b from blanks to previous word",
+			start:     models.Position{X: 13, Y: 2},
+			kind:      models.MotionWordBackward,
+			wantPos:   models.Position{X: 6, Y: 2},
+			wantMoved: true,
+		},
+		{
+			name:      "B skips punctuation",
+			start:     models.Position{X: 14, Y: 2},
+			kind:      models.MotionWORDBackward,
+			wantPos:   models.Position{X: 2, Y: 2},
+			wantMoved: true,
+		},
+		{
+			name:      "b stops on empty line",
+			start:     models.Position{X: 2, Y: 2},
+			kind:      models.MotionWordBackward,
+			wantPos:   models.Position{X: 0, Y: 1},
+			wantMoved: true,
+		},
+		{
+			name:      "b at start of buffer fails",
+			start:     models.Position{X: 0, Y: 0},
+			kind:      models.MotionWordBackward,
+			wantPos:   models.Position{X: 0, Y: 0},
+			wantMoved: false,
+		},
+		{
+			name:      "e to end of current word",
+			start:     models.Position{X: 0, Y: 0},
+			kind:      models.MotionWordEnd,
+			wantPos:   models.Position{X: 2, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "e from end of word to end of next word",
+			start:     models.Position{X: 2, Y: 0},
+			kind:      models.MotionWordEnd,
+			wantPos:   models.Position{X: 3, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "E skips punctuation",
+			start:     models.Position{X: 0, Y: 0},
+			kind:      models.MotionWORDEnd,
+			wantPos:   models.Position{X: 6, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "e skips empty lines",
+			start:     models.Position{X: 10, Y: 0},
+			kind:      models.MotionWordEnd,
+			wantPos:   models.Position{X: 4, Y: 2},
+			wantMoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, moved := Resolve(wordBuffer, tt.start, models.Motion{Kind: tt.kind})
+			if got != tt.wantPos {
+				t.Errorf("expected position %+v, got %+v", tt.wantPos, got)
+			}
+			if moved != tt.wantMoved {
+				t.Errorf("expected moved to be %v, got %v", tt.wantMoved, moved)
+			}
+		})
+	}
+}
+
+func Test_ResolveLineMotions(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     models.Position
+		motion    models.Motion
+		wantPos   models.Position
+		wantMoved bool
+	}{
+		{
+			name:      "0 to first column",
+			start:     models.Position{X: 9, Y: 2},
+			motion:    models.Motion{Kind: models.MotionStartOfLine},
+			wantPos:   models.Position{X: 0, Y: 2},
+			wantMoved: true,
+		},
+		{
+			name:      "^ skips indentation",
+			start:     models.Position{X: 9, Y: 2},
+			motion:    models.Motion{Kind: models.MotionFirstNonBlank},
+			wantPos:   models.Position{X: 2, Y: 2},
+			wantMoved: true,
+		},
+		{
+			name:      "$ to last character",
+			start:     models.Position{X: 2, Y: 2},
+			motion:    models.Motion{Kind: models.MotionEndOfLine},
+			wantPos:   models.Position{X: 16, Y: 2},
+			wantMoved: true,
+		},
+		{
+			name:      "$ on empty line does not move",
+			start:     models.Position{X: 0, Y: 1},
+			motion:    models.Motion{Kind: models.MotionEndOfLine},
+			wantPos:   models.Position{X: 0, Y: 1},
+			wantMoved: false,
+		},
+		{
+			name:      "gg keeps the column",
+			start:     models.Position{X: 3, Y: 3},
+			motion:    models.Motion{Kind: models.MotionFirstLine},
+			wantPos:   models.Position{X: 3, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "G to last line",
+			start:     models.Position{X: 10, Y: 0},
+			motion:    models.Motion{Kind: models.MotionLastLine},
+			wantPos:   models.Position{X: 3, Y: 3},
+			wantMoved: true,
+		},
+		{
+			name:      "count G to line number",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionLastLine, Count: 3},
+			wantPos:   models.Position{X: 0, Y: 2},
+			wantMoved: true,
+		},
+		{
+			name:      "count G past last line",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionLastLine, Count: 99},
+			wantPos:   models.Position{X: 0, Y: 3},
+			wantMoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, moved := Resolve(wordBuffer, tt.start, tt.motion)
+			if got != tt.wantPos {
+				t.Errorf("expected position %+v, got %+v", tt.wantPos, got)
+			}
+			if moved != tt.wantMoved {
+				t.Errorf("expected moved to be %v, got %v", tt.wantMoved, moved)
+			}
+		})
+	}
+}
+
+func Test_ResolveCounts(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     models.Position
+		motion    models.Motion
+		wantPos   models.Position
+		wantMoved bool
+	}{
+		{
+			name:      "count l",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionRight, Count: 4},
+			wantPos:   models.Position{X: 4, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "count h stops at start of line",
+			start:     models.Position{X: 2, Y: 0},
+			motion:    models.Motion{Kind: models.MotionLeft, Count: 9},
+			wantPos:   models.Position{X: 0, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "count j",
+			start:     models.Position{X: 2, Y: 0},
+			motion:    models.Motion{Kind: models.MotionDown, Count: 2},
+			wantPos:   models.Position{X: 2, Y: 2},
+			wantMoved: true,
+		},
+		{
+			name:      "count j stops on last line",
+			start:     models.Position{X: 2, Y: 0},
+			motion:    models.Motion{Kind: models.MotionDown, Count: 20},
+			wantPos:   models.Position{X: 2, Y: 3},
+			wantMoved: true,
+		},
+		{
+			name:      "count w",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionWordForward, Count: 3},
+			wantPos:   models.Position{X: 8, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "count w stops at end of buffer",
+			start:     models.Position{X: 0, Y: 2},
+			motion:    models.Motion{Kind: models.MotionWordForward, Count: 50},
+			wantPos:   models.Position{X: 3, Y: 3},
+			wantMoved: true,
+		},
+		{
+			name:      "count $ moves down",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionEndOfLine, Count: 3},
+			wantPos:   models.Position{X: 16, Y: 2},
+			wantMoved: true,
+		},
+		{
+			name:      "count $ past last line fails",
+			start:     models.Position{X: 0, Y: 2},
+			motion:    models.Motion{Kind: models.MotionEndOfLine, Count: 3},
+			wantPos:   models.Position{X: 0, Y: 2},
+			wantMoved: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, moved := Resolve(wordBuffer, tt.start, tt.motion)
+			if got != tt.wantPos {
+				t.Errorf("expected position %+v, got %+v", tt.wantPos, got)
+			}
+			if moved != tt.wantMoved {
+				t.Errorf("expected moved to be %v, got %v", tt.wantMoved, moved)
+			}
+		})
+	}
+}
+
+func Test_ResolveFind(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     models.Position
+		motion    models.Motion
+		wantPos   models.Position
+		wantMoved bool
+	}{
+		{
+			name:      "f onto character",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionFindForward, Char: 'a'},
+			wantPos:   models.Position{X: 5, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "count f",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionFindForward, Char: 'a', Count: 2},
+			wantPos:   models.Position{X: 9, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "count f with too few occurrences",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionFindForward, Char: 'a', Count: 3},
+			wantPos:   models.Position{X: 0, Y: 0},
+			wantMoved: false,
+		},
+		{
+			name:      "f does not search other lines",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionFindForward, Char: 'k'},
+			wantPos:   models.Position{X: 0, Y: 0},
+			wantMoved: false,
+		},
+		{
+			name:      "t in front of character",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionTillForward, Char: 'a'},
+			wantPos:   models.Position{X: 4, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "F onto previous character",
+			start:     models.Position{X: 10, Y: 0},
+			motion:    models.Motion{Kind: models.MotionFindBackward, Char: 'o'},
+			wantPos:   models.Position{X: 2, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "T behind previous character",
+			start:     models.Position{X: 10, Y: 0},
+			motion:    models.Motion{Kind: models.MotionTillBackward, Char: 'o'},
+			wantPos:   models.Position{X: 3, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "t next to character does not move",
+			start:     models.Position{X: 4, Y: 0},
+			motion:    models.Motion{Kind: models.MotionTillForward, Char: 'a'},
+			wantPos:   models.Position{X: 4, Y: 0},
+			wantMoved: false,
+		},
+		{
+			name:      "repeated t skips character next to cursor",
+			start:     models.Position{X: 4, Y: 0},
+			motion:    models.Motion{Kind: models.MotionTillForward, Char: 'a', Repeated: true},
+			wantPos:   models.Position{X: 8, Y: 0},
+			wantMoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, moved := Resolve(wordBuffer, tt.start, tt.motion)
+			if got != tt.wantPos {
+				t.Errorf("expected position %+v, got %+v", tt.wantPos, got)
+			}
+			if moved != tt.wantMoved {
+				t.Errorf("expected moved to be %v, got %v", tt.wantMoved, moved)
+			}
+		})
+	}
+}
+
+func Test_RepeatFind(t *testing.T) {
+	last := models.Motion{Kind: models.MotionTillForward, Char: 'a', Keys: []string{"t", "a"}}
+
+	got := RepeatFind(last, models.Motion{Kind: models.MotionRepeatFind, Count: 2, Keys: []string{"2", ";"}})
+	if got.Kind != models.MotionTillForward || got.Char != 'a' || got.Count != 2 || !got.Repeated {
+		t.Errorf("expected repeated t with count 2, got %+v", got)
+	}
+
+	got = RepeatFind(last, models.Motion{Kind: models.MotionRepeatFindReverse, Keys: []string{","}})
+	if got.Kind != models.MotionTillBackward || got.Char != 'a' || !got.Repeated {
+		t.Errorf("expected repeated T, got %+v", got)
+	}
+}
+
+func Test_ResolveWalls(t *testing.T) {
+	buf := NewBlankBuffer(10, 10)
+	walls := map[models.Position]bool{
+		{X: 7, Y: 5}: true,
+		{X: 5, Y: 2}: true,
+		{X: 4, Y: 5}: true,
+	}
+	buf.SetWalls(func(pos models.Position) bool { return walls[pos] })
+	start := models.Position{X: 5, Y: 5}
+
+	tests := []struct {
+		name      string
+		kind      models.MotionKind
+		count     int
+		wantPos   models.Position
+		wantMoved bool
+	}{
+		{name: "$ stops in front of wall", kind: models.MotionEndOfLine, wantPos: models.Position{X: 6, Y: 5}, wantMoved: true},
+		{name: "gg stops in front of wall", kind: models.MotionFirstLine, wantPos: models.Position{X: 5, Y: 3}, wantMoved: true},
+		{name: "G without walls", kind: models.MotionLastLine, wantPos: models.Position{X: 5, Y: 9}, wantMoved: true},
+		{name: "h into wall", kind: models.MotionLeft, wantPos: start, wantMoved: false},
+		{name: "0 blocked by adjacent wall", kind: models.MotionStartOfLine, wantPos: start, wantMoved: false},
+		{name: "count k stops in front of wall", kind: models.MotionUp, count: 4, wantPos: models.Position{X: 5, Y: 3}, wantMoved: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, moved := Resolve(buf, start, models.Motion{Kind: tt.kind, Count: tt.count})
+			if got != tt.wantPos {
+				t.Errorf("expected position %+v, got %+v", tt.wantPos, got)
+			}
+			if moved != tt.wantMoved {
+				t.Errorf("expected moved to be %v, got %v", tt.wantMoved, moved)
+			}
+		})
+	}
+}
+
+func Test_Counts(t *testing.T) {
+	walled := NewBlankBuffer(12, 6)
+	walls := map[models.Position]bool{{X: 8, Y: 2}: true, {X: 2, Y: 5}: true, {X: 3, Y: 0}: true}
+	walled.SetWalls(func(pos models.Position) bool { return walls[pos] })
+	kinds := []models.MotionKind{
+		models.MotionLeft, models.MotionRight, models.MotionUp, models.MotionDown,
+		models.MotionWordForward, models.MotionWordBackward, models.MotionWordEnd, models.MotionEndOfLine,
+	}
+
+	for _, buf := range []*Buffer{wordBuffer, walled} {
+		for y := range buf.LineCount() {
+			for x := range max(len(buf.Line(y)), 1) {
+				start := models.Position{X: x, Y: y}
+				for _, kind := range kinds {
+					last := 0
+					Counts(buf, start, models.Motion{Kind: kind}, 20, func(count int, to models.Position, moved bool) bool {
+						last = count
+						want, wantMoved := Resolve(buf, start, models.Motion{Kind: kind, Count: count})
+						if to != want || moved != wantMoved {
+							t.Errorf("%v with count %d from %v: expected %v (%v), got %v (%v)", kind, count, start, want, wantMoved, to, moved)
+						}
+						return true
+					})
+					// the counts only end early when a larger count no longer moves the cursor further
+					if last < 20 {
+						before, _ := Resolve(buf, start, models.Motion{Kind: kind, Count: last})
+						if after, _ := Resolve(buf, start, models.Motion{Kind: kind, Count: 20}); after != before {
+							t.Errorf("%v from %v: expected the counts to go on after %d, %v moves to %v", kind, start, last, before, after)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func Test_BufferClamp(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  models.Position
+		want models.Position
+	}{
+		{name: "valid position", pos: models.Position{X: 3, Y: 0}, want: models.Position{X: 3, Y: 0}},
+		{name: "past end of line", pos: models.Position{X: 20, Y: 3}, want: models.Position{X: 3, Y: 3}},
+		{name: "empty line", pos: models.Position{X: 4, Y: 1}, want: models.Position{X: 0, Y: 1}},
+		{name: "below last line", pos: models.Position{X: 1, Y: 9}, want: models.Position{X: 1, Y: 3}},
+		{name: "negative position", pos: models.Position{X: -1, Y: -1}, want: models.Position{X: 0, Y: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wordBuffer.Clamp(tt.pos); got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
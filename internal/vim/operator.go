@@ -0,0 +1,202 @@
+package vim
+
+import (
+	"strings"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// Span is the text an operator acts on, it starts at Start and ends in front of End.
+// A linewise Span covers the whole lines from Start.Y up to and including End.Y,
+// a block Span covers the columns from Start.X up to End.X on each of those lines
+type Span struct {
+	Start    models.Position
+	End      models.Position
+	Linewise bool
+	Block    bool
+}
+
+// Register holds the text of the last delete, change or yank
+type Register struct {
+	Text     string
+	Linewise bool
+}
+
+// MotionSpan returns the Span an operator acts on when it is followed by the models.Motion,
+// end is where the motion takes the cursor from pos. It reports false when the Span is empty
+func MotionSpan(buf *Buffer, pos, end models.Position, motion models.Motion, op models.Operator) (Span, bool) {
+	start := buf.Clamp(pos)
+	count := max(motion.Count, 1)
+
+	switch kind := motion.Kind; {
+	case kind.IsLinewise():
+		return Span{
+			Start:    models.Position{Y: min(start.Y, end.Y)},
+			End:      models.Position{Y: max(start.Y, end.Y)},
+			Linewise: true,
+		}, true
+	case kind == models.MotionWordForward || kind == models.MotionWORDForward:
+		end = wordSpanEnd(buf, start, count, kind == models.MotionWORDForward, op == models.OperatorChange)
+	case kind == models.MotionRight:
+		// like x the characters under and after the cursor are used, even on the last character
+		end = models.Position{X: min(start.X+count, len(buf.Line(start.Y))), Y: start.Y}
+	case kind.IsInclusive():
+		end.X = min(end.X+1, len(buf.Line(end.Y)))
+	default:
+		if before(end, start) {
+			start, end = end, start
+		}
+		// like in Vim an exclusive motion that ends at the start of a line stops at the end of the line above
+		if end.Y > start.Y && end.X == 0 {
+			end = models.Position{X: len(buf.Line(end.Y - 1)), Y: end.Y - 1}
+		}
+	}
+
+	span := Span{Start: start, End: end}
+	return span, before(start, end)
+}
+
+// wordSpanEnd returns the end of the Span of w or W, which never goes past the end of the line
+// the last word is on. Like in Vim cw on a word only changes up to the end of the word
+func wordSpanEnd(buf *Buffer, start models.Position, count int, bigWord, change bool) models.Position {
+	c := &cursor{buf: buf, pos: start}
+	if change && c.class(bigWord) != classBlank {
+		for i := range count {
+			// the word the cursor is at the end of counts as the first word
+			if i > 0 || !c.atWordEnd(bigWord) {
+				c.wordEnd(bigWord)
+			}
+		}
+		return models.Position{X: min(c.pos.X+1, len(c.line())), Y: c.pos.Y}
+	}
+
+	c.repeat(count, func() { c.wordForward(bigWord) })
+	if c.pos.Y > start.Y && c.onlyBlanksBefore() {
+		return models.Position{X: len(buf.Line(c.pos.Y - 1)), Y: c.pos.Y - 1}
+	}
+	return c.pos
+}
+
+// atWordEnd reports whether the cursor is on the last character of a word
+func (c *cursor) atWordEnd(bigWord bool) bool {
+	line := c.line()
+	next := c.pos.X + 1
+	return next >= len(line) || charClass(line[next], bigWord) != c.class(bigWord)
+}
+
+// onlyBlanksBefore reports whether the characters in front of the cursor on its line are all blanks
+func (c *cursor) onlyBlanksBefore() bool {
+	for _, r := range c.line()[:min(c.pos.X, len(c.line()))] {
+		if r != ' ' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// LineSpan returns the linewise Span of count lines starting at the line of pos, like dd does
+func LineSpan(buf *Buffer, pos models.Position, count int) Span {
+	y := buf.Clamp(pos).Y
+	return Span{
+		Start:    models.Position{Y: y},
+		End:      models.Position{Y: min(y+max(count, 1), buf.LineCount()) - 1},
+		Linewise: true,
+	}
+}
+
+// Operate applies the models.Operator to the Span of the Buffer, it returns the Register
+// holding the text the operator acted on and the position of the cursor afterwards
+func Operate(buf *Buffer, pos models.Position, span Span, op models.Operator) (Register, models.Position) {
+	register := Register{Text: buf.Text(span), Linewise: span.Linewise}
+
+	switch {
+	case op == models.OperatorYank && span.Linewise:
+		// the cursor only moves when lines above it were yanked
+		return register, buf.Clamp(models.Position{X: pos.X, Y: min(pos.Y, span.Start.Y)})
+	case op == models.OperatorYank:
+		return register, buf.Clamp(span.Start)
+	case span.Block:
+		// unlike in Vim a changed block is only typed into on its first line
+		for y := span.Start.Y; y <= span.End.Y; y++ {
+			line := buf.Line(y)
+			from, to := min(span.Start.X, len(line)), min(span.End.X, len(line))
+			buf.lines[y] = append(append([]rune{}, line[:from]...), line[to:]...)
+		}
+		if op == models.OperatorChange {
+			return register, buf.insertClamp(span.Start)
+		}
+		return register, buf.Clamp(span.Start)
+	case op == models.OperatorChange && span.Linewise:
+		// the lines are replaced by a single empty line that is ready to be typed into
+		buf.replace(span.Start.Y, span.End.Y, [][]rune{{}})
+		return register, models.Position{Y: span.Start.Y}
+	case span.Linewise:
+		buf.replace(span.Start.Y, span.End.Y, nil)
+		c := &cursor{buf: buf, pos: buf.Clamp(span.Start)}
+		c.firstNonBlank()
+		c.adjust()
+		return register, c.pos
+	default:
+		first, last := buf.Line(span.Start.Y), buf.Line(span.End.Y)
+		joined := append(append([]rune{}, first[:span.Start.X]...), last[span.End.X:]...)
+		buf.replace(span.Start.Y, span.End.Y, [][]rune{joined})
+		if op == models.OperatorChange {
+			// the cursor stays where the new text is typed, which can be after the last character
+			return register, span.Start
+		}
+		return register, buf.Clamp(span.Start)
+	}
+}
+
+// Text returns the text covered by the Span, the lines of a linewise Span all end with a newline
+// and the lines of a block Span are separated by one
+func (b *Buffer) Text(span Span) string {
+	var sb strings.Builder
+	if span.Block {
+		for y := span.Start.Y; y <= span.End.Y; y++ {
+			line := b.Line(y)
+			if y > span.Start.Y {
+				sb.WriteByte('\n')
+			}
+			sb.WriteString(string(line[min(span.Start.X, len(line)):min(span.End.X, len(line))]))
+		}
+		return sb.String()
+	}
+	if span.Linewise {
+		for y := span.Start.Y; y <= span.End.Y; y++ {
+			sb.WriteString(string(b.Line(y)))
+			sb.WriteByte('\n')
+		}
+		return sb.String()
+	}
+
+	for y := span.Start.Y; y <= span.End.Y; y++ {
+		line := b.Line(y)
+		from, to := 0, len(line)
+		if y == span.Start.Y {
+			from = min(span.Start.X, len(line))
+		}
+		if y == span.End.Y {
+			to = min(span.End.X, len(line))
+		}
+		if y > span.Start.Y {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(string(line[from:max(from, to)]))
+	}
+	return sb.String()
+}
+
+// replace replaces the lines from first up to and including last with the given lines,
+// like in Vim the Buffer keeps at least one line
+func (b *Buffer) replace(first, last int, lines [][]rune) {
+	b.lines = append(b.lines[:first], append(lines, b.lines[last+1:]...)...)
+	if len(b.lines) == 0 {
+		b.lines = [][]rune{{}}
+	}
+}
+
+// before reports whether the position a comes before b in the Buffer
+func before(a, b models.Position) bool {
+	return a.Y < b.Y || (a.Y == b.Y && a.X < b.X)
+}
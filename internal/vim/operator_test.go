@@ -0,0 +1,342 @@
+package vim
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+var operatorText = []string{
+	"one two three",
+	"four five",
+	"",
+	"  six seven",
+}
+
+func Test_Operate(t *testing.T) {
+	tests := []struct {
+		name         string
+		start        models.Position
+		op           models.Operator
+		motion       models.Motion
+		wantLines    []string
+		wantRegister string
+		wantPos      models.Position
+	}{
+		{
+			name:         "dw",
+			start:        models.Position{X: 4, Y: 0},
+			op:           models.OperatorDelete,
+			motion:       models.Motion{Kind: models.MotionWordForward},
+			wantLines:    []string{"one three", "four five", "", "  six seven"},
+			wantRegister: "two ",
+			wantPos:      models.Position{X: 4, Y: 0},
+		},
+		{
+			name:         "dw on last word of line stays on the line",
+			start:        models.Position{X: 8, Y: 0},
+			op:           models.OperatorDelete,
+			motion:       models.Motion{Kind: models.MotionWordForward},
+			wantLines:    []string{"one two ", "four five", "", "  six seven"},
+			wantRegister: "three",
+			wantPos:      models.Position{X: 7, Y: 0},
+		},
+		{
+			name:         "d2w",
+			start:        models.Position{X: 0, Y: 0},
+			op:           models.OperatorDelete,
+			motion:       models.Motion{Kind: models.MotionWordForward, Count: 2},
+			wantLines:    []string{"three", "four five", "", "  six seven"},
+			wantRegister: "one two ",
+			wantPos:      models.Position{X: 0, Y: 0},
+		},
+		{
+			name:         "cw changes up to the end of the word",
+			start:        models.Position{X: 4, Y: 0},
+			op:           models.OperatorChange,
+			motion:       models.Motion{Kind: models.MotionWordForward},
+			wantLines:    []string{"one  three", "four five", "", "  six seven"},
+			wantRegister: "two",
+			wantPos:      models.Position{X: 4, Y: 0},
+		},
+		{
+			name:         "cw on the last word keeps the cursor after the line",
+			start:        models.Position{X: 8, Y: 0},
+			op:           models.OperatorChange,
+			motion:       models.Motion{Kind: models.MotionWordForward},
+			wantLines:    []string{"one two ", "four five", "", "  six seven"},
+			wantRegister: "three",
+			wantPos:      models.Position{X: 8, Y: 0},
+		},
+		{
+			name:         "de is inclusive",
+			start:        models.Position{X: 0, Y: 1},
+			op:           models.OperatorDelete,
+			motion:       models.Motion{Kind: models.MotionWordEnd},
+			wantLines:    []string{"one two three", " five", "", "  six seven"},
+			wantRegister: "four",
+			wantPos:      models.Position{X: 0, Y: 1},
+		},
+		{
+			name:         "d$",
+			start:        models.Position{X: 3, Y: 0},
+			op:           models.OperatorDelete,
+			motion:       models.Motion{Kind: models.MotionEndOfLine},
+			wantLines:    []string{"one", "four five", "", "  six seven"},
+			wantRegister: " two three",
+			wantPos:      models.Position{X: 2, Y: 0},
+		},
+		{
+			name:         "db is exclusive",
+			start:        models.Position{X: 8, Y: 0},
+			op:           models.OperatorDelete,
+			motion:       models.Motion{Kind: models.MotionWordBackward},
+			wantLines:    []string{"one three", "four five", "", "  six seven"},
+			wantRegister: "two ",
+			wantPos:      models.Position{X: 4, Y: 0},
+		},
+		{
+			name:         "dt stops in front of the character",
+			start:        models.Position{X: 0, Y: 0},
+			op:           models.OperatorDelete,
+			motion:       models.Motion{Kind: models.MotionTillForward, Char: 'h'},
+			wantLines:    []string{"hree", "four five", "", "  six seven"},
+			wantRegister: "one two t",
+			wantPos:      models.Position{X: 0, Y: 0},
+		},
+		{
+			name:         "dl on the last character",
+			start:        models.Position{X: 8, Y: 1},
+			op:           models.OperatorDelete,
+			motion:       models.Motion{Kind: models.MotionRight},
+			wantLines:    []string{"one two three", "four fiv", "", "  six seven"},
+			wantRegister: "e",
+			wantPos:      models.Position{X: 7, Y: 1},
+		},
+		{
+			name:         "dj is linewise",
+			start:        models.Position{X: 5, Y: 1},
+			op:           models.OperatorDelete,
+			motion:       models.Motion{Kind: models.MotionDown},
+			wantLines:    []string{"one two three", "  six seven"},
+			wantRegister: "four five\n\n",
+			wantPos:      models.Position{X: 2, Y: 1},
+		},
+		{
+			name:         "yk moves the cursor up",
+			start:        models.Position{X: 3, Y: 1},
+			op:           models.OperatorYank,
+			motion:       models.Motion{Kind: models.MotionUp},
+			wantLines:    operatorText,
+			wantRegister: "one two three\nfour five\n",
+			wantPos:      models.Position{X: 3, Y: 0},
+		},
+		{
+			name:         "yb leaves the text",
+			start:        models.Position{X: 4, Y: 3},
+			op:           models.OperatorYank,
+			motion:       models.Motion{Kind: models.MotionWordBackward},
+			wantLines:    operatorText,
+			wantRegister: "si",
+			wantPos:      models.Position{X: 2, Y: 3},
+		},
+		{
+			name:         "cG leaves an empty line",
+			start:        models.Position{X: 0, Y: 2},
+			op:           models.OperatorChange,
+			motion:       models.Motion{Kind: models.MotionLastLine},
+			wantLines:    []string{"one two three", "four five", ""},
+			wantRegister: "\n  six seven\n",
+			wantPos:      models.Position{X: 0, Y: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := NewBuffer(operatorText)
+			end, _ := Resolve(buf, tt.start, tt.motion)
+			span, ok := MotionSpan(buf, tt.start, end, tt.motion, tt.op)
+			if !ok {
+				t.Fatalf("expected a span for %v", tt.motion.Kind)
+			}
+
+			register, pos := Operate(buf, tt.start, span, tt.op)
+			if !slices.Equal(buf.Lines(), tt.wantLines) {
+				t.Errorf("expected lines %q, got %q", tt.wantLines, buf.Lines())
+			}
+			if register.Text != tt.wantRegister {
+				t.Errorf("expected register %q, got %q", tt.wantRegister, register.Text)
+			}
+			if register.Linewise != tt.motion.Kind.IsLinewise() {
+				t.Errorf("expected linewise register to be %v", tt.motion.Kind.IsLinewise())
+			}
+			if pos != tt.wantPos {
+				t.Errorf("expected cursor at %v, got %v", tt.wantPos, pos)
+			}
+		})
+	}
+}
+
+func Test_MotionSpanEmpty(t *testing.T) {
+	buf := NewBuffer(operatorText)
+	start := models.Position{X: 0, Y: 0}
+	end, _ := Resolve(buf, start, models.Motion{Kind: models.MotionStartOfLine})
+	if _, ok := MotionSpan(buf, start, end, models.Motion{Kind: models.MotionStartOfLine}, models.OperatorDelete); ok {
+		t.Errorf("expected d0 at the start of a line to have no span")
+	}
+}
+
+func Test_LineSpan(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     models.Position
+		count     int
+		wantLines []string
+	}{
+		{name: "dd", start: models.Position{X: 4, Y: 1}, count: 0, wantLines: []string{"one two three", "", "  six seven"}},
+		{name: "3dd", start: models.Position{X: 0, Y: 0}, count: 3, wantLines: []string{"  six seven"}},
+		{name: "count past the last line", start: models.Position{X: 0, Y: 2}, count: 5, wantLines: []string{"one two three", "four five"}},
+		{name: "every line", start: models.Position{X: 0, Y: 0}, count: 4, wantLines: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := NewBuffer(operatorText)
+			Operate(buf, tt.start, LineSpan(buf, tt.start, tt.count), models.OperatorDelete)
+			if !slices.Equal(buf.Lines(), tt.wantLines) {
+				t.Errorf("expected lines %q, got %q", tt.wantLines, buf.Lines())
+			}
+		})
+	}
+}
+
+func Test_TextObjectSpan(t *testing.T) {
+	line := NewBuffer([]string{"say foo.bar  now", ""})
+	tests := []struct {
+		name     string
+		start    models.Position
+		object   models.TextObject
+		count    int
+		wantText string
+		wantOK   bool
+	}{
+		{name: "iw", start: models.Position{X: 5, Y: 0}, object: models.TextObjectInnerWord, wantText: "foo", wantOK: true},
+		{name: "iW", start: models.Position{X: 5, Y: 0}, object: models.TextObjectInnerWORD, wantText: "foo.bar", wantOK: true},
+		{name: "iw on blanks", start: models.Position{X: 11, Y: 0}, object: models.TextObjectInnerWord, wantText: "  ", wantOK: true},
+		{name: "3iw", start: models.Position{X: 0, Y: 0}, object: models.TextObjectInnerWord, count: 3, wantText: "say foo", wantOK: true},
+		{name: "aw takes the blanks after", start: models.Position{X: 1, Y: 0}, object: models.TextObjectAWord, wantText: "say ", wantOK: true},
+		{name: "aw takes the blanks before at the end", start: models.Position{X: 14, Y: 0}, object: models.TextObjectAWord, wantText: "  now", wantOK: true},
+		{name: "aw on blanks takes the word after", start: models.Position{X: 3, Y: 0}, object: models.TextObjectAWord, wantText: " foo", wantOK: true},
+		{name: "aW", start: models.Position{X: 8, Y: 0}, object: models.TextObjectAWORD, wantText: "foo.bar  ", wantOK: true},
+		{name: "2aw", start: models.Position{X: 0, Y: 0}, object: models.TextObjectAWord, count: 2, wantText: "say foo", wantOK: true},
+		{name: "iw on an empty line", start: models.Position{X: 0, Y: 1}, object: models.TextObjectInnerWord, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span, ok := TextObjectSpan(line, tt.start, tt.object, tt.count)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok to be %v, got %v", tt.wantOK, ok)
+			}
+			if ok && line.Text(span) != tt.wantText {
+				t.Errorf("expected %q, got %q", tt.wantText, line.Text(span))
+			}
+		})
+	}
+}
+
+func Test_TextObjectSpanDelimited(t *testing.T) {
+	buf := NewBuffer([]string{
+		`say("hi", 'yo')  x = "a \" b"`,
+		"if f(a, (b + c)) {",
+		"    return [1, 2]",
+		"}",
+		"<p>Hello <b>bold</b> text</p>",
+		"()",
+		"x = {",
+		"}",
+	})
+	tests := []struct {
+		name         string
+		start        models.Position
+		object       models.TextObject
+		count        int
+		wantText     string
+		wantLinewise bool
+		wantOK       bool
+	}{
+		{name: `i" inside`, start: models.Position{X: 6, Y: 0}, object: models.TextObjectInnerDoubleQuote, wantText: "hi", wantOK: true},
+		{name: `i" on the closing quote`, start: models.Position{X: 7, Y: 0}, object: models.TextObjectInnerDoubleQuote, wantText: "hi", wantOK: true},
+		{name: `i" before a string looks forward`, start: models.Position{X: 0, Y: 0}, object: models.TextObjectInnerDoubleQuote, wantText: "hi", wantOK: true},
+		{name: `i" skips escaped quotes`, start: models.Position{X: 23, Y: 0}, object: models.TextObjectInnerDoubleQuote, wantText: `a \" b`, wantOK: true},
+		{name: `a" takes the blanks before at the end`, start: models.Position{X: 23, Y: 0}, object: models.TextObjectADoubleQuote, wantText: ` "a \" b"`, wantOK: true},
+		{name: `a' takes the blank before without blanks after`, start: models.Position{X: 12, Y: 0}, object: models.TextObjectASingleQuote, wantText: ` 'yo'`, wantOK: true},
+		{name: "i( innermost", start: models.Position{X: 11, Y: 1}, object: models.TextObjectInnerParen, wantText: "b + c", wantOK: true},
+		{name: "2i( takes the enclosing block", start: models.Position{X: 11, Y: 1}, object: models.TextObjectInnerParen, count: 2, wantText: "a, (b + c)", wantOK: true},
+		{name: "a( on the opening paren", start: models.Position{X: 8, Y: 1}, object: models.TextObjectAParen, wantText: "(b + c)", wantOK: true},
+		{name: "a( on the closing paren", start: models.Position{X: 15, Y: 1}, object: models.TextObjectAParen, wantText: "(a, (b + c))", wantOK: true},
+		{name: "i[", start: models.Position{X: 12, Y: 2}, object: models.TextObjectInnerBracket, wantText: "1, 2", wantOK: true},
+		{name: "i{ over lines is linewise", start: models.Position{X: 4, Y: 2}, object: models.TextObjectInnerBrace, wantText: "    return [1, 2]\n", wantLinewise: true, wantOK: true},
+		{name: "a{ over lines", start: models.Position{X: 4, Y: 2}, object: models.TextObjectABrace, wantText: "{\n    return [1, 2]\n}", wantOK: true},
+		{name: "i( without a block", start: models.Position{X: 0, Y: 3}, object: models.TextObjectInnerParen, wantOK: false},
+		{name: "i( on an empty block", start: models.Position{X: 0, Y: 5}, object: models.TextObjectInnerParen, wantOK: false},
+		{name: "i{ on an empty block over lines", start: models.Position{X: 4, Y: 6}, object: models.TextObjectInnerBrace, wantOK: false},
+		{name: "it", start: models.Position{X: 13, Y: 4}, object: models.TextObjectInnerTag, wantText: "bold", wantOK: true},
+		{name: "at", start: models.Position{X: 13, Y: 4}, object: models.TextObjectATag, wantText: "<b>bold</b>", wantOK: true},
+		{name: "2it", start: models.Position{X: 13, Y: 4}, object: models.TextObjectInnerTag, count: 2, wantText: "Hello <b>bold</b> text", wantOK: true},
+		{name: "it on a tag", start: models.Position{X: 1, Y: 4}, object: models.TextObjectInnerTag, wantText: "Hello <b>bold</b> text", wantOK: true},
+		{name: "it outside tags", start: models.Position{X: 0, Y: 0}, object: models.TextObjectInnerTag, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span, ok := TextObjectSpan(buf, tt.start, tt.object, tt.count)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok to be %v, got %v", tt.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if buf.Text(span) != tt.wantText {
+				t.Errorf("expected %q, got %q", tt.wantText, buf.Text(span))
+			}
+			if span.Linewise != tt.wantLinewise {
+				t.Errorf("expected linewise to be %v, got %v", tt.wantLinewise, span.Linewise)
+			}
+		})
+	}
+}
+
+func Test_TextObjectSpanParagraph(t *testing.T) {
+	buf := NewBuffer([]string{"one", "two", "", "  ", "three", "", "four"})
+	tests := []struct {
+		name      string
+		start     models.Position
+		object    models.TextObject
+		count     int
+		wantLines [2]int
+		wantOK    bool
+	}{
+		{name: "ip", start: models.Position{Y: 1}, object: models.TextObjectInnerParagraph, wantLines: [2]int{0, 1}, wantOK: true},
+		{name: "ip on blank lines", start: models.Position{Y: 2}, object: models.TextObjectInnerParagraph, wantLines: [2]int{2, 3}, wantOK: true},
+		{name: "2ip counts the blank lines", start: models.Position{Y: 0}, object: models.TextObjectInnerParagraph, count: 2, wantLines: [2]int{0, 3}, wantOK: true},
+		{name: "ap takes the blank lines after", start: models.Position{Y: 0}, object: models.TextObjectAParagraph, wantLines: [2]int{0, 3}, wantOK: true},
+		{name: "ap on blank lines takes the paragraph after", start: models.Position{Y: 3}, object: models.TextObjectAParagraph, wantLines: [2]int{2, 4}, wantOK: true},
+		{name: "ap at the end takes the blank lines before", start: models.Position{Y: 6}, object: models.TextObjectAParagraph, wantLines: [2]int{5, 6}, wantOK: true},
+		{name: "2ap", start: models.Position{Y: 0}, object: models.TextObjectAParagraph, count: 2, wantLines: [2]int{0, 5}, wantOK: true},
+		{name: "ip past the end", start: models.Position{Y: 6}, object: models.TextObjectInnerParagraph, count: 2, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span, ok := TextObjectSpan(buf, tt.start, tt.object, tt.count)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok to be %v, got %v", tt.wantOK, ok)
+			}
+			if ok && (!span.Linewise || span.Start.Y != tt.wantLines[0] || span.End.Y != tt.wantLines[1]) {
+				t.Errorf("expected lines %v, got %d to %d linewise %v", tt.wantLines, span.Start.Y, span.End.Y, span.Linewise)
+			}
+		})
+	}
+}
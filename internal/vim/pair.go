@@ -0,0 +1,74 @@
+package vim
+
+import "github.com/dasvh/go-learn-vim/internal/models"
+
+// pairs maps every bracket % jumps from to the bracket that matches it
+var pairs = map[rune]rune{'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{'}
+
+// NextBracket returns the position of the first bracket under or after pos in its line,
+// which is the bracket % jumps from, and whether there is one
+func NextBracket(buf *Buffer, pos models.Position) (models.Position, bool) {
+	line := buf.Line(pos.Y)
+	for x := max(pos.X, 0); x < len(line); x++ {
+		if _, ok := pairs[line[x]]; ok {
+			return models.Position{X: x, Y: pos.Y}, true
+		}
+	}
+	return models.Position{}, false
+}
+
+// matchPair moves the cursor to the bracket that matches the first bracket under or after it in the line and
+// reports whether there is one, nested pairs of the same brackets are skipped. Like in Vim brackets inside
+// double quotes and character literals like '(' are skipped as well, unless the bracket the cursor starts from is quoted
+func (c *cursor) matchPair() bool {
+	from, ok := NextBracket(c.buf, c.pos)
+	if !ok {
+		return false
+	}
+	open := c.buf.runeAt(from)
+	close := pairs[open]
+	quoted := isQuoted(c.buf.Line(from.Y), from.X)
+
+	s := &cursor{buf: c.buf, pos: from}
+	step := s.dec
+	if open == '(' || open == '[' || open == '{' {
+		step = s.inc
+	}
+	depth := 0
+	for step() != stepEnd {
+		r := c.buf.runeAt(s.pos)
+		if r != open && r != close || !quoted && isQuoted(c.buf.Line(s.pos.Y), s.pos.X) {
+			continue
+		}
+		if r == open {
+			depth++
+			continue
+		}
+		if depth == 0 {
+			c.pos = s.pos
+			return true
+		}
+		depth--
+	}
+	return false
+}
+
+// isQuoted reports whether the character at x is inside a double quoted string or is a character literal like '('
+func isQuoted(line []rune, x int) bool {
+	if x > 0 && x+1 < len(line) && line[x-1] == '\'' && line[x+1] == '\'' {
+		return true
+	}
+	inside := false
+	for i := 0; i < x; i++ {
+		switch {
+		case line[i] == '\\':
+			i++
+		case line[i] == '\'' && !inside && i+2 < len(line) && line[i+2] == '\'':
+			// a character literal like '"' does not start a string
+			i += 2
+		case line[i] == '"':
+			inside = !inside
+		}
+	}
+	return inside
+}
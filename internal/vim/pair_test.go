@@ -0,0 +1,56 @@
+package vim
+
+import (
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// pairText has nested brackets, brackets inside a string and a character literal
+// and a bracket in a comment that has no match
+var pairText = []string{
+	`if f(a[i], "(") {`,
+	`    g(')', b) // :)`,
+	`}`,
+	`done`,
+}
+
+func Test_ResolveMatchPair(t *testing.T) {
+	buf := NewBuffer(pairText)
+	tests := []struct {
+		name      string
+		start     models.Position
+		wantPos   models.Position
+		wantMoved bool
+	}{
+		{name: "( skips the quoted bracket", start: models.Position{X: 4, Y: 0}, wantPos: models.Position{X: 14, Y: 0}, wantMoved: true},
+		{name: "the first bracket after the cursor", start: models.Position{X: 0, Y: 0}, wantPos: models.Position{X: 14, Y: 0}, wantMoved: true},
+		{name: ") back to (", start: models.Position{X: 14, Y: 0}, wantPos: models.Position{X: 4, Y: 0}, wantMoved: true},
+		{name: "nested [", start: models.Position{X: 6, Y: 0}, wantPos: models.Position{X: 8, Y: 0}, wantMoved: true},
+		{name: "{ over lines", start: models.Position{X: 16, Y: 0}, wantPos: models.Position{X: 0, Y: 2}, wantMoved: true},
+		{name: "} back over lines", start: models.Position{X: 0, Y: 2}, wantPos: models.Position{X: 16, Y: 0}, wantMoved: true},
+		{name: "( skips the character literal", start: models.Position{X: 5, Y: 1}, wantPos: models.Position{X: 12, Y: 1}, wantMoved: true},
+		{name: "quoted ( counts every bracket", start: models.Position{X: 12, Y: 0}, wantPos: models.Position{X: 14, Y: 0}, wantMoved: true},
+		{name: "unmatched )", start: models.Position{X: 18, Y: 1}, wantPos: models.Position{X: 18, Y: 1}, wantMoved: false},
+		{name: "no bracket in the line", start: models.Position{X: 0, Y: 3}, wantPos: models.Position{X: 0, Y: 3}, wantMoved: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos, moved := Resolve(buf, tt.start, models.Motion{Kind: models.MotionMatchPair})
+			if pos != tt.wantPos || moved != tt.wantMoved {
+				t.Errorf("expected %v, %v, got %v, %v", tt.wantPos, tt.wantMoved, pos, moved)
+			}
+		})
+	}
+}
+
+func Test_NextBracket(t *testing.T) {
+	buf := NewBuffer(pairText)
+	if pos, ok := NextBracket(buf, models.Position{X: 9, Y: 1}); !ok || pos != (models.Position{X: 12, Y: 1}) {
+		t.Errorf("expected the bracket at {12 1}, got %v, %v", pos, ok)
+	}
+	if _, ok := NextBracket(buf, models.Position{Y: 3}); ok {
+		t.Errorf("expected no bracket in %q", pairText[3])
+	}
+}
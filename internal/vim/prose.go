@@ -0,0 +1,118 @@
+package vim
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// paragraph moves the cursor to the next empty line, or to the previous one going backward.
+// Like in Vim the empty lines the cursor is on are skipped first, and when there is no empty line
+// left the cursor stops on the last character of the Buffer, or on the first one going backward
+func (c *cursor) paragraph(forward bool) {
+	step := -1
+	if forward {
+		step = 1
+	}
+	y := c.pos.Y
+	next := func() bool {
+		if y+step < 0 || y+step >= c.buf.LineCount() {
+			return false
+		}
+		y += step
+		return true
+	}
+	isEmpty := func() bool { return len(c.buf.Line(y)) == 0 }
+
+	for isEmpty() && next() {
+	}
+	for !isEmpty() && next() {
+	}
+	switch {
+	case isEmpty():
+		c.pos = models.Position{Y: y}
+	case forward:
+		c.pos = models.Position{X: max(len(c.buf.Line(y))-1, 0), Y: y}
+	default:
+		c.pos = models.Position{}
+	}
+}
+
+// sentence moves the cursor to the start of the next sentence, or going backward to the start
+// of the sentence it is in, or of the previous one when it is at its start already.
+// Going forward past the last sentence the cursor stops on the last character of the Buffer
+func (c *cursor) sentence(forward bool) {
+	starts := sentenceStarts(c.buf)
+	i, found := slices.BinarySearchFunc(starts, c.pos, comparePositions)
+	if !forward {
+		if i > 0 {
+			c.pos = starts[i-1]
+		}
+		return
+	}
+	if found {
+		i++
+	}
+	if i < len(starts) {
+		c.pos = starts[i]
+		return
+	}
+	last := c.buf.LineCount() - 1
+	c.pos = models.Position{X: max(len(c.buf.Line(last))-1, 0), Y: last}
+}
+
+// sentenceStarts returns the positions sentences start at in the Buffer, in order. Like in Vim a sentence
+// ends at a '.', '!' or '?' that is followed by the end of the line, a space or a tab, with any closing
+// parentheses, brackets and quotes in between. An empty line is a sentence of its own, as it ends a paragraph,
+// and the text after it starts a new sentence
+func sentenceStarts(buf *Buffer) []models.Position {
+	var starts []models.Position
+	atStart := true
+	for y := range buf.LineCount() {
+		line := buf.Line(y)
+		if len(line) == 0 {
+			starts = append(starts, models.Position{Y: y})
+			atStart = true
+			continue
+		}
+		for x := 0; x < len(line); x++ {
+			r := line[x]
+			if r == ' ' || r == '\t' {
+				continue
+			}
+			if atStart {
+				starts = append(starts, models.Position{X: x, Y: y})
+				atStart = false
+			}
+			if r != '.' && r != '!' && r != '?' {
+				continue
+			}
+			end := x + 1
+			for end < len(line) && isSentenceCloser(line[end]) {
+				end++
+			}
+			if end == len(line) || line[end] == ' ' || line[end] == '\t' {
+				atStart = true
+				x = end - 1
+			}
+		}
+	}
+	return starts
+}
+
+// isSentenceCloser reports whether r can follow the punctuation at the end of a sentence
+func isSentenceCloser(r rune) bool {
+	return r == ')' || r == ']' || r == '"' || r == '\''
+}
+
+// comparePositions orders positions the way they appear in the Buffer
+func comparePositions(a, b models.Position) int {
+	switch {
+	case before(a, b):
+		return -1
+	case before(b, a):
+		return 1
+	default:
+		return 0
+	}
+}
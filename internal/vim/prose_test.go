@@ -0,0 +1,64 @@
+package vim
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// proseText has sentences that end inside a line, at the end of a line and after closing quotes,
+// and a dot that does not end a sentence
+var proseText = []string{
+	"  One sentence. Two (pi is 3.14)! Three",
+	"goes on here? \"Four.\" Five",
+	"",
+	"",
+	"Six",
+}
+
+func Test_SentenceStarts(t *testing.T) {
+	want := []models.Position{
+		{X: 2, Y: 0}, {X: 16, Y: 0}, {X: 34, Y: 0},
+		{X: 14, Y: 1}, {X: 22, Y: 1},
+		{X: 0, Y: 2}, {X: 0, Y: 3},
+		{X: 0, Y: 4},
+	}
+	if got := sentenceStarts(NewBuffer(proseText)); !slices.Equal(got, want) {
+		t.Errorf("expected sentence starts %v, got %v", want, got)
+	}
+}
+
+func Test_ResolveProse(t *testing.T) {
+	buf := NewBuffer(proseText)
+	tests := []struct {
+		name      string
+		start     models.Position
+		motion    models.Motion
+		wantPos   models.Position
+		wantMoved bool
+	}{
+		{name: ") to the next sentence", start: models.Position{X: 5, Y: 0}, motion: models.Motion{Kind: models.MotionSentenceForward}, wantPos: models.Position{X: 16, Y: 0}, wantMoved: true},
+		{name: ") over a line", start: models.Position{X: 34, Y: 0}, motion: models.Motion{Kind: models.MotionSentenceForward}, wantPos: models.Position{X: 14, Y: 1}, wantMoved: true},
+		{name: "count )", start: models.Position{X: 2, Y: 0}, motion: models.Motion{Kind: models.MotionSentenceForward, Count: 4}, wantPos: models.Position{X: 22, Y: 1}, wantMoved: true},
+		{name: ") stops at an empty line", start: models.Position{X: 22, Y: 1}, motion: models.Motion{Kind: models.MotionSentenceForward}, wantPos: models.Position{X: 0, Y: 2}, wantMoved: true},
+		{name: ") after the last sentence", start: models.Position{X: 0, Y: 4}, motion: models.Motion{Kind: models.MotionSentenceForward}, wantPos: models.Position{X: 2, Y: 4}, wantMoved: true},
+		{name: "( to the start of the sentence", start: models.Position{X: 20, Y: 0}, motion: models.Motion{Kind: models.MotionSentenceBackward}, wantPos: models.Position{X: 16, Y: 0}, wantMoved: true},
+		{name: "( at a start to the previous sentence", start: models.Position{X: 16, Y: 0}, motion: models.Motion{Kind: models.MotionSentenceBackward}, wantPos: models.Position{X: 2, Y: 0}, wantMoved: true},
+		{name: "( at the first sentence", start: models.Position{X: 2, Y: 0}, motion: models.Motion{Kind: models.MotionSentenceBackward}, wantPos: models.Position{X: 2, Y: 0}, wantMoved: false},
+		{name: "} to the empty line", start: models.Position{X: 5, Y: 0}, motion: models.Motion{Kind: models.MotionParagraphForward}, wantPos: models.Position{X: 0, Y: 2}, wantMoved: true},
+		{name: "} skips the empty lines it starts on", start: models.Position{X: 0, Y: 2}, motion: models.Motion{Kind: models.MotionParagraphForward}, wantPos: models.Position{X: 2, Y: 4}, wantMoved: true},
+		{name: "{ to the empty line", start: models.Position{X: 1, Y: 4}, motion: models.Motion{Kind: models.MotionParagraphBackward}, wantPos: models.Position{X: 0, Y: 3}, wantMoved: true},
+		{name: "{ without an empty line before", start: models.Position{X: 5, Y: 1}, motion: models.Motion{Kind: models.MotionParagraphBackward}, wantPos: models.Position{X: 0, Y: 0}, wantMoved: true},
+		{name: "count }", start: models.Position{X: 5, Y: 0}, motion: models.Motion{Kind: models.MotionParagraphForward, Count: 2}, wantPos: models.Position{X: 2, Y: 4}, wantMoved: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos, moved := Resolve(buf, tt.start, tt.motion)
+			if pos != tt.wantPos || moved != tt.wantMoved {
+				t.Errorf("expected %v, %v, got %v, %v", tt.wantPos, tt.wantMoved, pos, moved)
+			}
+		})
+	}
+}
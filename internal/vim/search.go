@@ -0,0 +1,77 @@
+package vim
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// RepeatSearch returns the search motion that n or N repeats based on the last search motion,
+// the count and keys are taken from the repeating motion
+func RepeatSearch(last, motion models.Motion) models.Motion {
+	kind := last.Kind
+	if motion.Kind == models.MotionSearchPrevious {
+		if kind == models.MotionSearchForward {
+			kind = models.MotionSearchBackward
+		} else {
+			kind = models.MotionSearchForward
+		}
+	}
+	return models.Motion{Kind: kind, Count: motion.Count, Pattern: last.Pattern, Keys: motion.Keys}
+}
+
+// search moves the cursor to the count'th match of the pattern and reports whether it was found,
+// like with 'wrapscan' the search continues at the other end of the Buffer
+func (c *cursor) search(pattern string, forward bool, count int) bool {
+	if pattern == "" {
+		return false
+	}
+	for range count {
+		pos, found := c.buf.search([]rune(pattern), c.pos, forward)
+		if !found {
+			return false
+		}
+		c.pos = pos
+	}
+	return true
+}
+
+// search returns the first match of the pattern after or before from,
+// the line of from is searched again at the end to find matches that wrapped around
+func (b *Buffer) search(pattern []rune, from models.Position, forward bool) (models.Position, bool) {
+	n := len(b.lines)
+	for i := 0; i <= n; i++ {
+		y := (from.Y + i) % n
+		if !forward {
+			y = ((from.Y-i)%n + n) % n
+		}
+
+		matches := b.matches(y, pattern)
+		if !forward {
+			slices.Reverse(matches)
+		}
+		for _, x := range matches {
+			switch {
+			case i == 0 && forward && x <= from.X,
+				i == 0 && !forward && x >= from.X,
+				i == n && forward && x > from.X,
+				i == n && !forward && x < from.X:
+				continue
+			}
+			return models.Position{X: x, Y: y}, true
+		}
+	}
+	return from, false
+}
+
+// matches returns the columns where the pattern starts in line y
+func (b *Buffer) matches(y int, pattern []rune) []int {
+	var columns []int
+	line := b.lines[y]
+	for x := 0; x+len(pattern) <= len(line); x++ {
+		if slices.Equal(line[x:x+len(pattern)], pattern) {
+			columns = append(columns, x)
+		}
+	}
+	return columns
+}
@@ -0,0 +1,100 @@
+package vim
+
+import (
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+func Test_ResolveSearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     models.Position
+		motion    models.Motion
+		wantPos   models.Position
+		wantMoved bool
+	}{
+		{
+			name:      "/ to next match",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionSearchForward, Pattern: "ba"},
+			wantPos:   models.Position{X: 4, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "count /",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionSearchForward, Pattern: "ba", Count: 2},
+			wantPos:   models.Position{X: 8, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "/ on another line",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionSearchForward, Pattern: "ue"},
+			wantPos:   models.Position{X: 9, Y: 2},
+			wantMoved: true,
+		},
+		{
+			name:      "/ wraps around the end",
+			start:     models.Position{X: 9, Y: 0},
+			motion:    models.Motion{Kind: models.MotionSearchForward, Pattern: "ba"},
+			wantPos:   models.Position{X: 4, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "? to previous match",
+			start:     models.Position{X: 0, Y: 3},
+			motion:    models.Motion{Kind: models.MotionSearchBackward, Pattern: "ba"},
+			wantPos:   models.Position{X: 8, Y: 0},
+			wantMoved: true,
+		},
+		{
+			name:      "? wraps around the start",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionSearchBackward, Pattern: "end"},
+			wantPos:   models.Position{X: 14, Y: 2},
+			wantMoved: true,
+		},
+		{
+			name:      "only match is under the cursor",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionSearchForward, Pattern: "foo"},
+			wantPos:   models.Position{X: 0, Y: 0},
+			wantMoved: false,
+		},
+		{
+			name:      "pattern not found",
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionSearchForward, Pattern: "zzz"},
+			wantPos:   models.Position{X: 0, Y: 0},
+			wantMoved: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, moved := Resolve(wordBuffer, tt.start, tt.motion)
+			if got != tt.wantPos {
+				t.Errorf("expected position %+v, got %+v", tt.wantPos, got)
+			}
+			if moved != tt.wantMoved {
+				t.Errorf("expected moved to be %v, got %v", tt.wantMoved, moved)
+			}
+		})
+	}
+}
+
+func Test_RepeatSearch(t *testing.T) {
+	last := models.Motion{Kind: models.MotionSearchBackward, Pattern: "ba", Keys: []string{"?"}}
+
+	got := RepeatSearch(last, models.Motion{Kind: models.MotionSearchNext, Keys: []string{"n"}})
+	if got.Kind != models.MotionSearchBackward || got.Pattern != "ba" {
+		t.Errorf("expected repeated ?, got %+v", got)
+	}
+
+	got = RepeatSearch(last, models.Motion{Kind: models.MotionSearchPrevious, Count: 2, Keys: []string{"2", "N"}})
+	if got.Kind != models.MotionSearchForward || got.Pattern != "ba" || got.Count != 2 {
+		t.Errorf("expected repeated / with count 2, got %+v", got)
+	}
+}
@@ -0,0 +1,398 @@
+package vim
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// quotes maps the quote text objects to the quote character that delimits them
+var quotes = map[models.TextObject]rune{
+	models.TextObjectInnerDoubleQuote: '"',
+	models.TextObjectADoubleQuote:     '"',
+	models.TextObjectInnerSingleQuote: '\'',
+	models.TextObjectASingleQuote:     '\'',
+	models.TextObjectInnerBacktick:    '`',
+	models.TextObjectABacktick:        '`',
+}
+
+// blocks maps the block text objects to the pair of brackets that delimits them
+var blocks = map[models.TextObject][2]rune{
+	models.TextObjectInnerParen:   {'(', ')'},
+	models.TextObjectAParen:       {'(', ')'},
+	models.TextObjectInnerBracket: {'[', ']'},
+	models.TextObjectABracket:     {'[', ']'},
+	models.TextObjectInnerBrace:   {'{', '}'},
+	models.TextObjectABrace:       {'{', '}'},
+	models.TextObjectInnerAngle:   {'<', '>'},
+	models.TextObjectAAngle:       {'<', '>'},
+}
+
+// TextObjectSpan returns the Span covered by the models.TextObject at pos, a count selects
+// that many objects. It reports false when there is no such object at pos
+func TextObjectSpan(buf *Buffer, pos models.Position, object models.TextObject, count int) (Span, bool) {
+	pos = buf.Clamp(pos)
+	count = max(count, 1)
+	around := !object.IsInner()
+
+	switch object {
+	case models.TextObjectInnerWord, models.TextObjectInnerWORD:
+		return wordObject(buf.Line(pos.Y), pos, count, object == models.TextObjectInnerWORD, false)
+	case models.TextObjectAWord, models.TextObjectAWORD:
+		return wordObject(buf.Line(pos.Y), pos, count, object == models.TextObjectAWORD, true)
+	case models.TextObjectInnerParagraph, models.TextObjectAParagraph:
+		return paragraphObject(buf, pos.Y, count, around)
+	case models.TextObjectInnerTag, models.TextObjectATag:
+		return tagObject(buf, pos, count, around)
+	}
+	if quote, ok := quotes[object]; ok {
+		return quoteObject(buf.Line(pos.Y), pos, quote, around)
+	}
+	if pair, ok := blocks[object]; ok {
+		return blockObject(buf, pos, pair[0], pair[1], count, around)
+	}
+	return Span{}, false
+}
+
+// wordObject returns the Span of count words on the line starting at the word under pos.
+// For an inner word the blanks between words count as words of their own, a word with
+// around includes the blanks after it, or the blanks before it when there are none after it
+func wordObject(line []rune, pos models.Position, count int, bigWord, around bool) (Span, bool) {
+	if len(line) == 0 {
+		return Span{}, false
+	}
+	isBlank := func(x int) bool { return charClass(line[x], bigWord) == classBlank }
+	runStart := func(x int) int {
+		for x > 0 && charClass(line[x-1], bigWord) == charClass(line[x], bigWord) {
+			x--
+		}
+		return x
+	}
+	runEnd := func(x int) int {
+		class := charClass(line[x], bigWord)
+		for x < len(line) && charClass(line[x], bigWord) == class {
+			x++
+		}
+		return x
+	}
+
+	start, end := runStart(pos.X), runEnd(pos.X)
+	if !around {
+		for i := 1; i < count && end < len(line); i++ {
+			end = runEnd(end)
+		}
+		return Span{Start: models.Position{X: start, Y: pos.Y}, End: models.Position{X: end, Y: pos.Y}}, true
+	}
+
+	startedOnBlank := isBlank(pos.X)
+	if startedOnBlank && end < len(line) {
+		// the blanks under the cursor come with the word after them
+		end = runEnd(end)
+	}
+	for i := 1; i < count && end < len(line); i++ {
+		if isBlank(end) {
+			end = runEnd(end)
+		}
+		if end < len(line) {
+			end = runEnd(end)
+		}
+	}
+	if !startedOnBlank {
+		switch {
+		case end < len(line) && isBlank(end):
+			end = runEnd(end)
+		case start > 0 && isBlank(start-1):
+			start = runStart(start - 1)
+		}
+	}
+	return Span{Start: models.Position{X: start, Y: pos.Y}, End: models.Position{X: end, Y: pos.Y}}, true
+}
+
+// quoteObject returns the Span of the quoted string on the line around pos, or of the first one after pos
+// when pos is not inside one. Like in Vim a quote escaped with a backslash does not count and the count
+// is ignored. A quoted string with around includes the blanks after it, or the blanks before it when there are none after it
+func quoteObject(line []rune, pos models.Position, quote rune, around bool) (Span, bool) {
+	var found []int
+	for x, r := range line {
+		if r == quote && (x == 0 || line[x-1] != '\\') {
+			found = append(found, x)
+		}
+	}
+
+	// an odd number of quotes before the cursor means it is inside a quoted string,
+	// or on the quote that closes it
+	i, _ := slices.BinarySearch(found, pos.X)
+	if i%2 == 1 {
+		i--
+	}
+	if i+1 >= len(found) {
+		return Span{}, false
+	}
+	start, end := found[i], found[i+1]+1
+
+	if !around {
+		if end-1 == start+1 {
+			return Span{}, false
+		}
+		return Span{Start: models.Position{X: start + 1, Y: pos.Y}, End: models.Position{X: end - 1, Y: pos.Y}}, true
+	}
+	isBlank := func(x int) bool { return line[x] == ' ' || line[x] == '\t' }
+	switch {
+	case end < len(line) && isBlank(end):
+		for end < len(line) && isBlank(end) {
+			end++
+		}
+	default:
+		for start > 0 && isBlank(start-1) {
+			start--
+		}
+	}
+	return Span{Start: models.Position{X: start, Y: pos.Y}, End: models.Position{X: end, Y: pos.Y}}, true
+}
+
+// blockObject returns the Span of the count-th block delimited by open and close around pos, nested blocks
+// count as one level each. Like in Vim an inner block that starts at the end of a line and ends on a line
+// of its own covers the whole lines in between
+func blockObject(buf *Buffer, pos models.Position, open, close rune, count int, around bool) (Span, bool) {
+	start := pos
+	// on a closing bracket the block is the one it closes
+	if buf.runeAt(pos) == close {
+		c := &cursor{buf: buf, pos: pos}
+		if c.dec() == stepEnd {
+			return Span{}, false
+		}
+		start = c.pos
+	}
+	for i := range count {
+		if i > 0 {
+			c := &cursor{buf: buf, pos: start}
+			if c.dec() == stepEnd {
+				return Span{}, false
+			}
+			start = c.pos
+		}
+		var ok bool
+		if start, ok = buf.unmatched(start, open, close, false); !ok {
+			return Span{}, false
+		}
+	}
+	c := &cursor{buf: buf, pos: start}
+	if c.inc() == stepEnd {
+		return Span{}, false
+	}
+	end, ok := buf.unmatched(c.pos, open, close, true)
+	if !ok {
+		return Span{}, false
+	}
+
+	if around {
+		return Span{Start: start, End: models.Position{X: end.X + 1, Y: end.Y}}, true
+	}
+	inner := Span{Start: models.Position{X: start.X + 1, Y: start.Y}, End: end}
+	endCursor := &cursor{buf: buf, pos: end}
+	if start.X == len(buf.Line(start.Y))-1 && end.Y > start.Y {
+		inner.Start = models.Position{Y: start.Y + 1}
+		if endCursor.onlyBlanksBefore() {
+			if end.Y == start.Y+1 {
+				return Span{}, false
+			}
+			return Span{Start: inner.Start, End: models.Position{Y: end.Y - 1}, Linewise: true}, true
+		}
+	}
+	return inner, before(inner.Start, inner.End)
+}
+
+// unmatched returns the position of the first bracket from pos on that is not matched by a bracket
+// between it and pos, it looks for a close bracket going forward and an open bracket going backward
+func (b *Buffer) unmatched(pos models.Position, open, close rune, forward bool) (models.Position, bool) {
+	want, other := open, close
+	c := &cursor{buf: b, pos: pos}
+	step := c.dec
+	if forward {
+		want, other = close, open
+		step = c.inc
+	}
+	depth := 0
+	for {
+		switch b.runeAt(c.pos) {
+		case want:
+			if depth == 0 {
+				return c.pos, true
+			}
+			depth--
+		case other:
+			depth++
+		}
+		if step() == stepEnd {
+			return models.Position{}, false
+		}
+	}
+}
+
+// runeAt returns the character at pos, or zero at the end of a line
+func (b *Buffer) runeAt(pos models.Position) rune {
+	line := b.Line(pos.Y)
+	if pos.X < 0 || pos.X >= len(line) {
+		return 0
+	}
+	return line[pos.X]
+}
+
+// paragraphObject returns the linewise Span of count paragraphs starting at the paragraph of line y.
+// Lines with only blanks separate paragraphs, for an inner paragraph a run of them counts as a paragraph
+// of its own. A paragraph with around includes the blank lines after it, or the ones before it when there are none after it
+func paragraphObject(buf *Buffer, y, count int, around bool) (Span, bool) {
+	isBlank := func(y int) bool { return strings.TrimLeft(string(buf.Line(y)), " \t") == "" }
+	last := buf.LineCount() - 1
+	runStart := func(y int) int {
+		for y > 0 && isBlank(y-1) == isBlank(y) {
+			y--
+		}
+		return y
+	}
+	runEnd := func(y int) int {
+		for y < last && isBlank(y+1) == isBlank(y) {
+			y++
+		}
+		return y
+	}
+
+	start, end := runStart(y), runEnd(y)
+	if !around {
+		for i := 1; i < count; i++ {
+			if end == last {
+				return Span{}, false
+			}
+			end = runEnd(end + 1)
+		}
+		return Span{Start: models.Position{Y: start}, End: models.Position{Y: end}, Linewise: true}, true
+	}
+
+	startedOnBlank := isBlank(y)
+	for i := range count {
+		if i > 0 || startedOnBlank {
+			// the blank lines come with the paragraph after them
+			if end == last {
+				return Span{}, false
+			}
+			end = runEnd(end + 1)
+		}
+		if !startedOnBlank && end < last {
+			end = runEnd(end + 1)
+		}
+	}
+	if !startedOnBlank && !isBlank(end) && start > 0 {
+		start = runStart(start - 1)
+	}
+	return Span{Start: models.Position{Y: start}, End: models.Position{Y: end}, Linewise: true}, true
+}
+
+// tag is an opening or closing tag in the text, like <b> or </b>, from and to are offsets in the text
+type tag struct {
+	name    string
+	closing bool
+	from    int
+	to      int
+}
+
+// tagObject returns the Span of the count-th pair of matching tags around pos, like <b> and </b>.
+// An inner tag block covers the text between the tags, with around the tags are included
+func tagObject(buf *Buffer, pos models.Position, count int, around bool) (Span, bool) {
+	text, starts := buf.flatten()
+	offset := starts[pos.Y] + pos.X
+
+	// pairs holds the opening and closing tag of every matched pair, the innermost pair around the cursor comes last
+	var pairs [][2]tag
+	var open []tag
+	for _, t := range parseTags(text) {
+		if !t.closing {
+			open = append(open, t)
+			continue
+		}
+		// like in Vim an opening tag without a closing tag is skipped
+		for i := len(open) - 1; i >= 0; i-- {
+			if open[i].name == t.name {
+				if open[i].from <= offset && offset < t.to {
+					pairs = append(pairs, [2]tag{open[i], t})
+				}
+				open = open[:i]
+				break
+			}
+		}
+	}
+	slices.SortFunc(pairs, func(a, b [2]tag) int { return a[0].from - b[0].from })
+	if count > len(pairs) {
+		return Span{}, false
+	}
+
+	pair := pairs[len(pairs)-count]
+	from, to := pair[0].from, pair[1].to
+	if !around {
+		from, to = pair[0].to, pair[1].from
+	}
+	if from == to {
+		return Span{}, false
+	}
+	return Span{Start: position(starts, from), End: position(starts, to)}, true
+}
+
+// parseTags returns the opening and closing tags in the text in the order they appear in,
+// tags that close themselves like <br/> are left out
+func parseTags(text []rune) []tag {
+	var tags []tag
+	for from := 0; from < len(text); from++ {
+		if text[from] != '<' {
+			continue
+		}
+		x := from + 1
+		closing := x < len(text) && text[x] == '/'
+		if closing {
+			x++
+		}
+		nameStart := x
+		for x < len(text) && (unicode.IsLetter(text[x]) || unicode.IsDigit(text[x]) || text[x] == '-') {
+			x++
+		}
+		if x == nameStart || !unicode.IsLetter(text[nameStart]) {
+			continue
+		}
+		name := string(text[nameStart:x])
+		for x < len(text) && text[x] != '>' && text[x] != '<' {
+			x++
+		}
+		if x == len(text) || text[x] != '>' {
+			continue
+		}
+		if text[x-1] != '/' {
+			tags = append(tags, tag{name: name, closing: closing, from: from, to: x + 1})
+		}
+		from = x
+	}
+	return tags
+}
+
+// flatten returns the text of the Buffer with a newline after every line but the last,
+// together with the offset in the text every line starts at
+func (b *Buffer) flatten() ([]rune, []int) {
+	var text []rune
+	starts := make([]int, len(b.lines))
+	for y, line := range b.lines {
+		if y > 0 {
+			text = append(text, '\n')
+		}
+		starts[y] = len(text)
+		text = append(text, line...)
+	}
+	return text, starts
+}
+
+// position returns the position of the offset in a text flattened from a Buffer
+func position(starts []int, offset int) models.Position {
+	y, found := slices.BinarySearch(starts, offset)
+	if !found {
+		y--
+	}
+	return models.Position{X: offset - starts[y], Y: y}
+}
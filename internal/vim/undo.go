@@ -0,0 +1,89 @@
+package vim
+
+import (
+	"slices"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// UndoTree keeps the text as it is after every change, like the undo tree of Vim a change
+// made after undoing starts a new branch and the changes that were undone are kept.
+// The first change is the text the tree started with
+type UndoTree struct {
+	changes []models.Change
+	current int
+}
+
+// NewUndoTree creates an UndoTree that starts with the given lines
+func NewUndoTree(lines []string) *UndoTree {
+	return &UndoTree{changes: []models.Change{{Parent: -1, Lines: slices.Clone(lines)}}}
+}
+
+// RestoreUndoTree creates an UndoTree from saved changes, it reports false when the changes
+// do not form a tree, which is when a parent does not come before its change
+func RestoreUndoTree(changes []models.Change, current int) (*UndoTree, bool) {
+	if len(changes) == 0 || changes[0].Parent != -1 || current < 0 || current >= len(changes) {
+		return nil, false
+	}
+	for i, change := range changes[1:] {
+		if change.Parent < 0 || change.Parent > i {
+			return nil, false
+		}
+	}
+	return &UndoTree{changes: slices.Clone(changes), current: current}, true
+}
+
+// Commit adds the lines as a change on top of the current one, cursor is where the change was made.
+// Nothing is added when the lines are the same as the current ones
+func (t *UndoTree) Commit(lines []string, cursor models.Position) {
+	if slices.Equal(lines, t.Lines()) {
+		return
+	}
+	t.changes = append(t.changes, models.Change{Parent: t.current, Lines: slices.Clone(lines), Cursor: cursor})
+	t.current = len(t.changes) - 1
+}
+
+// Undo goes back to the text before the current change, it returns where the change was made
+// and reports false when there is no change to undo
+func (t *UndoTree) Undo() (models.Position, bool) {
+	if t.current == 0 {
+		return models.Position{}, false
+	}
+	change := t.changes[t.current]
+	t.current = change.Parent
+	return change.Cursor, true
+}
+
+// Redo goes forward to the newest change made on the current text, it returns where the change
+// was made and reports false when there is no change to redo
+func (t *UndoTree) Redo() (models.Position, bool) {
+	for i := len(t.changes) - 1; i > t.current; i-- {
+		if t.changes[i].Parent == t.current {
+			t.current = i
+			return t.changes[i].Cursor, true
+		}
+	}
+	return models.Position{}, false
+}
+
+// Revert makes the change at index the current one again without removing the changes after it
+func (t *UndoTree) Revert(index int) {
+	if index >= 0 && index < len(t.changes) {
+		t.current = index
+	}
+}
+
+// Current returns the index of the current change
+func (t *UndoTree) Current() int {
+	return t.current
+}
+
+// Lines returns the text as it is after the current change
+func (t *UndoTree) Lines() []string {
+	return slices.Clone(t.changes[t.current].Lines)
+}
+
+// Changes returns all changes in the order they were made
+func (t *UndoTree) Changes() []models.Change {
+	return slices.Clone(t.changes)
+}
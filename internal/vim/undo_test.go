@@ -0,0 +1,79 @@
+package vim
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+func Test_UndoTree(t *testing.T) {
+	tree := NewUndoTree([]string{"one"})
+	tree.Commit([]string{"one"}, models.Position{})
+	if len(tree.Changes()) != 1 {
+		t.Fatalf("expected lines that did not change not to be committed, got %d changes", len(tree.Changes()))
+	}
+
+	tree.Commit([]string{"one two"}, models.Position{X: 3})
+	tree.Commit([]string{"one two three"}, models.Position{X: 7})
+	if pos, ok := tree.Undo(); !ok || pos != (models.Position{X: 7}) {
+		t.Fatalf("expected undo to return where the change was made, got %v, %v", pos, ok)
+	}
+	if want := []string{"one two"}; !slices.Equal(tree.Lines(), want) {
+		t.Errorf("expected lines %q after undo, got %q", want, tree.Lines())
+	}
+
+	// a change after undoing starts a new branch, redo follows the newest one
+	tree.Commit([]string{"one two four"}, models.Position{X: 7})
+	tree.Undo()
+	if _, ok := tree.Redo(); !ok {
+		t.Fatalf("expected a change to redo")
+	}
+	if want := []string{"one two four"}; !slices.Equal(tree.Lines(), want) {
+		t.Errorf("expected lines %q after redo, got %q", want, tree.Lines())
+	}
+	if _, ok := tree.Redo(); ok {
+		t.Errorf("expected no change to redo at the newest change")
+	}
+
+	tree.Undo()
+	tree.Undo()
+	if _, ok := tree.Undo(); ok || tree.Current() != 0 {
+		t.Errorf("expected no change to undo at the oldest change, at change %d", tree.Current())
+	}
+	if len(tree.Changes()) != 4 {
+		t.Errorf("expected undone changes to be kept, got %d changes", len(tree.Changes()))
+	}
+
+	tree.Revert(3)
+	if want := []string{"one two four"}; !slices.Equal(tree.Lines(), want) {
+		t.Errorf("expected lines %q after revert, got %q", want, tree.Lines())
+	}
+}
+
+func Test_RestoreUndoTree(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []models.Change
+		current int
+		wantOK  bool
+	}{
+		{name: "tree", changes: []models.Change{{Parent: -1}, {Parent: 0}, {Parent: 0}, {Parent: 2}}, current: 3, wantOK: true},
+		{name: "no changes", changes: nil, current: 0, wantOK: false},
+		{name: "root with a parent", changes: []models.Change{{Parent: 0}}, current: 0, wantOK: false},
+		{name: "parent after its change", changes: []models.Change{{Parent: -1}, {Parent: 2}, {Parent: 0}}, current: 0, wantOK: false},
+		{name: "current out of range", changes: []models.Change{{Parent: -1}}, current: 1, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, ok := RestoreUndoTree(tt.changes, tt.current)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok to be %v, got %v", tt.wantOK, ok)
+			}
+			if ok && tree.Current() != tt.current {
+				t.Errorf("expected current change %d, got %d", tt.current, tree.Current())
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package vim
+
+import "github.com/dasvh/go-learn-vim/internal/models"
+
+// VisualSpan returns the Span selected in the models.VisualMode from the anchor, where visual mode
+// started, up to the cursor. Like in Vim the selection includes the characters at both ends
+func VisualSpan(buf *Buffer, anchor, cursor models.Position, mode models.VisualMode) Span {
+	anchor, cursor = buf.Clamp(anchor), buf.Clamp(cursor)
+
+	switch mode {
+	case models.VisualLine:
+		return Span{
+			Start:    models.Position{Y: min(anchor.Y, cursor.Y)},
+			End:      models.Position{Y: max(anchor.Y, cursor.Y)},
+			Linewise: true,
+		}
+	case models.VisualBlock:
+		return Span{
+			Start: models.Position{X: min(anchor.X, cursor.X), Y: min(anchor.Y, cursor.Y)},
+			End:   models.Position{X: max(anchor.X, cursor.X) + 1, Y: max(anchor.Y, cursor.Y)},
+			Block: true,
+		}
+	default:
+		start, end := anchor, cursor
+		if before(end, start) {
+			start, end = end, start
+		}
+		end.X = min(end.X+1, len(buf.Line(end.Y)))
+		return Span{Start: start, End: end}
+	}
+}
+
+// Covers reports whether the position is inside the Span
+func (s Span) Covers(pos models.Position) bool {
+	switch {
+	case pos.Y < s.Start.Y || pos.Y > s.End.Y:
+		return false
+	case s.Linewise:
+		return true
+	case s.Block:
+		return pos.X >= s.Start.X && pos.X < s.End.X
+	default:
+		return !before(pos, s.Start) && before(pos, s.End)
+	}
+}
@@ -0,0 +1,115 @@
+package vim
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+func Test_VisualSpan(t *testing.T) {
+	tests := []struct {
+		name         string
+		anchor       models.Position
+		cursor       models.Position
+		mode         models.VisualMode
+		op           models.Operator
+		wantLines    []string
+		wantRegister string
+		wantPos      models.Position
+	}{
+		{
+			name:         "v includes the character under the cursor",
+			anchor:       models.Position{X: 4, Y: 0},
+			cursor:       models.Position{X: 7, Y: 0},
+			mode:         models.VisualChar,
+			op:           models.OperatorDelete,
+			wantLines:    []string{"one three", "four five", "", "  six seven"},
+			wantRegister: "two ",
+			wantPos:      models.Position{X: 4, Y: 0},
+		},
+		{
+			name:         "v backwards over lines",
+			anchor:       models.Position{X: 3, Y: 1},
+			cursor:       models.Position{X: 8, Y: 0},
+			mode:         models.VisualChar,
+			op:           models.OperatorDelete,
+			wantLines:    []string{"one two  five", "", "  six seven"},
+			wantRegister: "three\nfour",
+			wantPos:      models.Position{X: 8, Y: 0},
+		},
+		{
+			name:         "V yanks whole lines",
+			anchor:       models.Position{X: 5, Y: 1},
+			cursor:       models.Position{X: 0, Y: 0},
+			mode:         models.VisualLine,
+			op:           models.OperatorYank,
+			wantLines:    operatorText,
+			wantRegister: "one two three\nfour five\n",
+			wantPos:      models.Position{X: 0, Y: 0},
+		},
+		{
+			name:         "ctrl+v deletes a block",
+			anchor:       models.Position{X: 1, Y: 0},
+			cursor:       models.Position{X: 2, Y: 1},
+			mode:         models.VisualBlock,
+			op:           models.OperatorDelete,
+			wantLines:    []string{"o two three", "fr five", "", "  six seven"},
+			wantRegister: "ne\nou",
+			wantPos:      models.Position{X: 1, Y: 0},
+		},
+		{
+			name:         "a block past the end of short lines",
+			anchor:       models.Position{X: 3, Y: 1},
+			cursor:       models.Position{X: 5, Y: 3},
+			mode:         models.VisualBlock,
+			op:           models.OperatorDelete,
+			wantLines:    []string{"one two three", "fouive", "", "  sseven"},
+			wantRegister: "r f\n\nix ",
+			wantPos:      models.Position{X: 3, Y: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := NewBuffer(operatorText)
+			span := VisualSpan(buf, tt.anchor, tt.cursor, tt.mode)
+			register, pos := Operate(buf, tt.cursor, span, tt.op)
+			if !slices.Equal(buf.Lines(), tt.wantLines) {
+				t.Errorf("expected lines %q, got %q", tt.wantLines, buf.Lines())
+			}
+			if register.Text != tt.wantRegister {
+				t.Errorf("expected register %q, got %q", tt.wantRegister, register.Text)
+			}
+			if pos != tt.wantPos {
+				t.Errorf("expected cursor at %v, got %v", tt.wantPos, pos)
+			}
+		})
+	}
+}
+
+func Test_SpanCovers(t *testing.T) {
+	buf := NewBuffer(operatorText)
+	tests := []struct {
+		name string
+		span Span
+		pos  models.Position
+		want bool
+	}{
+		{name: "charwise start", span: VisualSpan(buf, models.Position{X: 4}, models.Position{X: 1, Y: 1}, models.VisualChar), pos: models.Position{X: 4}, want: true},
+		{name: "charwise before start", span: VisualSpan(buf, models.Position{X: 4}, models.Position{X: 1, Y: 1}, models.VisualChar), pos: models.Position{X: 3}, want: false},
+		{name: "charwise end", span: VisualSpan(buf, models.Position{X: 4}, models.Position{X: 1, Y: 1}, models.VisualChar), pos: models.Position{X: 1, Y: 1}, want: true},
+		{name: "charwise after end", span: VisualSpan(buf, models.Position{X: 4}, models.Position{X: 1, Y: 1}, models.VisualChar), pos: models.Position{X: 2, Y: 1}, want: false},
+		{name: "linewise", span: VisualSpan(buf, models.Position{X: 4}, models.Position{Y: 1}, models.VisualLine), pos: models.Position{X: 8, Y: 1}, want: true},
+		{name: "block inside", span: VisualSpan(buf, models.Position{X: 4}, models.Position{X: 1, Y: 1}, models.VisualBlock), pos: models.Position{X: 2, Y: 1}, want: true},
+		{name: "block outside", span: VisualSpan(buf, models.Position{X: 4}, models.Position{X: 1, Y: 1}, models.VisualBlock), pos: models.Position{X: 5}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.span.Covers(tt.pos); got != tt.want {
+				t.Errorf("expected Covers(%v) to be %v, got %v", tt.pos, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package vim
+
+import "github.com/dasvh/go-learn-vim/internal/models"
+
+// Window represents the lines of a Buffer that are visible,
+// it starts at the line Top and shows Height lines
+type Window struct {
+	Top    int
+	Height int
+}
+
+// NewWindow creates a Window that shows height lines from the top of a Buffer
+func NewWindow(height int) *Window {
+	return &Window{Top: 0, Height: max(height, 1)}
+}
+
+// Bottom returns the last line of the Window that is part of the Buffer
+func (w *Window) Bottom(buf *Buffer) int {
+	return min(w.Top+w.Height, buf.LineCount()) - 1
+}
+
+// Follow scrolls the Window as little as possible to make the line y visible
+func (w *Window) Follow(buf *Buffer, y int) {
+	if y < w.Top {
+		w.Top = y
+	}
+	if y >= w.Top+w.Height {
+		w.Top = y - w.Height + 1
+	}
+	w.clamp(buf)
+}
+
+// Resolve returns the position reached by applying the models.Motion from pos inside the Window
+// and whether the cursor or the Window was moved, motions that are not relative to the Window are
+// passed on to Resolve and the Window follows the cursor
+func (w *Window) Resolve(buf *Buffer, pos models.Position, motion models.Motion) (models.Position, bool) {
+	start := buf.Clamp(pos)
+	top := w.Top
+	count := max(motion.Count, 1)
+	target := start
+
+	switch motion.Kind {
+	case models.MotionScreenTop:
+		target.Y = min(w.Top+count-1, w.Bottom(buf))
+	case models.MotionScreenMiddle:
+		target.Y = w.Top + (w.Bottom(buf)-w.Top)/2
+	case models.MotionScreenBottom:
+		target.Y = max(w.Bottom(buf)-count+1, w.Top)
+	case models.MotionHalfPageDown, models.MotionHalfPageUp:
+		// like the 'scroll' option a count sets the number of lines to scroll
+		lines := w.Height / 2
+		if motion.Count > 0 {
+			lines = motion.Count
+		}
+		if motion.Kind == models.MotionHalfPageUp {
+			lines = -lines
+		}
+		w.Top += lines
+		w.clamp(buf)
+		target.Y += lines
+	case models.MotionPageDown:
+		// like in Vim two lines of the previous page stay visible
+		w.Top += count * max(w.Height-2, 1)
+		w.clamp(buf)
+		target.Y = max(target.Y, w.Top)
+	case models.MotionPageUp:
+		w.Top -= count * max(w.Height-2, 1)
+		w.clamp(buf)
+		target.Y = min(target.Y, w.Top+w.Height-1)
+	case models.MotionScrollCenter:
+		w.Top = start.Y - w.Height/2
+	case models.MotionScrollTop:
+		w.Top = start.Y
+	case models.MotionScrollBottom:
+		w.Top = start.Y - w.Height + 1
+	default:
+		end, moved := Resolve(buf, pos, motion)
+		w.Follow(buf, end.Y)
+		return end, moved
+	}
+
+	end := buf.stopAtWall(start, buf.Clamp(target))
+	w.Follow(buf, end.Y)
+	return end, end != pos || w.Top != top
+}
+
+// clamp keeps the Window inside the Buffer
+func (w *Window) clamp(buf *Buffer) {
+	w.Top = min(max(w.Top, 0), max(buf.LineCount()-w.Height, 0))
+}
@@ -0,0 +1,164 @@
+package vim
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dasvh/go-learn-vim/internal/models"
+)
+
+// longBuffer returns a Buffer with the given number of lines
+func longBuffer(lines int) *Buffer {
+	content := make([]string, lines)
+	for y := range content {
+		content[y] = fmt.Sprintf("line %d", y+1)
+	}
+	return NewBuffer(content)
+}
+
+func Test_WindowResolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		top       int
+		start     models.Position
+		motion    models.Motion
+		wantPos   models.Position
+		wantTop   int
+		wantMoved bool
+	}{
+		{
+			name:      "H to top of window",
+			top:       5,
+			start:     models.Position{X: 3, Y: 10},
+			motion:    models.Motion{Kind: models.MotionScreenTop},
+			wantPos:   models.Position{X: 3, Y: 5},
+			wantTop:   5,
+			wantMoved: true,
+		},
+		{
+			name:      "count H",
+			top:       5,
+			start:     models.Position{X: 3, Y: 10},
+			motion:    models.Motion{Kind: models.MotionScreenTop, Count: 3},
+			wantPos:   models.Position{X: 3, Y: 7},
+			wantTop:   5,
+			wantMoved: true,
+		},
+		{
+			name:      "M to middle of window",
+			top:       5,
+			start:     models.Position{X: 0, Y: 5},
+			motion:    models.Motion{Kind: models.MotionScreenMiddle},
+			wantPos:   models.Position{X: 0, Y: 9},
+			wantTop:   5,
+			wantMoved: true,
+		},
+		{
+			name:      "L to bottom of window",
+			top:       5,
+			start:     models.Position{X: 0, Y: 5},
+			motion:    models.Motion{Kind: models.MotionScreenBottom},
+			wantPos:   models.Position{X: 0, Y: 14},
+			wantTop:   5,
+			wantMoved: true,
+		},
+		{
+			name:      "ctrl+d scrolls half a window",
+			top:       0,
+			start:     models.Position{X: 0, Y: 2},
+			motion:    models.Motion{Kind: models.MotionHalfPageDown},
+			wantPos:   models.Position{X: 0, Y: 7},
+			wantTop:   5,
+			wantMoved: true,
+		},
+		{
+			name:      "ctrl+u at top of buffer still moves the cursor",
+			top:       0,
+			start:     models.Position{X: 0, Y: 7},
+			motion:    models.Motion{Kind: models.MotionHalfPageUp},
+			wantPos:   models.Position{X: 0, Y: 2},
+			wantTop:   0,
+			wantMoved: true,
+		},
+		{
+			name:      "ctrl+d on last line fails",
+			top:       20,
+			start:     models.Position{X: 0, Y: 29},
+			motion:    models.Motion{Kind: models.MotionHalfPageDown},
+			wantPos:   models.Position{X: 0, Y: 29},
+			wantTop:   20,
+			wantMoved: false,
+		},
+		{
+			name:      "ctrl+f keeps two lines visible",
+			top:       0,
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionPageDown},
+			wantPos:   models.Position{X: 0, Y: 8},
+			wantTop:   8,
+			wantMoved: true,
+		},
+		{
+			name:      "ctrl+b moves cursor into window",
+			top:       16,
+			start:     models.Position{X: 0, Y: 20},
+			motion:    models.Motion{Kind: models.MotionPageUp},
+			wantPos:   models.Position{X: 0, Y: 17},
+			wantTop:   8,
+			wantMoved: true,
+		},
+		{
+			name:      "zz centers cursor line",
+			top:       0,
+			start:     models.Position{X: 2, Y: 9},
+			motion:    models.Motion{Kind: models.MotionScrollCenter},
+			wantPos:   models.Position{X: 2, Y: 9},
+			wantTop:   4,
+			wantMoved: true,
+		},
+		{
+			name:      "zt puts cursor line at top",
+			top:       0,
+			start:     models.Position{X: 2, Y: 9},
+			motion:    models.Motion{Kind: models.MotionScrollTop},
+			wantPos:   models.Position{X: 2, Y: 9},
+			wantTop:   9,
+			wantMoved: true,
+		},
+		{
+			name:      "zb at top of buffer does not scroll",
+			top:       0,
+			start:     models.Position{X: 2, Y: 3},
+			motion:    models.Motion{Kind: models.MotionScrollBottom},
+			wantPos:   models.Position{X: 2, Y: 3},
+			wantTop:   0,
+			wantMoved: false,
+		},
+		{
+			name:      "window follows G",
+			top:       0,
+			start:     models.Position{X: 0, Y: 0},
+			motion:    models.Motion{Kind: models.MotionLastLine},
+			wantPos:   models.Position{X: 0, Y: 29},
+			wantTop:   20,
+			wantMoved: true,
+		},
+	}
+
+	buf := longBuffer(30)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Window{Top: tt.top, Height: 10}
+			got, moved := w.Resolve(buf, tt.start, tt.motion)
+			if got != tt.wantPos {
+				t.Errorf("expected position %+v, got %+v", tt.wantPos, got)
+			}
+			if w.Top != tt.wantTop {
+				t.Errorf("expected top %d, got %d", tt.wantTop, w.Top)
+			}
+			if moved != tt.wantMoved {
+				t.Errorf("expected moved to be %v, got %v", tt.wantMoved, moved)
+			}
+		})
+	}
+}
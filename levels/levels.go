@@ -0,0 +1,9 @@
+// Package levels holds the level packs that are embedded in the application
+package levels
+
+import "embed"
+
+// Packs holds a directory for every embedded level pack
+//
+//go:embed */*.toml
+var Packs embed.FS